@@ -0,0 +1,18 @@
+package blob
+
+import "io"
+
+// Storage keeps the content of attachments, keys are slash separated paths like cars/{carId}/{attachmentId}
+type Storage interface {
+	// Put stores the content read from r under key, replacing the content stored before
+	Put(key string, r io.Reader) error
+
+	// Open returns a reader of the content stored under key, the caller must close it
+	Open(key string) (io.ReadCloser, error)
+
+	// Delete deletes the content stored under key, deleting missing content is not an error
+	Delete(key string) error
+
+	// DeleteDir deletes the content stored under all keys starting with dir + "/"
+	DeleteDir(dir string) error
+}
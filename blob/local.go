@@ -0,0 +1,95 @@
+package blob
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type local struct {
+	dir string
+}
+
+// NewLocal returns a storage which keeps every key as a file below dir
+//
+//nolint:revive //local should not be exported
+func NewLocal(dir string) local {
+	return local{dir: dir}
+}
+
+func (l local) Put(key string, r io.Reader) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(name), 0o750)
+	if err != nil {
+		return err
+	}
+
+	// the content is written to a temporary file first, so that readers never see partial content
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+func (l local) Open(key string) (io.ReadCloser, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(name)
+}
+
+func (l local) Delete(key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(name)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (l local) DeleteDir(dir string) error {
+	name, err := l.path(dir)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(name)
+}
+
+// path maps key to its file, keys which are not clean relative paths are rejected so that no file outside dir is used
+func (l local) path(key string) (string, error) {
+	if key == "" || path.Clean(key) != key || path.IsAbs(key) || key == ".." || strings.HasPrefix(key, "../") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
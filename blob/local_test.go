@@ -0,0 +1,57 @@
+package blob
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal(t *testing.T) {
+	storage := NewLocal(t.TempDir())
+
+	err := storage.Put("cars/1/a1", strings.NewReader("photo"))
+	assert.Nil(t, err)
+
+	err = storage.Put("cars/1/a1-thumbnail", strings.NewReader("thumbnail"))
+	assert.Nil(t, err)
+
+	err = storage.Put("cars/2/a2", strings.NewReader("document"))
+	assert.Nil(t, err)
+
+	r, err := storage.Open("cars/1/a1")
+	assert.Nil(t, err)
+
+	content, _ := io.ReadAll(r)
+	r.Close()
+
+	assert.Equal(t, "photo", string(content))
+
+	// the thumbnail is deleted, deleting it again is not an error
+	assert.Nil(t, storage.Delete("cars/1/a1-thumbnail"))
+	assert.Nil(t, storage.Delete("cars/1/a1-thumbnail"))
+
+	// deleting the content of a car leaves the content of other cars
+	assert.Nil(t, storage.DeleteDir("cars/1"))
+
+	_, err = storage.Open("cars/1/a1")
+	assert.True(t, os.IsNotExist(err))
+
+	r, err = storage.Open("cars/2/a2")
+	assert.Nil(t, err)
+	r.Close()
+}
+
+func TestLocal_InvalidKey(t *testing.T) {
+	storage := NewLocal(t.TempDir())
+
+	keys := []string{"", "/etc/passwd", "../secret", "..", "cars/../../secret", "cars//1", "cars/1/"}
+
+	for i, key := range keys {
+		err := storage.Put(key, strings.NewReader("content"))
+
+		assert.Errorf(t, err, "Testcase[%v] (%v)", i, key)
+	}
+}
@@ -0,0 +1,187 @@
+// Package carcsv reads and writes cars as CSV files whose header names the car param of every column.
+package carcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+// column maps a car param to its field, field returns a pointer to the string, int or float64 it is stored in
+type column struct {
+	param  string
+	engine bool
+	field  func(car *model.Car) interface{}
+}
+
+// path returns the JSON pointer of the param of c, engine params are nested under /engine
+func (c column) path() string {
+	if c.engine {
+		return "/engine/" + c.param
+	}
+
+	return "/" + c.param
+}
+
+// columns lists the columns a car can be imported from, in the order they are written
+func columns() []column {
+	return []column{
+		{model.ParamName, false, func(car *model.Car) interface{} { return &car.Name }},
+		{model.ParamYearOfManufacture, false, func(car *model.Car) interface{} { return &car.YearOfManufacture }},
+		{model.ParamBrand, false, func(car *model.Car) interface{} { return &car.Brand }},
+		{model.ParamFuelType, false, func(car *model.Car) interface{} { return &car.FuelType }},
+		{model.ParamTrimID, false, func(car *model.Car) interface{} { return &car.TrimID }},
+		{model.ParamVIN, false, func(car *model.Car) interface{} { return &car.VIN }},
+		{model.ParamStatus, false, func(car *model.Car) interface{} { return (*string)(&car.Status) }},
+		{model.ParamDisplacement, true, func(car *model.Car) interface{} { return &car.Engine.Displacement }},
+		{model.ParamNoOfCylinders, true, func(car *model.Car) interface{} { return &car.Engine.NoOfCylinders }},
+		{model.ParamRange, true, func(car *model.Car) interface{} { return &car.Engine.Range }},
+		{model.ParamBatteryCapacity, true, func(car *model.Car) interface{} { return &car.Engine.BatteryCapacity }},
+		{model.ParamElectricRange, true, func(car *model.Car) interface{} { return &car.Engine.ElectricRange }},
+		{model.ParamTankCapacity, true, func(car *model.Car) interface{} { return &car.Engine.TankCapacity }},
+		{model.ParamNoOfMotors, true, func(car *model.Car) interface{} { return &car.Engine.NoOfMotors }},
+		{model.ParamPower, true, func(car *model.Car) interface{} { return &car.Engine.Power }},
+		{model.ParamTorque, true, func(car *model.Car) interface{} { return &car.Engine.Torque }},
+		{model.ParamTransmission, true, func(car *model.Car) interface{} { return &car.Engine.Transmission }},
+		{model.ParamGears, true, func(car *model.Car) interface{} { return &car.Engine.Gears }},
+		{model.ParamDrivetrain, true, func(car *model.Car) interface{} { return &car.Engine.Drivetrain }},
+	}
+}
+
+// Params lists the params which can be used as column names
+func Params() []string {
+	cols := columns()
+
+	params := make([]string, len(cols))
+	for i := range cols {
+		params[i] = cols[i].param
+	}
+
+	return params
+}
+
+// Decoder reads cars from a CSV one row at a time, so that large files are never held in memory
+type Decoder struct {
+	r       *csv.Reader
+	columns []column
+	line    int
+}
+
+// NewDecoder reads the header of the CSV of r, every column must be named by a car param.
+// Names are matched case-insensitively and surrounding spaces are ignored
+func NewDecoder(r io.Reader) (*Decoder, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the CSV has no header")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]column)
+	for _, c := range columns() {
+		byName[strings.ToLower(c.param)] = c
+	}
+
+	d := &Decoder{r: cr, columns: make([]column, len(header))}
+	seen := make(map[string]bool)
+
+	for i, name := range header {
+		c, ok := byName[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q, columns must be one of %v", name, strings.Join(Params(), ", "))
+		}
+
+		if seen[c.param] {
+			return nil, fmt.Errorf("column %v is listed more than once", c.param)
+		}
+
+		seen[c.param] = true
+		d.columns[i] = c
+	}
+
+	return d, nil
+}
+
+// Decode reads the next row into a new car, the cells which cannot be parsed are returned as invalid fields.
+// A row without a cell for every column is invalid as a whole, io.EOF is returned after the last row
+// and any other error if the CSV is malformed
+func (d *Decoder) Decode() (*model.Car, customErrors.InvalidFields, error) {
+	record, err := d.r.Read()
+	if errors.Is(err, csv.ErrFieldCount) {
+		d.line, _ = d.r.FieldPos(0)
+
+		return nil, customErrors.InvalidFields{{
+			Code:    customErrors.FieldInvalid,
+			Message: fmt.Sprintf("the row has %v cells, the header has %v", len(record), len(d.columns)),
+		}}, nil
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		d.line = parseErr.StartLine
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d.line, _ = d.r.FieldPos(0)
+
+	var (
+		car    model.Car
+		fields customErrors.InvalidFields
+	)
+
+	for i, value := range record {
+		field := decodeCell(d.columns[i], &car, strings.TrimSpace(value))
+		if field != nil {
+			fields = append(fields, *field)
+		}
+	}
+
+	return &car, fields, nil
+}
+
+// Line returns the line of the row last decoded, the header is on line 1
+func (d *Decoder) Line() int {
+	return d.line
+}
+
+// decodeCell parses value into the field of c in car, empty cells leave the field at its zero value
+func decodeCell(c column, car *model.Car, value string) *customErrors.FieldError {
+	if value == "" {
+		return nil
+	}
+
+	var err error
+
+	switch field := c.field(car).(type) {
+	case *string:
+		*field = value
+	case *int:
+		*field, err = strconv.Atoi(value)
+	case *float64:
+		*field, err = strconv.ParseFloat(value, 64)
+	}
+
+	if err != nil {
+		return &customErrors.FieldError{
+			Path:    c.path(),
+			Code:    customErrors.FieldInvalid,
+			Message: fmt.Sprintf("%v must be a number", c.param),
+		}
+	}
+
+	return nil
+}
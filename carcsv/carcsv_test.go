@@ -0,0 +1,152 @@
+package carcsv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func TestNewDecoder(t *testing.T) {
+	tests := []struct {
+		desc   string
+		header string
+		err    error
+	}{
+		{"Success", "Name, yearOfManufacture,BRAND", nil},
+		{"No header", "", errors.New("the CSV has no header")},
+		{"Unknown column", "name,model", errors.New(`unknown column "model", columns must be one of ` + strings.Join(Params(), ", "))},
+		{"Duplicate column", "name,brand,Name", errors.New("column name is listed more than once")},
+	}
+
+	for i, tc := range tests {
+		_, err := NewDecoder(strings.NewReader(tc.header))
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestDecoder_Decode(t *testing.T) {
+	csv := "name,yearOfManufacture,brand,fuelType,range,batteryCapacity,drivetrain\n" +
+		"Roadster,2008,Tesla,Electric,393,53,RWD\n" +
+		"\"Model S\",2012,Tesla,Electric,,,\n" +
+		"Model X,twenty,Tesla,Electric,500,big,AWD\n" +
+		"Model 3,2017\n"
+
+	dec, err := NewDecoder(strings.NewReader(csv))
+	assert.Nil(t, err)
+
+	tests := []struct {
+		desc   string
+		car    *model.Car
+		fields customErrors.InvalidFields
+		line   int
+	}{
+		{
+			"Success",
+			&model.Car{Name: "Roadster", YearOfManufacture: 2008, Brand: "Tesla", FuelType: "Electric",
+				Engine: model.Engine{Range: 393, BatteryCapacity: 53, Drivetrain: "RWD"}},
+			nil,
+			2,
+		},
+		{
+			"Empty cells",
+			&model.Car{Name: "Model S", YearOfManufacture: 2012, Brand: "Tesla", FuelType: "Electric"},
+			nil,
+			3,
+		},
+		{
+			"Numbers not parsed",
+			&model.Car{Name: "Model X", Brand: "Tesla", FuelType: "Electric", Engine: model.Engine{Range: 500, Drivetrain: "AWD"}},
+			customErrors.InvalidFields{
+				{Path: "/yearOfManufacture", Code: customErrors.FieldInvalid, Message: "yearOfManufacture must be a number"},
+				{Path: "/engine/batteryCapacity", Code: customErrors.FieldInvalid, Message: "batteryCapacity must be a number"},
+			},
+			4,
+		},
+		{
+			"Cells missing",
+			nil,
+			customErrors.InvalidFields{{Code: customErrors.FieldInvalid, Message: "the row has 2 cells, the header has 7"}},
+			5,
+		},
+	}
+
+	for i, tc := range tests {
+		car, fields, err := dec.Decode()
+
+		assert.Nilf(t, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.car, car, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.fields, fields, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.line, dec.Line(), "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	_, _, err = dec.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecoder_DecodeMalformed(t *testing.T) {
+	dec, err := NewDecoder(strings.NewReader("name,brand\nRoadster,Tesla\n\"Model S,Tesla\n"))
+	assert.Nil(t, err)
+
+	_, _, err = dec.Decode()
+	assert.Nil(t, err)
+
+	_, _, err = dec.Decode()
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, dec.Line())
+}
+
+func TestEncoder(t *testing.T) {
+	cars := []*model.Car{
+		{ID: "c1", Name: "Roadster, 2nd gen", YearOfManufacture: 2020, Brand: "Tesla", FuelType: "Electric", Status: model.StatusOnLot,
+			Engine: model.Engine{Range: 1000, BatteryCapacity: 200.5}, DealershipID: "d1",
+			Price: &model.Price{Currency: "EUR", List: 20000000, Minimum: 18000000}},
+		{ID: "c2", Name: "Abc", YearOfManufacture: 2020, Brand: "Ferrari", FuelType: "Diesel",
+			Engine: model.Engine{Displacement: 600, NoOfCylinders: 4}},
+	}
+
+	tests := []struct {
+		desc        string
+		withMinimum bool
+		csv         string
+	}{
+		{
+			"Without minimum price",
+			false,
+			"carId," + strings.Join(Params(), ",") + ",dealershipId,currency,listPrice\n" +
+				"c1,\"Roadster, 2nd gen\",2020,Tesla,Electric,,,on-lot,,,1000,200.5,,,,,,,,,d1,EUR,20000000\n" +
+				"c2,Abc,2020,Ferrari,Diesel,,,,600,4,,,,,,,,,,,,,\n",
+		},
+		{
+			"With minimum price",
+			true,
+			"carId," + strings.Join(Params(), ",") + ",dealershipId,currency,listPrice,minimumPrice\n" +
+				"c1,\"Roadster, 2nd gen\",2020,Tesla,Electric,,,on-lot,,,1000,200.5,,,,,,,,,d1,EUR,20000000,18000000\n" +
+				"c2,Abc,2020,Ferrari,Diesel,,,,600,4,,,,,,,,,,,,,,\n",
+		},
+	}
+
+	for i, tc := range tests {
+		var b strings.Builder
+
+		enc, err := NewEncoder(&b, tc.withMinimum)
+		assert.Nilf(t, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		for _, car := range cars {
+			assert.Nilf(t, enc.Encode(car), "Testcase[%v] (%v)", i, tc.desc)
+		}
+
+		assert.Nilf(t, enc.Flush(), "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.csv, b.String(), "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
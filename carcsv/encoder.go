@@ -0,0 +1,114 @@
+package carcsv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+
+	"carAPI/model"
+)
+
+// the columns of the price of a car in an export
+const (
+	paramListPrice    = "listPrice"
+	paramMinimumPrice = "minimumPrice"
+)
+
+// ExportHeader returns the names of the columns of an export, the minimum price is only exported if withMinimum is set
+func ExportHeader(withMinimum bool) []string {
+	header := append([]string{model.ParamCarID}, Params()...)
+	header = append(header, model.ParamDealershipID, model.ParamCurrency, paramListPrice)
+
+	if withMinimum {
+		header = append(header, paramMinimumPrice)
+	}
+
+	return header
+}
+
+// ExportValues returns the values of car in the order of ExportHeader. Strings, ints and int64s are returned as they are
+// and float64s as float64, fields at their zero value are returned as nil so that they are left empty
+func ExportValues(car *model.Car, withMinimum bool) []interface{} {
+	values := []interface{}{nonZero(car.ID)}
+
+	for _, c := range columns() {
+		values = append(values, nonZero(c.field(car)))
+	}
+
+	values = append(values, nonZero(car.DealershipID))
+
+	var price model.Price
+	if car.Price != nil {
+		price = *car.Price
+	}
+
+	values = append(values, nonZero(price.Currency), nonZero(price.List))
+
+	if withMinimum {
+		values = append(values, nonZero(price.Minimum))
+	}
+
+	return values
+}
+
+// nonZero dereferences v if it is a pointer, it returns nil for zero values
+func nonZero(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *string:
+		return nonZero(*v)
+	case *int:
+		return nonZero(*v)
+	case *float64:
+		return nonZero(*v)
+	}
+
+	if v == reflect.Zero(reflect.TypeOf(v)).Interface() {
+		return nil
+	}
+
+	return v
+}
+
+// Encoder writes cars as the rows of an export
+type Encoder struct {
+	w           *csv.Writer
+	withMinimum bool
+	record      []string
+}
+
+// NewEncoder writes the header of an export to w, the minimum price is only exported if withMinimum is set
+func NewEncoder(w io.Writer, withMinimum bool) (*Encoder, error) {
+	e := &Encoder{w: csv.NewWriter(w), withMinimum: withMinimum}
+
+	err := e.w.Write(ExportHeader(withMinimum))
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Encode writes car as the next row, rows are buffered until Flush
+func (e *Encoder) Encode(car *model.Car) error {
+	values := ExportValues(car, e.withMinimum)
+
+	e.record = e.record[:0]
+
+	for _, v := range values {
+		cell := ""
+		if v != nil {
+			cell = fmt.Sprint(v)
+		}
+
+		e.record = append(e.record, cell)
+	}
+
+	return e.w.Write(e.record)
+}
+
+// Flush writes the buffered rows
+func (e *Encoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
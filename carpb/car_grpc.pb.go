@@ -0,0 +1,334 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.12
+// source: car.proto
+
+// Package carapi.v1 serves the cars of the dealership of the API key, which is sent in the x-api-key metadata.
+// Errors carry the gRPC status code of their custom-errors class and an ErrorInfo detail whose reason is the
+// problem code the REST API would respond with.
+
+package carpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CarService_GetAll_FullMethodName   = "/carapi.v1.CarService/GetAll"
+	CarService_GetByID_FullMethodName  = "/carapi.v1.CarService/GetByID"
+	CarService_Create_FullMethodName   = "/carapi.v1.CarService/Create"
+	CarService_Update_FullMethodName   = "/carapi.v1.CarService/Update"
+	CarService_Delete_FullMethodName   = "/carapi.v1.CarService/Delete"
+	CarService_ListCars_FullMethodName = "/carapi.v1.CarService/ListCars"
+)
+
+// CarServiceClient is the client API for CarService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CarServiceClient interface {
+	// GetAll lists the cars which match the filter
+	GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (*GetAllResponse, error)
+	GetByID(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*Car, error)
+	// Create creates a car at the dealership of the API key, new cars are in transit unless created on the lot
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Car, error)
+	// Update replaces the members of a car, its status, price and dealership are not changed
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*Car, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// ListCars streams the cars which match the filter with their engines as they are read from the DB
+	ListCars(ctx context.Context, in *ListCarsRequest, opts ...grpc.CallOption) (CarService_ListCarsClient, error)
+}
+
+type carServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCarServiceClient(cc grpc.ClientConnInterface) CarServiceClient {
+	return &carServiceClient{cc}
+}
+
+func (c *carServiceClient) GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (*GetAllResponse, error) {
+	out := new(GetAllResponse)
+	err := c.cc.Invoke(ctx, CarService_GetAll_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) GetByID(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*Car, error) {
+	out := new(Car)
+	err := c.cc.Invoke(ctx, CarService_GetByID_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Car, error) {
+	out := new(Car)
+	err := c.cc.Invoke(ctx, CarService_Create_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*Car, error) {
+	out := new(Car)
+	err := c.cc.Invoke(ctx, CarService_Update_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CarService_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) ListCars(ctx context.Context, in *ListCarsRequest, opts ...grpc.CallOption) (CarService_ListCarsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CarService_ServiceDesc.Streams[0], CarService_ListCars_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &carServiceListCarsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CarService_ListCarsClient interface {
+	Recv() (*Car, error)
+	grpc.ClientStream
+}
+
+type carServiceListCarsClient struct {
+	grpc.ClientStream
+}
+
+func (x *carServiceListCarsClient) Recv() (*Car, error) {
+	m := new(Car)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CarServiceServer is the server API for CarService service.
+// All implementations must embed UnimplementedCarServiceServer
+// for forward compatibility
+type CarServiceServer interface {
+	// GetAll lists the cars which match the filter
+	GetAll(context.Context, *GetAllRequest) (*GetAllResponse, error)
+	GetByID(context.Context, *GetByIDRequest) (*Car, error)
+	// Create creates a car at the dealership of the API key, new cars are in transit unless created on the lot
+	Create(context.Context, *CreateRequest) (*Car, error)
+	// Update replaces the members of a car, its status, price and dealership are not changed
+	Update(context.Context, *UpdateRequest) (*Car, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// ListCars streams the cars which match the filter with their engines as they are read from the DB
+	ListCars(*ListCarsRequest, CarService_ListCarsServer) error
+	mustEmbedUnimplementedCarServiceServer()
+}
+
+// UnimplementedCarServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCarServiceServer struct {
+}
+
+func (UnimplementedCarServiceServer) GetAll(context.Context, *GetAllRequest) (*GetAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedCarServiceServer) GetByID(context.Context, *GetByIDRequest) (*Car, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByID not implemented")
+}
+func (UnimplementedCarServiceServer) Create(context.Context, *CreateRequest) (*Car, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedCarServiceServer) Update(context.Context, *UpdateRequest) (*Car, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedCarServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedCarServiceServer) ListCars(*ListCarsRequest, CarService_ListCarsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListCars not implemented")
+}
+func (UnimplementedCarServiceServer) mustEmbedUnimplementedCarServiceServer() {}
+
+// UnsafeCarServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CarServiceServer will
+// result in compilation errors.
+type UnsafeCarServiceServer interface {
+	mustEmbedUnimplementedCarServiceServer()
+}
+
+func RegisterCarServiceServer(s grpc.ServiceRegistrar, srv CarServiceServer) {
+	s.RegisterService(&CarService_ServiceDesc, srv)
+}
+
+func _CarService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_GetAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).GetAll(ctx, req.(*GetAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_GetByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).GetByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_GetByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).GetByID(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_ListCars_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCarsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CarServiceServer).ListCars(m, &carServiceListCarsServer{stream})
+}
+
+type CarService_ListCarsServer interface {
+	Send(*Car) error
+	grpc.ServerStream
+}
+
+type carServiceListCarsServer struct {
+	grpc.ServerStream
+}
+
+func (x *carServiceListCarsServer) Send(m *Car) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// CarService_ServiceDesc is the grpc.ServiceDesc for CarService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CarService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "carapi.v1.CarService",
+	HandlerType: (*CarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAll",
+			Handler:    _CarService_GetAll_Handler,
+		},
+		{
+			MethodName: "GetByID",
+			Handler:    _CarService_GetByID_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _CarService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _CarService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _CarService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListCars",
+			Handler:       _CarService_ListCars_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "car.proto",
+}
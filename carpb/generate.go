@@ -0,0 +1,4 @@
+// Package carpb holds the protobuf messages and the gRPC client and server of the car service, generated from car.proto
+package carpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative car.proto
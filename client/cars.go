@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"carAPI/dto"
+	"carAPI/dto/v2"
+	"carAPI/model"
+)
+
+// carRequest is the body of a car in requests. Version 2 always writes the status of cars, it is left out of requests
+// unless it is set, since it is only read on create
+type carRequest struct {
+	*v2.Car
+
+	Status model.Status `json:"status,omitempty"`
+}
+
+func newCarRequest(car *model.Car) carRequest {
+	return carRequest{Car: v2.NewCar(car), Status: car.Status}
+}
+
+// ListCars lists the cars of the dealership of the API key which match filter with their engines,
+// the dealership of filter is ignored
+func (c *Client) ListCars(ctx context.Context, filter model.CarFilter) ([]model.Car, error) {
+	var cars []model.Car
+
+	body, done := dto.V2.Request(&cars)
+
+	err := c.do(ctx, http.MethodGet, "/car", filterQuery(filter), nil, body)
+	if err != nil {
+		return nil, err
+	}
+
+	done()
+
+	return cars, nil
+}
+
+// GetCar fetches the car with given ID
+func (c *Client) GetCar(ctx context.Context, id string) (*model.Car, error) {
+	return c.car(ctx, http.MethodGet, "/car/"+url.PathEscape(id), nil)
+}
+
+// CreateCar creates car at the dealership of the API key and returns the created car
+func (c *Client) CreateCar(ctx context.Context, car *model.Car) (*model.Car, error) {
+	return c.car(ctx, http.MethodPost, "/car", car)
+}
+
+// UpdateCar replaces the members of the car with given ID by the ones of car and returns the updated car,
+// its status, price and dealership are not changed
+func (c *Client) UpdateCar(ctx context.Context, id string, car *model.Car) (*model.Car, error) {
+	return c.car(ctx, http.MethodPut, "/car/"+url.PathEscape(id), car)
+}
+
+// DeleteCar deletes the car with given ID
+func (c *Client) DeleteCar(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/car/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// car sends a request with the body of in, which may be nil, and returns the car of the response
+func (c *Client) car(ctx context.Context, method, path string, in *model.Car) (*model.Car, error) {
+	var req interface{}
+	if in != nil {
+		req = newCarRequest(in)
+	}
+
+	var car model.Car
+
+	body, done := dto.V2.Request(&car)
+
+	err := c.do(ctx, method, path, nil, req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	done()
+
+	return &car, nil
+}
+
+// filterQuery returns the query params of filter, the engines of the cars are always fetched
+func filterQuery(filter model.CarFilter) url.Values {
+	q := url.Values{"withEngine": {"true"}}
+
+	params := []struct {
+		param string
+		value string
+	}{
+		{model.ParamBrand, filter.Brand},
+		{model.ParamStatus, string(filter.Status)},
+		{model.ParamTransmission, filter.Transmission},
+		{model.ParamDrivetrain, filter.Drivetrain},
+		{model.ParamCurrency, filter.Currency},
+		{model.ParamMinPower, itoa(int64(filter.MinPower))},
+		{model.ParamMaxPower, itoa(int64(filter.MaxPower))},
+		{model.ParamMinPrice, itoa(filter.MinPrice)},
+		{model.ParamMaxPrice, itoa(filter.MaxPrice)},
+	}
+
+	for _, p := range params {
+		if p.value != "" {
+			q.Set(p.param, p.value)
+		}
+	}
+
+	return q
+}
+
+// itoa formats n, zero is formatted as the empty string since zero bounds do not filter
+func itoa(n int64) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(n, 10)
+}
@@ -0,0 +1,181 @@
+// Package client is a typed Go client of the REST API. It speaks version 2 of the API, reads and writes cars
+// as model.Car and returns the error responses of the API as *Error.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// prefix is the path prefix of the version of the API the client speaks
+	prefix = "/v2"
+
+	defaultRetries = 3
+	defaultBackoff = 200 * time.Millisecond
+
+	// maxBackoff caps the wait between attempts, also if the API asks for a longer one
+	maxBackoff = 10 * time.Second
+)
+
+// Client calls the API at a base URL with an API key. It is safe for concurrent use
+type Client struct {
+	baseURL string
+	key     string
+	http    *http.Client
+	retries int
+	backoff time.Duration
+}
+
+// Option configures a Client
+type Option func(c *Client)
+
+// WithHTTPClient sends the requests of the client with hc instead of http.DefaultClient
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithRetries retries failed requests up to retries times, the wait before the first retry is backoff
+// and doubles with every further retry
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client of the API at baseURL, e.g. https://cars.example.com, which authenticates with the API key key
+func New(baseURL, key string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		key:     key,
+		http:    http.DefaultClient,
+		retries: defaultRetries,
+		backoff: defaultBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// do sends a request with the JSON body of in to path and decodes the JSON body of the response into out,
+// in and out may be nil. Responses which may succeed when retried are retried with backoff, other error responses
+// are returned as *Error
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	var body []byte
+
+	if in != nil {
+		var err error
+
+		body, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, path, query, body)
+		if err != nil {
+			return err
+		}
+
+		if attempt == c.retries || !retryable(method, resp) {
+			return decode(resp, out)
+		}
+
+		wait := c.wait(attempt, resp)
+
+		// the body is drained so that the connection is reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// send sends one attempt of a request, a new request is built for every attempt so that the body is sent again
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body []byte) (*http.Response, error) {
+	u := c.baseURL + prefix + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("x-api-key", c.key)
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.http.Do(req)
+}
+
+// retryable reports whether a request with method may succeed if it is sent again after resp.
+// Idempotent requests are retried on rate limits and server errors. Server errors, unavailable requests included,
+// may have left changes behind, so requests which are not idempotent are only retried when rate limited or when
+// the API asks for the retry with Retry-After, so that cars are never created twice
+func retryable(method string, resp *http.Response) bool {
+	idempotent := method != http.MethodPost && method != http.MethodPatch
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") != "":
+		return true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return idempotent
+	}
+
+	return false
+}
+
+// wait returns how long to wait before the retry after attempt, the Retry-After header of resp wins over the backoff
+func (c *Client) wait(attempt int, resp *http.Response) time.Duration {
+	wait := c.backoff << attempt
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
+	}
+
+	if wait > maxBackoff || wait < 0 {
+		wait = maxBackoff
+	}
+
+	return wait
+}
+
+// decode decodes the body of resp into out, error responses are returned as *Error
+func decode(resp *http.Response, out interface{}) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return readError(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
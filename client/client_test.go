@@ -0,0 +1,377 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/dto"
+	"carAPI/handler"
+	"carAPI/middleware"
+	"carAPI/mocks"
+	"carAPI/model"
+	"carAPI/openapi"
+	"carAPI/problem"
+)
+
+func id1() string {
+	return "86a4cc77-4a2b-4215-8a2c-ff3ecca19627"
+}
+
+func car1() *model.Car {
+	return &model.Car{
+		ID:                id1(),
+		Name:              "Roadster",
+		YearOfManufacture: 2000,
+		Brand:             "Tesla",
+		FuelType:          "Electric",
+		Engine:            model.Engine{ID: "e1", Range: 500},
+		Status:            model.StatusOnLot,
+		Price:             &model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000},
+		DealershipID:      "d1",
+	}
+}
+
+// viewCar1 is car1 as seen by viewer keys, which do not see the minimum price
+func viewCar1() *model.Car {
+	c := car1()
+	c.Price.Minimum = 0
+
+	return c
+}
+
+// serve serves the car routes of version 2 of the API with the car service s and returns its URL.
+// Before the API, flaky answers the first requests it is given with their status
+func serve(t *testing.T, mockCtrl *gomock.Controller, s *mocks.MockCarService, flaky ...int) string {
+	t.Helper()
+
+	c := mocks.NewMockCatalog(mockCtrl)
+	c.EXPECT().Brands().Return([]string{"Tesla", "BMW"}, nil).AnyTimes()
+	c.EXPECT().FuelTypes().Return([]string{"Electric", "Petrol"}, nil).AnyTimes()
+	c.EXPECT().EngineRules().Return(map[string]model.EngineRule{
+		"Electric": {Required: []string{"range"}, Forbidden: []string{"displacement", "noOfCylinders", "electricRange", "tankCapacity"}},
+		"Petrol":   {Required: []string{"displacement", "noOfCylinders"}, Forbidden: []string{"batteryCapacity", "electricRange", "noOfMotors"}},
+	}, nil).AnyTimes()
+
+	validator, err := openapi.NewValidator(c, "/v2/car")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := handler.New(s, c)
+	r := mux.NewRouter()
+	v2 := r.PathPrefix("/v2").Subrouter()
+
+	v2.HandleFunc("/car", h.Get).Methods(http.MethodGet)
+	v2.HandleFunc("/car/{id}", h.GetByID).Methods(http.MethodGet)
+	v2.HandleFunc("/car", h.Create).Methods(http.MethodPost)
+	v2.HandleFunc("/car/{id}", h.Owned(h.Update)).Methods(http.MethodPut)
+	v2.HandleFunc("/car/{id}", h.Owned(h.Delete)).Methods(http.MethodDelete)
+
+	v2.Use(middleware.Auth(map[string]model.APIKey{
+		"admin-key": {Role: model.RoleAdmin, DealershipID: "d1"},
+		"north-key": {Role: model.RoleViewer, DealershipID: "d1"},
+		"south-key": {Role: model.RoleViewer, DealershipID: "d2"},
+	}))
+	v2.Use(middleware.Negotiate)
+	v2.Use(middleware.Versioned(dto.V2))
+	v2.Use(middleware.ValidateBody(validator))
+
+	var served int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		n := int(atomic.AddInt32(&served, 1))
+		if n > len(flaky) {
+			r.ServeHTTP(w, req)
+			return
+		}
+
+		w.Header().Set("Retry-After", "0")
+		problem.Write(w, req, code(flaky[n-1]), "")
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+// code returns the error code of the flaky status
+func code(status int) customErrors.Code {
+	if status == http.StatusServiceUnavailable {
+		return customErrors.CodeUnavailable
+	}
+
+	return customErrors.CodeDatabase
+}
+
+func TestClient_GetCar(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+	url := serve(t, mockCtrl, m)
+
+	m.EXPECT().GetByID(id1()).Return(car1(), nil).Times(2)
+	m.EXPECT().GetByID(id1()).Return(nil, customErrors.CarNotExists())
+
+	tests := []struct {
+		desc   string
+		key    string
+		id     string
+		car    *model.Car
+		code   customErrors.Code
+		target error
+	}{
+		{"Admin keys see the minimum price", "admin-key", id1(), car1(), "", nil},
+		{"Viewer keys do not", "north-key", id1(), viewCar1(), "", nil},
+		{"Car does not exist", "north-key", id1(), nil, customErrors.CodeEntityNotFound, customErrors.ErrNotFound},
+		{"Invalid ID", "north-key", "1", nil, customErrors.CodeInvalidID, nil},
+		{"Unknown key", "unknown-key", id1(), nil, customErrors.CodeUnauthorized, nil},
+	}
+
+	for i, tc := range tests {
+		car, err := New(url, tc.key).GetCar(context.Background(), tc.id)
+
+		assert.Equalf(t, tc.car, car, "Testcase[%v] (%v)", i, tc.desc)
+
+		if tc.code == "" {
+			assert.Nilf(t, err, "Testcase[%v] (%v)", i, tc.desc)
+			continue
+		}
+
+		var apiErr *Error
+
+		if assert.Truef(t, errors.As(err, &apiErr), "Testcase[%v] (%v)", i, tc.desc) {
+			assert.Equalf(t, tc.code, apiErr.Code, "Testcase[%v] (%v)", i, tc.desc)
+		}
+
+		if tc.target != nil {
+			assert.Truef(t, errors.Is(err, tc.target), "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
+
+func TestClient_ListCars(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+	c := New(serve(t, mockCtrl, m), "north-key")
+
+	m.EXPECT().GetAll(model.CarFilter{Brand: "Tesla", Status: model.StatusOnLot, MinPower: 100, Currency: "EUR",
+		MaxPrice: 9000000, DealershipID: "d1"}, true).Return([]model.Car{*car1()}, nil)
+	m.EXPECT().GetAll(model.CarFilter{DealershipID: "d1"}, true).Return(nil, nil)
+
+	cars, err := c.ListCars(context.Background(), model.CarFilter{Brand: "Tesla", Status: model.StatusOnLot, MinPower: 100,
+		Currency: "EUR", MaxPrice: 9000000, DealershipID: "d2"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Car{*viewCar1()}, cars)
+
+	cars, err = c.ListCars(context.Background(), model.CarFilter{})
+
+	assert.Nil(t, err)
+	assert.Empty(t, cars)
+
+	_, err = c.ListCars(context.Background(), model.CarFilter{Status: "parked"})
+
+	var apiErr *Error
+
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, customErrors.CodeInvalidQuery, apiErr.Code)
+		assert.Equal(t, model.ParamStatus, apiErr.Param)
+	}
+}
+
+func TestClient_CreateCar(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+	c := New(serve(t, mockCtrl, m), "admin-key")
+
+	input := &model.Car{Name: "Roadster", YearOfManufacture: 2000, Brand: "Tesla", FuelType: "Electric",
+		Engine: model.Engine{Range: 500}}
+
+	m.EXPECT().Create(gomock.Any()).DoAndReturn(func(car *model.Car) (*model.Car, error) {
+		assert.Equal(t, "d1", car.DealershipID)
+		assert.Equal(t, "Roadster", car.Name)
+
+		return car1(), nil
+	})
+
+	car, err := c.CreateCar(context.Background(), input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, car1(), car)
+
+	input.Brand = "Fiat"
+
+	_, err = c.CreateCar(context.Background(), input)
+
+	var apiErr *Error
+
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, customErrors.CodeSchema, apiErr.Code)
+		assert.Equal(t, "/brand", apiErr.Errors[0].Path)
+	}
+
+	assert.True(t, errors.Is(err, customErrors.ErrValidation))
+	assert.Equal(t, "carAPI: schema-violation: errors lists every member of the body which does not match the schema", err.Error())
+}
+
+func TestClient_UpdateDeleteCar(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+	c := New(serve(t, mockCtrl, m), "north-key")
+
+	m.EXPECT().GetByID(id1()).Return(car1(), nil).Times(3)
+	m.EXPECT().Update(gomock.Any()).DoAndReturn(func(car *model.Car) (*model.Car, error) {
+		assert.Equal(t, id1(), car.ID)
+		assert.Equal(t, "Model S", car.Name)
+
+		return car1(), nil
+	})
+	m.EXPECT().Delete(id1()).Return(nil)
+	m.EXPECT().Delete(id1()).Return(customErrors.Conflict{Entity: "Car", Reason: "the car has an open order"})
+
+	input := viewCar1()
+	input.Name = "Model S"
+
+	car, err := c.UpdateCar(context.Background(), id1(), input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, viewCar1(), car)
+
+	err = c.DeleteCar(context.Background(), id1())
+
+	assert.Nil(t, err)
+
+	err = c.DeleteCar(context.Background(), id1())
+
+	assert.True(t, errors.Is(err, customErrors.ErrConflict))
+	assert.False(t, errors.Is(err, customErrors.ErrNotFound))
+}
+
+func TestClient_Retries(t *testing.T) {
+	unavailable, serverErr := http.StatusServiceUnavailable, http.StatusInternalServerError
+
+	tests := []struct {
+		desc    string
+		flaky   []int
+		retries int
+		create  bool
+		code    customErrors.Code
+	}{
+		{"Unavailable requests are retried", []int{unavailable, unavailable}, 3, false, ""},
+		{"Until the retries are used up", []int{unavailable, unavailable}, 1, false, customErrors.CodeUnavailable},
+		{"Idempotent requests are retried on server errors", []int{serverErr}, 3, false, ""},
+		{"Creates are retried when unavailable with Retry-After", []int{unavailable}, 3, true, ""},
+		{"But not on server errors", []int{serverErr}, 3, true, customErrors.CodeDatabase},
+	}
+
+	for i, tc := range tests {
+		mockCtrl := gomock.NewController(t)
+		m := mocks.NewMockCarService(mockCtrl)
+
+		if tc.code == "" && tc.create {
+			m.EXPECT().Create(gomock.Any()).Return(car1(), nil)
+		} else if tc.code == "" {
+			m.EXPECT().GetByID(id1()).Return(car1(), nil)
+		}
+
+		c := New(serve(t, mockCtrl, m, tc.flaky...), "admin-key", WithRetries(tc.retries, time.Millisecond))
+
+		var err error
+
+		if tc.create {
+			_, err = c.CreateCar(context.Background(), &model.Car{Name: "Roadster", YearOfManufacture: 2000, Brand: "Tesla",
+				FuelType: "Electric", Engine: model.Engine{Range: 500}})
+		} else {
+			_, err = c.GetCar(context.Background(), id1())
+		}
+
+		var apiErr *Error
+
+		if tc.code == "" {
+			assert.Nilf(t, err, "Testcase[%v] (%v)", i, tc.desc)
+		} else if assert.Truef(t, errors.As(err, &apiErr), "Testcase[%v] (%v)", i, tc.desc) {
+			assert.Equalf(t, tc.code, apiErr.Code, "Testcase[%v] (%v)", i, tc.desc)
+		}
+
+		mockCtrl.Finish()
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		desc       string
+		method     string
+		status     int
+		retryAfter string
+		want       bool
+	}{
+		{"Rate limited create", http.MethodPost, http.StatusTooManyRequests, "", true},
+		{"Unavailable create with Retry-After", http.MethodPost, http.StatusServiceUnavailable, "1", true},
+		{"Unavailable create may have been written", http.MethodPost, http.StatusServiceUnavailable, "", false},
+		{"Unavailable patch may have been written", http.MethodPatch, http.StatusServiceUnavailable, "", false},
+		{"Unavailable get", http.MethodGet, http.StatusServiceUnavailable, "", true},
+		{"Server error of an update", http.MethodPut, http.StatusInternalServerError, "", true},
+		{"Server error of a create", http.MethodPost, http.StatusInternalServerError, "1", false},
+		{"Client error", http.MethodGet, http.StatusNotFound, "", false},
+	}
+
+	for i, tc := range tests {
+		resp := &http.Response{StatusCode: tc.status, Header: http.Header{}}
+		if tc.retryAfter != "" {
+			resp.Header.Set("Retry-After", tc.retryAfter)
+		}
+
+		assert.Equalf(t, tc.want, retryable(tc.method, resp), "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestClient_RetriesCanceled(t *testing.T) {
+	// the API asks for a wait which outlasts the context
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		problem.Write(w, r, customErrors.CodeUnavailable, "")
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := New(srv.URL, "admin-key").GetCar(ctx, id1())
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestReadError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "admin-key", WithRetries(0, 0)).GetCar(context.Background(), id1())
+
+	var apiErr *Error
+
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusBadGateway, apiErr.Status)
+		assert.Equal(t, customErrors.Code(""), apiErr.Code)
+	}
+
+	assert.Equal(t, "carAPI: 502 Bad Gateway", err.Error())
+}
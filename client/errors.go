@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/problem"
+)
+
+// Error is an error response of the API, its members are the problem details of the response.
+// It matches the sentinel errors of custom-errors its code belongs to, e.g. errors.Is(err, customErrors.ErrNotFound)
+type Error struct {
+	problem.Details
+}
+
+func (e *Error) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+
+	if e.Code == "" {
+		return fmt.Sprintf("carAPI: %v %v", e.Status, msg)
+	}
+
+	return fmt.Sprintf("carAPI: %v: %v", e.Code, msg)
+}
+
+// Is reports whether target is the sentinel error of the code of e
+func (e *Error) Is(target error) bool {
+	switch e.Code {
+	case customErrors.CodeEntityNotFound:
+		return target == customErrors.ErrNotFound
+	case customErrors.CodeConflict:
+		return target == customErrors.ErrConflict
+	case customErrors.CodeValidation, customErrors.CodeSchema:
+		return target == customErrors.ErrValidation
+	case customErrors.CodeUnavailable:
+		return target == customErrors.ErrUnavailable
+	}
+
+	return false
+}
+
+// readError reads the problem details of an error response. Responses without them, e.g. of a proxy in front of the API,
+// only carry their status
+func readError(resp *http.Response) error {
+	e := &Error{Details: problem.Details{Status: resp.StatusCode, Title: http.StatusText(resp.StatusCode)}}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.Header.Get("Content-Type") != problem.ContentType {
+		return e
+	}
+
+	var details problem.Details
+
+	err = json.Unmarshal(body, &details)
+	if err != nil {
+		return e
+	}
+
+	e.Details = details
+
+	return e
+}
@@ -0,0 +1,167 @@
+// Package codec encodes responses and decodes request bodies as JSON, XML or YAML.
+// XML and YAML documents are built from the JSON encoding of a value, so that members are named the same in every format.
+package codec
+
+import (
+	"encoding/json"
+	"mime"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the media types of the codecs, the first media type of a codec is the one responses are sent with
+const (
+	MediaTypeJSON = "application/json"
+	MediaTypeXML  = "application/xml"
+	MediaTypeYAML = "application/yaml"
+)
+
+// Codec encodes and decodes values in one format
+type Codec struct {
+	// MediaType is the media type encoded values are sent with
+	MediaType string
+
+	Marshal   func(v interface{}) ([]byte, error)
+	Unmarshal func(data []byte, v interface{}) error
+
+	// aliases are other media types of the format, which are accepted in the Accept and Content-Type headers
+	aliases []string
+}
+
+// JSON is the codec used when a request does not name a media type
+func JSON() Codec {
+	return Codec{MediaType: MediaTypeJSON, Marshal: json.Marshal, Unmarshal: json.Unmarshal}
+}
+
+// UnmarshalTree decodes data into the tree of the members it has, maps, []interface{}, strings, numbers, bools and nils
+// as if it was the equivalent JSON, so that the members can be merged into an existing value. XML has no types of its own,
+// its members are typed by the fields of v, which is not changed
+func (c Codec) UnmarshalTree(data []byte, v interface{}) (interface{}, error) {
+	if c.MediaType == MediaTypeXML {
+		root, err := parseXML(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return root.value(reflect.TypeOf(v), "")
+	}
+
+	var tree interface{}
+
+	err := c.Unmarshal(data, &tree)
+
+	return tree, err
+}
+
+// codecs lists the supported codecs, from the most to the least preferred
+func codecs() []Codec {
+	return []Codec{
+		JSON(),
+		{MediaType: MediaTypeXML, Marshal: marshalXML, Unmarshal: unmarshalXML, aliases: []string{"text/xml"}},
+		{
+			MediaType: MediaTypeYAML, Marshal: marshalYAML, Unmarshal: unmarshalYAML,
+			aliases: []string{"application/x-yaml", "text/yaml", "text/x-yaml"},
+		},
+	}
+}
+
+// MediaTypes lists the media types responses can be sent with
+func MediaTypes() []string {
+	cs := codecs()
+
+	types := make([]string, len(cs))
+	for i := range cs {
+		types[i] = cs[i].MediaType
+	}
+
+	return types
+}
+
+// ForAccept returns the codec of the most preferred media range of the Accept header accept which can be produced,
+// JSON if accept is empty. False is returned if none of the media ranges can be produced
+func ForAccept(accept string) (Codec, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return JSON(), true
+	}
+
+	for _, mediaRange := range mediaRanges(accept) {
+		c, ok := match(mediaRange)
+		if ok {
+			return c, true
+		}
+	}
+
+	return Codec{}, false
+}
+
+// ForContentType returns the codec of the Content-Type header contentType, JSON if it is empty.
+// False is returned if the media type is not supported
+func ForContentType(contentType string) (Codec, bool) {
+	if contentType == "" {
+		return JSON(), true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || strings.Contains(mediaType, "*") {
+		return Codec{}, false
+	}
+
+	return match(mediaType)
+}
+
+// match returns the first codec matching mediaRange, which may be */* or a type followed by /*
+func match(mediaRange string) (Codec, bool) {
+	for _, c := range codecs() {
+		for _, t := range append([]string{c.MediaType}, c.aliases...) {
+			if mediaRange == "*/*" || mediaRange == t || mediaRange == t[:strings.Index(t, "/")]+"/*" {
+				return c, true
+			}
+		}
+	}
+
+	return Codec{}, false
+}
+
+// mediaRanges returns the media ranges of the Accept header accept by descending quality,
+// ranges of the same quality keep their order and ranges with a quality of 0 are left out
+func mediaRanges(accept string) []string {
+	type weighted struct {
+		mediaRange string
+		q          float64
+	}
+
+	var ranges []weighted
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+
+		if v, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		if q > 0 {
+			ranges = append(ranges, weighted{mediaRange, q})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	result := make([]string, len(ranges))
+	for i := range ranges {
+		result[i] = ranges[i].mediaRange
+	}
+
+	return result
+}
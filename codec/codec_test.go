@@ -0,0 +1,250 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"carAPI/model"
+)
+
+func TestForAccept(t *testing.T) {
+	tests := []struct {
+		desc      string
+		accept    string
+		mediaType string
+		ok        bool
+	}{
+		{"No Accept header", "", MediaTypeJSON, true},
+		{"Any media type", "*/*", MediaTypeJSON, true},
+		{"XML", "application/xml", MediaTypeXML, true},
+		{"XML alias", "text/xml", MediaTypeXML, true},
+		{"YAML alias", "application/x-yaml", MediaTypeYAML, true},
+		{"Quality", "application/json;q=0.5, application/yaml", MediaTypeYAML, true},
+		{"Unsupported type skipped", "text/html, application/xhtml+xml, text/*;q=0.9, */*;q=0.8", MediaTypeXML, true},
+		{"Excluded type", "application/json;q=0, application/xml;q=0.1", MediaTypeXML, true},
+		{"Not acceptable", "text/html, image/png", "", false},
+	}
+
+	for i, tc := range tests {
+		c, ok := ForAccept(tc.accept)
+
+		assert.Equalf(t, tc.ok, ok, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.mediaType, c.MediaType, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestForContentType(t *testing.T) {
+	tests := []struct {
+		desc        string
+		contentType string
+		mediaType   string
+		ok          bool
+	}{
+		{"No Content-Type header", "", MediaTypeJSON, true},
+		{"JSON with charset", "application/json; charset=utf-8", MediaTypeJSON, true},
+		{"XML alias", "text/xml", MediaTypeXML, true},
+		{"YAML", "application/yaml", MediaTypeYAML, true},
+		{"Media range", "*/*", "", false},
+		{"Unsupported", "text/csv", "", false},
+	}
+
+	for i, tc := range tests {
+		c, ok := ForContentType(tc.contentType)
+
+		assert.Equalf(t, tc.ok, ok, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.mediaType, c.MediaType, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func car() *model.Car {
+	return &model.Car{
+		ID:                "c1",
+		Name:              "Tom & Jerry's \"911\"",
+		YearOfManufacture: 2020,
+		Brand:             "Porsche",
+		FuelType:          "Electric",
+		Engine:            model.Engine{Range: 500, BatteryCapacity: 93.4, Drivetrain: "AWD"},
+		Price:             &model.Price{Currency: "EUR", List: 10000000},
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		desc string
+		c    Codec
+		v    interface{}
+		out  string
+	}{
+		{
+			"XML",
+			codecFor(MediaTypeXML),
+			car(),
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<car><carId>c1</carId><name>Tom &amp; Jerry&#39;s &#34;911&#34;</name><yearOfManufacture>2020</yearOfManufacture>` +
+				`<brand>Porsche</brand><fuelType>Electric</fuelType>` +
+				`<engine><engineId></engineId><displacement>0</displacement><noOfCylinders>0</noOfCylinders><range>500</range>` +
+				`<batteryCapacity>93.4</batteryCapacity><drivetrain>AWD</drivetrain></engine>` +
+				`<price><currency>EUR</currency><list>10000000</list></price></car>`,
+		},
+		{
+			"XML array and map",
+			codecFor(MediaTypeXML),
+			[]map[string]interface{}{{"2024-01-01": true, "ids": []string{"a", "b"}, "none": nil}},
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<items><item><entry key="2024-01-01">true</entry><ids><item>a</item><item>b</item></ids></item></items>`,
+		},
+		{
+			"YAML",
+			codecFor(MediaTypeYAML),
+			car(),
+			"carId: c1\n" +
+				"name: Tom & Jerry's \"911\"\n" +
+				"yearOfManufacture: 2020\n" +
+				"brand: Porsche\n" +
+				"fuelType: Electric\n" +
+				"engine:\n" +
+				"    engineId: \"\"\n" +
+				"    displacement: 0\n" +
+				"    noOfCylinders: 0\n" +
+				"    range: 500\n" +
+				"    batteryCapacity: 93.4\n" +
+				"    drivetrain: AWD\n" +
+				"price:\n" +
+				"    currency: EUR\n" +
+				"    list: 10000000\n",
+		},
+	}
+
+	for i, tc := range tests {
+		out, err := tc.c.Marshal(tc.v)
+
+		assert.Nilf(t, err, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.out, string(out), "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	var history struct {
+		Items []model.StatusChange `json:"items"`
+	}
+
+	changedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		desc string
+		c    Codec
+		in   string
+		v    interface{}
+		want interface{}
+		err  error
+	}{
+		{
+			"XML",
+			codecFor(MediaTypeXML),
+			`<car><carId>c1</carId><name>Tom &amp; Jerry's "911"</name><yearOfManufacture> 2020 </yearOfManufacture>` +
+				`<brand>Porsche</brand><fuelType>Electric</fuelType><unknown>x</unknown>` +
+				`<engine><range>500</range><batteryCapacity>93.4</batteryCapacity><drivetrain>AWD</drivetrain></engine>` +
+				`<price><currency>EUR</currency><list>10000000</list></price></car>`,
+			&model.Car{},
+			car(),
+			nil,
+		},
+		{
+			"XML array and time",
+			codecFor(MediaTypeXML),
+			`<history><items><item><to>sold</to><at>2024-01-02T03:04:05Z</at></item></items></history>`,
+			&history,
+			&struct {
+				Items []model.StatusChange `json:"items"`
+			}{[]model.StatusChange{{To: model.StatusSold, At: changedAt}}},
+			nil,
+		},
+		{
+			"XML number",
+			codecFor(MediaTypeXML),
+			`<car><yearOfManufacture>twenty</yearOfManufacture></car>`,
+			&model.Car{},
+			&model.Car{},
+			errors.New("yearOfManufacture must be a number"),
+		},
+		{
+			"XML without root",
+			codecFor(MediaTypeXML),
+			``,
+			&model.Car{},
+			&model.Car{},
+			errors.New("the document has no root element"),
+		},
+		{
+			"YAML",
+			codecFor(MediaTypeYAML),
+			"carId: c1\nname: Tom & Jerry's \"911\"\nyearOfManufacture: 2020\nbrand: Porsche\nfuelType: Electric\n" +
+				"engine: {range: 500, batteryCapacity: 93.4, drivetrain: AWD}\nprice:\n  currency: EUR\n  list: 10000000\n",
+			&model.Car{},
+			car(),
+			nil,
+		},
+	}
+
+	for i, tc := range tests {
+		err := tc.c.Unmarshal([]byte(tc.in), tc.v)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.want, tc.v, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestUnmarshalTree(t *testing.T) {
+	tests := []struct {
+		desc string
+		c    Codec
+		in   string
+		want interface{}
+		err  error
+	}{
+		{
+			"JSON",
+			JSON(),
+			`{"name":"Model S","vin":null,"engine":{"range":600}}`,
+			map[string]interface{}{"name": "Model S", "vin": nil, "engine": map[string]interface{}{"range": float64(600)}},
+			nil,
+		},
+		{
+			"XML typed by the fields of v",
+			codecFor(MediaTypeXML),
+			`<car><name>911</name><engine><range>600</range></engine></car>`,
+			map[string]interface{}{"name": "911", "engine": map[string]interface{}{"range": json.Number("600")}},
+			nil,
+		},
+		{
+			"XML number",
+			codecFor(MediaTypeXML),
+			`<car><yearOfManufacture>twenty</yearOfManufacture></car>`,
+			nil,
+			errors.New("yearOfManufacture must be a number"),
+		},
+		{
+			"YAML",
+			codecFor(MediaTypeYAML),
+			"name: Model S\nvin: null\n",
+			map[string]interface{}{"name": "Model S", "vin": nil},
+			nil,
+		},
+	}
+
+	for i, tc := range tests {
+		tree, err := tc.c.UnmarshalTree([]byte(tc.in), &model.Car{})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.want, tree, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func codecFor(mediaType string) Codec {
+	c, _ := ForContentType(mediaType)
+	return c
+}
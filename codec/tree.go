@@ -0,0 +1,98 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// member is a member of a JSON object
+type member struct {
+	name  string
+	value interface{}
+}
+
+// object is a JSON object whose members keep their order
+type object []member
+
+// jsonTree encodes v as JSON and reads it back as a tree of objects, []interface{}, strings, json.Numbers, bools and nils,
+// so that the members of every object are written in the order of their fields
+func jsonTree(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	return readValue(dec)
+}
+
+// readValue reads the next value of dec
+func readValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		return readObject(dec)
+	case '[':
+		return readArray(dec)
+	}
+
+	return nil, errors.New("unexpected " + delim.String())
+}
+
+// readObject reads the members of an object whose opening brace has been read
+func readObject(dec *json.Decoder) (object, error) {
+	obj := object{}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		// the keys of JSON objects are always strings
+		name, _ := tok.(string)
+
+		value, err := readValue(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		obj = append(obj, member{name, value})
+	}
+
+	// the closing brace
+	_, err := dec.Token()
+
+	return obj, err
+}
+
+// readArray reads the elements of an array whose opening bracket has been read
+func readArray(dec *json.Decoder) ([]interface{}, error) {
+	arr := []interface{}{}
+
+	for dec.More() {
+		value, err := readValue(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		arr = append(arr, value)
+	}
+
+	// the closing bracket
+	_, err := dec.Token()
+
+	return arr, err
+}
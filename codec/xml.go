@@ -0,0 +1,357 @@
+package codec
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// the elements of XML documents which have no member name
+const (
+	itemElement  = "item"
+	itemsElement = "items"
+	entryElement = "entry"
+)
+
+// marshalXML encodes v as an XML document with an element for every member of its JSON encoding.
+// The root element is named after the type of v, elements of arrays are item elements and null members are left out
+func marshalXML(v interface{}) ([]byte, error) {
+	tree, err := jsonTree(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(xml.Header)
+
+	enc := xml.NewEncoder(&buf)
+
+	err = encodeElement(enc, rootName(v), tree)
+	if err != nil {
+		return nil, err
+	}
+
+	err = enc.Flush()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// rootName returns the name of the root element of v, the name of its type starting in lower case
+func rootName(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		return itemsElement
+	}
+
+	name := t.Name()
+	if name == "" {
+		return "object"
+	}
+
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// encodeElement writes value as an element with given name, members whose name is not a valid element name
+// are written as entry elements with a key attribute
+func encodeElement(enc *xml.Encoder, name string, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	if !isName(name) {
+		start = xml.StartElement{Name: xml.Name{Local: entryElement}, Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}}}
+	}
+
+	err := enc.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+
+	err = encodeContent(enc, value)
+	if err != nil {
+		return err
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+// encodeContent writes the content of the element of value
+func encodeContent(enc *xml.Encoder, value interface{}) error {
+	switch value := value.(type) {
+	case object:
+		for _, m := range value {
+			if m.value == nil {
+				continue
+			}
+
+			err := encodeElement(enc, m.name, m.value)
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, v := range value {
+			err := encodeElement(enc, itemElement, v)
+			if err != nil {
+				return err
+			}
+		}
+	case nil:
+	default:
+		return enc.EncodeToken(xml.CharData(fmt.Sprint(value)))
+	}
+
+	return nil
+}
+
+// isName reports whether s is a valid XML element name which does not use namespaces
+func isName(s string) bool {
+	if s == "" || strings.HasPrefix(strings.ToLower(s), "xml") {
+		return false
+	}
+
+	for i, r := range s {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r))) {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// element is an element of a parsed XML document
+type element struct {
+	name     string
+	key      string
+	text     string
+	children []*element
+}
+
+// unmarshalXML decodes the XML document data into v as if it was the JSON whose encoding marshalXML would write.
+// The type of v tells whether the text of an element is a string, a number or a bool
+func unmarshalXML(data []byte, v interface{}) error {
+	root, err := parseXML(data)
+	if err != nil {
+		return err
+	}
+
+	tree, err := root.value(reflect.TypeOf(v), "")
+	if err != nil {
+		return err
+	}
+
+	data, err = json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// parseXML parses data into a tree of elements and returns the root element
+func parseXML(data []byte) (*element, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	var (
+		root  *element
+		stack []*element
+	)
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			if root == nil {
+				return nil, errors.New("the document has no root element")
+			}
+
+			return root, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			e := &element{name: tok.Name.Local}
+
+			for _, a := range tok.Attr {
+				if a.Name.Local == "key" {
+					e.key = a.Value
+				}
+			}
+
+			if len(stack) == 0 {
+				root = e
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			}
+
+			stack = append(stack, e)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) != 0 {
+				stack[len(stack)-1].text += string(tok)
+			}
+		}
+	}
+}
+
+// value converts e into the JSON value of a Go value of type t, name is the name of the member e is the value of.
+// Members which t has no field for are left out, as JSON ignores them
+func (e *element) value(t reflect.Type, name string) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// types which decode themselves, e.g. time.Time, are decoded from the text of e
+	if reflect.PtrTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
+		return e.text, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return e.object(jsonFields(t), nil)
+	case reflect.Map:
+		return e.object(nil, t.Elem())
+	case reflect.Slice, reflect.Array:
+		// byte slices are encoded as base64 strings
+		if t.Elem().Kind() == reflect.Uint8 {
+			return e.text, nil
+		}
+
+		return e.array(t.Elem(), name)
+	}
+
+	return e.scalar(t.Kind(), name)
+}
+
+// scalar converts the text of e into the JSON value of a Go value of given kind
+func (e *element) scalar(kind reflect.Kind, name string) (interface{}, error) {
+	text := strings.TrimSpace(e.text)
+
+	switch kind {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("%v must be true or false", name)
+		}
+
+		return b, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		_, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%v must be a number", name)
+		}
+
+		return json.Number(text), nil
+	}
+
+	// strings keep their surrounding spaces
+	return e.text, nil
+}
+
+// object converts the children of e into the members of a JSON object, members are typed by fields
+// or, if fields is nil, by elem
+func (e *element) object(fields map[string]reflect.Type, elem reflect.Type) (interface{}, error) {
+	obj := make(map[string]interface{}, len(e.children))
+
+	for _, c := range e.children {
+		name := c.name
+		if c.key != "" {
+			name = c.key
+		}
+
+		t := elem
+
+		if fields != nil {
+			var ok bool
+
+			t, ok = fields[name]
+			if !ok {
+				continue
+			}
+		}
+
+		value, err := c.value(t, name)
+		if err != nil {
+			return nil, err
+		}
+
+		obj[name] = value
+	}
+
+	return obj, nil
+}
+
+// array converts the children of e into the elements of a JSON array of elem
+func (e *element) array(elem reflect.Type, name string) (interface{}, error) {
+	arr := make([]interface{}, len(e.children))
+
+	for i, c := range e.children {
+		value, err := c.value(elem, name)
+		if err != nil {
+			return nil, err
+		}
+
+		arr[i] = value
+	}
+
+	return arr, nil
+}
+
+// jsonFields maps the JSON names of the fields of the struct type t to their types,
+// the fields of embedded structs are promoted like encoding/json does
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for n, ft := range jsonFields(f.Type) {
+				fields[n] = ft
+			}
+
+			continue
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		fields[name] = f.Type
+	}
+
+	return fields
+}
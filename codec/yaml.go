@@ -0,0 +1,75 @@
+package codec
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// marshalYAML encodes v as a YAML document with the members of its JSON encoding
+func marshalYAML(v interface{}) ([]byte, error) {
+	tree, err := jsonTree(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(yamlNode(tree))
+}
+
+// yamlNode returns the YAML node of a value of a JSON tree, scalars are tagged so that strings are always read back as strings
+func yamlNode(value interface{}) *yaml.Node {
+	switch value := value.(type) {
+	case object:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+
+		for _, m := range value {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: m.name}, yamlNode(m.value))
+		}
+
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+
+		for _, v := range value {
+			node.Content = append(node.Content, yamlNode(v))
+		}
+
+		return node
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(value.String(), ".eE") {
+			tag = "!!float"
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value.String()}
+	case bool:
+		if value {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "false"}
+	}
+
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
+
+// unmarshalYAML decodes the YAML document data into v as if it was the equivalent JSON
+func unmarshalYAML(data []byte, v interface{}) error {
+	var tree interface{}
+
+	err := yaml.Unmarshal(data, &tree)
+	if err != nil {
+		return err
+	}
+
+	// mappings with keys other than strings have no JSON equivalent and fail to encode
+	data, err = json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
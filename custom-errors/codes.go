@@ -0,0 +1,97 @@
+package customerrors
+
+import "net/http"
+
+// Code is a stable, machine-readable identifier of an error returned by the API.
+// Codes are part of the public contract: clients switch on them, so existing
+// values must never be renamed.
+type Code string
+
+const (
+	CodeUnauthorized   Code = "unauthorized"
+	CodeForbidden      Code = "forbidden"
+	CodeCORSRejected   Code = "cors-rejected"
+	CodeInvalidID      Code = "invalid-id"
+	CodeInvalidQuery   Code = "invalid-query-param"
+	CodeMalformedBody  Code = "malformed-body"
+	CodeSchema         Code = "schema-violation"
+	CodeTooLarge       Code = "payload-too-large"
+	CodeUnsupported    Code = "unsupported-media-type"
+	CodeNotAcceptable  Code = "not-acceptable"
+	CodeValidation     Code = "validation-failed"
+	CodeEntityNotFound Code = "entity-not-found"
+	CodeConflict       Code = "conflict"
+	CodeUnavailable    Code = "service-unavailable"
+	CodeDatabase       Code = "database-error"
+	CodeEncoding       Code = "encoding-error"
+)
+
+// Status returns the HTTP status code responses carrying c are sent with
+func (c Code) Status() int {
+	switch c {
+	case CodeUnauthorized:
+		return http.StatusUnauthorized
+	case CodeForbidden, CodeCORSRejected:
+		return http.StatusForbidden
+	case CodeInvalidID, CodeInvalidQuery, CodeMalformedBody, CodeSchema:
+		return http.StatusBadRequest
+	case CodeTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case CodeUnsupported:
+		return http.StatusUnsupportedMediaType
+	case CodeNotAcceptable:
+		return http.StatusNotAcceptable
+	case CodeValidation:
+		return http.StatusUnprocessableEntity
+	case CodeEntityNotFound:
+		return http.StatusNotFound
+	case CodeConflict:
+		return http.StatusConflict
+	case CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case CodeDatabase, CodeEncoding:
+		return http.StatusInternalServerError
+	}
+
+	return http.StatusInternalServerError
+}
+
+// Title returns a short human-readable summary of c, which does not change between occurrences
+func (c Code) Title() string {
+	switch c {
+	case CodeUnauthorized:
+		return "A valid 'x-api-key' must be set in request headers"
+	case CodeForbidden:
+		return "The API key is not allowed to perform this request"
+	case CodeCORSRejected:
+		return "Cross-origin request is not allowed"
+	case CodeInvalidID:
+		return "Invalid ID"
+	case CodeInvalidQuery:
+		return "Invalid query parameter"
+	case CodeMalformedBody:
+		return "Cannot parse given body"
+	case CodeSchema:
+		return "Request body does not match the schema"
+	case CodeTooLarge:
+		return "Request body is too large"
+	case CodeUnsupported:
+		return "Media type is not supported"
+	case CodeNotAcceptable:
+		return "None of the accepted media types can be produced"
+	case CodeValidation:
+		return "Request body has invalid field(s)"
+	case CodeEntityNotFound:
+		return "Entity not found"
+	case CodeConflict:
+		return "Entity conflicts with existing data"
+	case CodeUnavailable:
+		return "Service temporarily unavailable"
+	case CodeDatabase:
+		return "Database error"
+	case CodeEncoding:
+		return "Cannot encode response"
+	}
+
+	return "Internal server error"
+}
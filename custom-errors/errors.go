@@ -1,28 +1,103 @@
 package customerrors
 
 import (
-	"carAPI/model"
+	"errors"
 	"fmt"
+	"strings"
+)
+
+// Sentinel errors classify failures independent of the layer they occurred in.
+// Every error type below matches exactly one of them with errors.Is, also when
+// wrapped, so callers never need to compare error values directly.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrValidation  = errors.New("validation failed")
+	ErrUnavailable = errors.New("unavailable")
 )
 
 type EntityNotExists string
 
-type MissingParams struct {
-	RequiredParams []string
+// Field error codes, describing why a single field of a request is invalid
+const (
+	FieldRequired   = "required"
+	FieldInvalid    = "invalid-value"
+	FieldOutOfRange = "out-of-range"
+	FieldForbidden  = "not-allowed"
+	FieldConflict   = "conflict"
+)
+
+// FieldError describes a single invalid field of a request body
+type FieldError struct {
+	// Path is the JSON pointer of the field, e.g. /engine/range
+	Path    string   `json:"path"`
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Allowed []string `json:"allowed,omitempty"`
+
+	// Rule is the keyword of the schema rule the field violates, it is only set for schema violations
+	Rule string `json:"rule,omitempty"`
+}
+
+// InvalidFields is returned when a request body fails validation, it lists every invalid field
+type InvalidFields []FieldError
+
+// Conflict is returned when a change violates a uniqueness or reference constraint
+type Conflict struct {
+	Entity string
+	Reason string
+	Err    error
 }
 
-type InvalidValue string
+// Unavailable is returned when a dependency such as the DB cannot serve the request right now,
+// retrying the request later may succeed
+type Unavailable struct {
+	Err error
+}
 
 func (e EntityNotExists) Error() string {
 	return fmt.Sprintf("%v not exists", string(e))
 }
 
-func (m MissingParams) Error() string {
-	return fmt.Sprint(m.RequiredParams)
+func (e EntityNotExists) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+func (f InvalidFields) Error() string {
+	paths := make([]string, len(f))
+	for i := range f {
+		paths[i] = f[i].Path
+	}
+
+	return fmt.Sprintf("invalid field(s) %v", strings.Join(paths, ", "))
+}
+
+func (f InvalidFields) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (c Conflict) Error() string {
+	return fmt.Sprintf("%v conflicts: %v", c.Entity, c.Reason)
+}
+
+func (c Conflict) Is(target error) bool {
+	return target == ErrConflict
+}
+
+func (c Conflict) Unwrap() error {
+	return c.Err
+}
+
+func (u Unavailable) Error() string {
+	return fmt.Sprintf("service unavailable: %v", u.Err)
+}
+
+func (u Unavailable) Is(target error) bool {
+	return target == ErrUnavailable
 }
 
-func (i InvalidValue) Error() string {
-	return fmt.Sprintf("Invalid Value of %v", string(i))
+func (u Unavailable) Unwrap() error {
+	return u.Err
 }
 
 func CarNotExists() EntityNotExists {
@@ -35,17 +110,58 @@ func EngineNotExists() EntityNotExists {
 	return e
 }
 
-func InvalidFuelType() InvalidValue {
-	var e InvalidValue = model.ParamFuelType
+func BrandNotExists() EntityNotExists {
+	var e EntityNotExists = "Brand"
 	return e
 }
 
-func InvalidYOM() InvalidValue {
-	var e InvalidValue = model.ParamYearOfManufacture
+func FuelTypeNotExists() EntityNotExists {
+	var e EntityNotExists = "FuelType"
 	return e
 }
 
-func InvalidBrand() InvalidValue {
-	var e InvalidValue = model.ParamBrand
+func ModelNotExists() EntityNotExists {
+	var e EntityNotExists = "Model"
 	return e
 }
+
+func TrimNotExists() EntityNotExists {
+	var e EntityNotExists = "Trim"
+	return e
+}
+
+func CustomerNotExists() EntityNotExists {
+	var e EntityNotExists = "Customer"
+	return e
+}
+
+func OrderNotExists() EntityNotExists {
+	var e EntityNotExists = "Order"
+	return e
+}
+
+func BookingNotExists() EntityNotExists {
+	var e EntityNotExists = "Booking"
+	return e
+}
+
+func DealershipNotExists() EntityNotExists {
+	var e EntityNotExists = "Dealership"
+	return e
+}
+
+func AttachmentNotExists() EntityNotExists {
+	var e EntityNotExists = "Attachment"
+	return e
+}
+
+func ThumbnailNotExists() EntityNotExists {
+	var e EntityNotExists = "Thumbnail"
+	return e
+}
+
+// IsTooLarge reports whether err is the error of a body read past the limit of http.MaxBytesReader,
+// the error has no type of its own
+func IsTooLarge(err error) bool {
+	return err != nil && err.Error() == "http: request body too large"
+}
@@ -0,0 +1,102 @@
+// Package dto maps cars between the domain model and the bodies of the versions of the API, which are held
+// by a package per version, so that the domain model can evolve without changing the bodies of existing versions.
+package dto
+
+import (
+	"carAPI/dto/v1"
+	"carAPI/dto/v2"
+	"carAPI/model"
+)
+
+// Version is a version of the API
+type Version int
+
+const (
+	V1 Version = 1
+	V2 Version = 2
+)
+
+// carBody is the body of a car in a version of the API
+type carBody interface {
+	// Car returns the car of the body
+	Car() model.Car
+}
+
+// body returns the body of car in version v
+func (v Version) body(car *model.Car) carBody {
+	if v == V2 {
+		return v2.NewCar(car)
+	}
+
+	return v1.NewCar(car)
+}
+
+// bodies returns a pointer to an empty slice of the bodies of cars in version v
+func (v Version) bodies() interface{} {
+	if v == V2 {
+		return &[]v2.Car{}
+	}
+
+	return &[]v1.Car{}
+}
+
+// Response returns the body value is written as in version v, cars are mapped to their body and other values are kept
+func (v Version) Response(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *model.Car:
+		return v.body(value)
+	case []model.Car:
+		bodies := make([]carBody, len(value))
+		for i := range value {
+			bodies[i] = v.body(&value[i])
+		}
+
+		return bodies
+	}
+
+	return value
+}
+
+// Request returns the value the body of a request is decoded into in version v to read value, and a function
+// which copies the decoded body into value. Cars are decoded from their body, which starts out with the members of value,
+// so that members which are left out of the body are kept. Other values are decoded as they are
+func (v Version) Request(value interface{}) (body interface{}, done func()) {
+	switch value := value.(type) {
+	case *model.Car:
+		b := v.body(value)
+
+		return b, func() {
+			*value = b.Car()
+		}
+	case *[]model.Car:
+		b := v.bodies()
+
+		return b, func() {
+			*value = cars(b)
+		}
+	}
+
+	return value, func() {}
+}
+
+// cars returns the cars of a pointer to a slice of car bodies
+func cars(bodies interface{}) []model.Car {
+	switch bodies := bodies.(type) {
+	case *[]v1.Car:
+		result := make([]model.Car, len(*bodies))
+		for i := range *bodies {
+			result[i] = (*bodies)[i].Car()
+		}
+
+		return result
+	case *[]v2.Car:
+		result := make([]model.Car, len(*bodies))
+		for i := range *bodies {
+			result[i] = (*bodies)[i].Car()
+		}
+
+		return result
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"carAPI/model"
+)
+
+func car() model.Car {
+	return model.Car{
+		ID:                "c1",
+		Name:              "Model 3",
+		YearOfManufacture: 2020,
+		Brand:             "Tesla",
+		FuelType:          "Electric",
+		Engine:            model.Engine{Range: 500},
+		VIN:               "XP7YGCEL0YB000001",
+		Status:            model.StatusOnLot,
+		Price:             &model.Price{Currency: "EUR", List: 4999900},
+	}
+}
+
+func TestVersion_Response(t *testing.T) {
+	tests := []struct {
+		desc    string
+		version Version
+		value   interface{}
+		body    string
+	}{
+		{
+			"Version 1 keeps the shape of the model",
+			V1,
+			[]model.Car{car()},
+			`[{"carId":"c1","name":"Model 3","yearOfManufacture":2020,"brand":"Tesla","fuelType":"Electric",` +
+				`"engine":{"engineId":"","displacement":0,"noOfCylinders":0,"range":500},"vin":"XP7YGCEL0YB000001","status":"on-lot",` +
+				`"price":{"currency":"EUR","list":4999900}}]`,
+		},
+		{
+			"Version 2",
+			V2,
+			&model.Car{ID: "c2", Name: "Model S", YearOfManufacture: 2021, Brand: "Tesla", FuelType: "Electric"},
+			`{"carId":"c2","name":"Model S","yearOfManufacture":2021,"brand":"Tesla","fuelType":"Electric",` +
+				`"engine":{"engineId":"","displacement":0,"noOfCylinders":0,"range":0},"status":""}`,
+		},
+		{
+			"Other values are kept",
+			V2,
+			model.Brand{Name: "Tesla"},
+			`{"name":"Tesla"}`,
+		},
+	}
+
+	for i, tc := range tests {
+		body, err := json.Marshal(tc.version.Response(tc.value))
+
+		assert.Nilf(t, err, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.body, string(body), "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestVersion_Request(t *testing.T) {
+	// members which are left out of the body are kept
+	patched := car()
+
+	body, done := V2.Request(&patched)
+
+	err := json.Unmarshal([]byte(`{"name":"Model 3 Performance","vin":{"number":"5YJ3E1EA7KF317000"}}`), body)
+	done()
+
+	want := car()
+	want.Name = "Model 3 Performance"
+	want.VIN = "5YJ3E1EA7KF317000"
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, patched)
+
+	var cars []model.Car
+
+	body, done = V2.Request(&cars)
+
+	err = json.Unmarshal([]byte(`[{"name":"Model Y","price":{"list":{"amount":100,"currency":"USD"}}}]`), body)
+	done()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Car{{Name: "Model Y", Price: &model.Price{Currency: "USD", List: 100}}}, cars)
+}
@@ -0,0 +1,130 @@
+// Package v1 holds the bodies of version 1 of the API, their shape is kept as it was when versions were introduced.
+package v1
+
+import (
+	"carAPI/model"
+)
+
+// Car is a car in version 1 of the API
+type Car struct {
+	ID                string       `json:"carId"`
+	Name              string       `json:"name"`
+	YearOfManufacture int          `json:"yearOfManufacture"`
+	Brand             string       `json:"brand"`
+	FuelType          string       `json:"fuelType"`
+	TrimID            string       `json:"trimId,omitempty"`
+	Engine            Engine       `json:"engine"`
+	VIN               string       `json:"vin,omitempty"`
+	Status            model.Status `json:"status,omitempty"`
+	Price             *Price       `json:"price,omitempty"`
+	DealershipID      string       `json:"dealershipId,omitempty"`
+}
+
+// Engine is the engine of a car in version 1 of the API, it has its own type so that changes of model.Engine
+// do not change the body
+type Engine struct {
+	ID              string  `json:"engineId"`
+	Displacement    int     `json:"displacement"`
+	NoOfCylinders   int     `json:"noOfCylinders"`
+	Range           int     `json:"range"`
+	BatteryCapacity float64 `json:"batteryCapacity,omitempty"`
+	ElectricRange   int     `json:"electricRange,omitempty"`
+	TankCapacity    float64 `json:"tankCapacity,omitempty"`
+	NoOfMotors      int     `json:"noOfMotors,omitempty"`
+	Power           int     `json:"power,omitempty"`
+	Torque          int     `json:"torque,omitempty"`
+	Transmission    string  `json:"transmission,omitempty"`
+	Gears           int     `json:"gears,omitempty"`
+	Drivetrain      string  `json:"drivetrain,omitempty"`
+}
+
+// Price is the price of a car in version 1 of the API, amounts are in the minor unit of the currency
+// and the minimum price is only present for admin keys
+type Price struct {
+	Currency string `json:"currency"`
+	List     int64  `json:"list"`
+	Minimum  int64  `json:"minimum,omitempty"`
+}
+
+// NewCar returns the body of car
+func NewCar(car *model.Car) *Car {
+	return &Car{
+		ID:                car.ID,
+		Name:              car.Name,
+		YearOfManufacture: car.YearOfManufacture,
+		Brand:             car.Brand,
+		FuelType:          car.FuelType,
+		TrimID:            car.TrimID,
+		Engine:            newEngine(car.Engine),
+		VIN:               car.VIN,
+		Status:            car.Status,
+		Price:             newPrice(car.Price),
+		DealershipID:      car.DealershipID,
+	}
+}
+
+// Car returns the car of c
+func (c Car) Car() model.Car {
+	car := model.Car{
+		ID:                c.ID,
+		Name:              c.Name,
+		YearOfManufacture: c.YearOfManufacture,
+		Brand:             c.Brand,
+		FuelType:          c.FuelType,
+		TrimID:            c.TrimID,
+		Engine:            c.Engine.engine(),
+		VIN:               c.VIN,
+		Status:            c.Status,
+		DealershipID:      c.DealershipID,
+	}
+
+	if c.Price != nil {
+		car.Price = &model.Price{Currency: c.Price.Currency, List: c.Price.List, Minimum: c.Price.Minimum}
+	}
+
+	return car
+}
+
+func newEngine(e model.Engine) Engine {
+	return Engine{
+		ID:              e.ID,
+		Displacement:    e.Displacement,
+		NoOfCylinders:   e.NoOfCylinders,
+		Range:           e.Range,
+		BatteryCapacity: e.BatteryCapacity,
+		ElectricRange:   e.ElectricRange,
+		TankCapacity:    e.TankCapacity,
+		NoOfMotors:      e.NoOfMotors,
+		Power:           e.Power,
+		Torque:          e.Torque,
+		Transmission:    e.Transmission,
+		Gears:           e.Gears,
+		Drivetrain:      e.Drivetrain,
+	}
+}
+
+func (e Engine) engine() model.Engine {
+	return model.Engine{
+		ID:              e.ID,
+		Displacement:    e.Displacement,
+		NoOfCylinders:   e.NoOfCylinders,
+		Range:           e.Range,
+		BatteryCapacity: e.BatteryCapacity,
+		ElectricRange:   e.ElectricRange,
+		TankCapacity:    e.TankCapacity,
+		NoOfMotors:      e.NoOfMotors,
+		Power:           e.Power,
+		Torque:          e.Torque,
+		Transmission:    e.Transmission,
+		Gears:           e.Gears,
+		Drivetrain:      e.Drivetrain,
+	}
+}
+
+func newPrice(p *model.Price) *Price {
+	if p == nil {
+		return nil
+	}
+
+	return &Price{Currency: p.Currency, List: p.List, Minimum: p.Minimum}
+}
@@ -0,0 +1,172 @@
+// Package v2 holds the bodies of version 2 of the API.
+package v2
+
+import (
+	"carAPI/model"
+	"carAPI/vin"
+)
+
+// Car is a car in version 2 of the API. Unlike version 1 its status is always present, its VIN is decoded
+// and its prices are amounts of money which carry their currency
+type Car struct {
+	ID                string       `json:"carId"`
+	Name              string       `json:"name"`
+	YearOfManufacture int          `json:"yearOfManufacture"`
+	Brand             string       `json:"brand"`
+	FuelType          string       `json:"fuelType"`
+	TrimID            string       `json:"trimId,omitempty"`
+	Engine            Engine       `json:"engine"`
+	VIN               *VIN         `json:"vin,omitempty"`
+	Status            model.Status `json:"status"`
+	Price             *Price       `json:"price,omitempty"`
+	DealershipID      string       `json:"dealershipId,omitempty"`
+}
+
+// Engine is the engine of a car in version 2 of the API, it has its own type so that changes of model.Engine
+// do not change the body
+type Engine struct {
+	ID              string  `json:"engineId"`
+	Displacement    int     `json:"displacement"`
+	NoOfCylinders   int     `json:"noOfCylinders"`
+	Range           int     `json:"range"`
+	BatteryCapacity float64 `json:"batteryCapacity,omitempty"`
+	ElectricRange   int     `json:"electricRange,omitempty"`
+	TankCapacity    float64 `json:"tankCapacity,omitempty"`
+	NoOfMotors      int     `json:"noOfMotors,omitempty"`
+	Power           int     `json:"power,omitempty"`
+	Torque          int     `json:"torque,omitempty"`
+	Transmission    string  `json:"transmission,omitempty"`
+	Gears           int     `json:"gears,omitempty"`
+	Drivetrain      string  `json:"drivetrain,omitempty"`
+}
+
+// VIN is the vehicle identification number of a car with the information decoded from it,
+// only the number is read from requests
+type VIN struct {
+	Number       string `json:"number"`
+	WMI          string `json:"wmi,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	ModelYears   []int  `json:"modelYears,omitempty"`
+}
+
+// Money is an amount in the minor unit of its ISO 4217 currency
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// Price is the price of a car, the minimum price is only present for admin keys
+type Price struct {
+	List    Money  `json:"list"`
+	Minimum *Money `json:"minimum,omitempty"`
+}
+
+// NewCar returns the body of car
+func NewCar(car *model.Car) *Car {
+	return &Car{
+		ID:                car.ID,
+		Name:              car.Name,
+		YearOfManufacture: car.YearOfManufacture,
+		Brand:             car.Brand,
+		FuelType:          car.FuelType,
+		TrimID:            car.TrimID,
+		Engine:            newEngine(car.Engine),
+		VIN:               newVIN(car.VIN),
+		Status:            car.Status,
+		Price:             newPrice(car.Price),
+		DealershipID:      car.DealershipID,
+	}
+}
+
+// Car returns the car of c
+func (c Car) Car() model.Car {
+	car := model.Car{
+		ID:                c.ID,
+		Name:              c.Name,
+		YearOfManufacture: c.YearOfManufacture,
+		Brand:             c.Brand,
+		FuelType:          c.FuelType,
+		TrimID:            c.TrimID,
+		Engine:            c.Engine.engine(),
+		Status:            c.Status,
+		DealershipID:      c.DealershipID,
+	}
+
+	if c.VIN != nil {
+		car.VIN = c.VIN.Number
+	}
+
+	if c.Price != nil {
+		car.Price = &model.Price{Currency: c.Price.List.Currency, List: c.Price.List.Amount}
+
+		if c.Price.Minimum != nil {
+			car.Price.Minimum = c.Price.Minimum.Amount
+		}
+	}
+
+	return car
+}
+
+// newVIN decodes the VIN v, VINs which cannot be decoded only have their number
+func newVIN(v string) *VIN {
+	if v == "" {
+		return nil
+	}
+
+	info, err := vin.Decode(v)
+	if err != nil {
+		return &VIN{Number: v}
+	}
+
+	return &VIN{Number: v, WMI: info.WMI, Manufacturer: info.Manufacturer, ModelYears: info.ModelYears}
+}
+
+func newEngine(e model.Engine) Engine {
+	return Engine{
+		ID:              e.ID,
+		Displacement:    e.Displacement,
+		NoOfCylinders:   e.NoOfCylinders,
+		Range:           e.Range,
+		BatteryCapacity: e.BatteryCapacity,
+		ElectricRange:   e.ElectricRange,
+		TankCapacity:    e.TankCapacity,
+		NoOfMotors:      e.NoOfMotors,
+		Power:           e.Power,
+		Torque:          e.Torque,
+		Transmission:    e.Transmission,
+		Gears:           e.Gears,
+		Drivetrain:      e.Drivetrain,
+	}
+}
+
+func (e Engine) engine() model.Engine {
+	return model.Engine{
+		ID:              e.ID,
+		Displacement:    e.Displacement,
+		NoOfCylinders:   e.NoOfCylinders,
+		Range:           e.Range,
+		BatteryCapacity: e.BatteryCapacity,
+		ElectricRange:   e.ElectricRange,
+		TankCapacity:    e.TankCapacity,
+		NoOfMotors:      e.NoOfMotors,
+		Power:           e.Power,
+		Torque:          e.Torque,
+		Transmission:    e.Transmission,
+		Gears:           e.Gears,
+		Drivetrain:      e.Drivetrain,
+	}
+}
+
+func newPrice(p *model.Price) *Price {
+	if p == nil {
+		return nil
+	}
+
+	price := &Price{List: Money{Amount: p.List, Currency: p.Currency}}
+
+	if p.Minimum != 0 {
+		price.Minimum = &Money{Amount: p.Minimum, Currency: p.Currency}
+	}
+
+	return price
+}
@@ -0,0 +1,95 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"carAPI/model"
+)
+
+func car() model.Car {
+	return model.Car{
+		ID:                "c1",
+		Name:              "Model 3",
+		YearOfManufacture: 2020,
+		Brand:             "Tesla",
+		FuelType:          "Plug-in Hybrid",
+		TrimID:            "t1",
+		Engine: model.Engine{
+			ID:              "e1",
+			Displacement:    1800,
+			NoOfCylinders:   4,
+			Range:           900,
+			BatteryCapacity: 8.8,
+			ElectricRange:   55,
+			TankCapacity:    43,
+			NoOfMotors:      2,
+			Power:           122,
+			Torque:          142,
+			Transmission:    "automatic",
+			Gears:           1,
+			Drivetrain:      "fwd",
+		},
+		VIN:          "5YJ3E1EA6LF000001",
+		Status:       model.StatusOnLot,
+		Price:        &model.Price{Currency: "EUR", List: 3799000, Minimum: 3650000},
+		DealershipID: "d1",
+	}
+}
+
+// golden returns the compacted body in testdata/car.json, it must only change when the shape of version 2 is meant to
+func golden(t *testing.T) string {
+	data, err := os.ReadFile("testdata/car.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+
+	err = json.Compact(&buf, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.String()
+}
+
+func TestNewCar(t *testing.T) {
+	c := car()
+
+	body, err := json.Marshal(NewCar(&c))
+
+	assert.Nil(t, err)
+	assert.Equal(t, golden(t), string(body))
+}
+
+func TestCar_Car(t *testing.T) {
+	tests := []struct {
+		desc string
+		body string
+		car  model.Car
+	}{
+		{"All members", golden(t), car()},
+		{
+			"Optional members left out",
+			`{"carId":"c2","name":"Model 3","yearOfManufacture":2020,"brand":"Tesla","fuelType":"Electric","engine":{"range":500}}`,
+			model.Car{
+				ID: "c2", Name: "Model 3", YearOfManufacture: 2020, Brand: "Tesla", FuelType: "Electric",
+				Engine: model.Engine{Range: 500},
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		var body Car
+
+		err := json.Unmarshal([]byte(tc.body), &body)
+
+		assert.Nilf(t, err, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.car, body.Car(), "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
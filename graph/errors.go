@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"errors"
+	"log"
+
+	customErrors "carAPI/custom-errors"
+)
+
+// queryError is an error of a resolver. Its code and the members of the problem details the REST API would respond with
+// are reported in the extensions of the GraphQL error
+type queryError struct {
+	code    customErrors.Code
+	message string
+	id      string
+	errors  customErrors.InvalidFields
+}
+
+// newError returns the error identified by code, the title of the code is its message if message is empty
+func newError(code customErrors.Code, message, id string) queryError {
+	if message == "" {
+		message = code.Title()
+	}
+
+	return queryError{code: code, message: message, id: id}
+}
+
+func (e queryError) Error() string {
+	return e.message
+}
+
+// Extensions returns the members of the extensions of the error
+func (e queryError) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": e.code}
+
+	if e.id != "" {
+		ext["id"] = e.id
+	}
+
+	if len(e.errors) > 0 {
+		ext["errors"] = e.errors
+	}
+
+	return ext
+}
+
+// classify maps an error of the services to the error of a resolver, like the handlers map it to problem details
+func classify(err error, id string) error {
+	log.Println(err)
+
+	var (
+		notExists customErrors.EntityNotExists
+		conflict  customErrors.Conflict
+		fields    customErrors.InvalidFields
+	)
+
+	// the message is taken from the classified error, so that the context added while wrapping is only logged
+	switch {
+	case errors.As(err, &notExists):
+		return newError(customErrors.CodeEntityNotFound, notExists.Error(), id)
+	case errors.As(err, &conflict):
+		return newError(customErrors.CodeConflict, conflict.Error(), id)
+	case errors.Is(err, customErrors.ErrNotFound):
+		return newError(customErrors.CodeEntityNotFound, "", id)
+	case errors.Is(err, customErrors.ErrConflict):
+		return newError(customErrors.CodeConflict, "", id)
+	case errors.As(err, &fields):
+		e := newError(customErrors.CodeValidation, "", "")
+		e.errors = fields
+
+		return e
+	case errors.Is(err, customErrors.ErrValidation):
+		return newError(customErrors.CodeValidation, err.Error(), "")
+	case errors.Is(err, customErrors.ErrUnavailable):
+		return newError(customErrors.CodeUnavailable, "", "")
+	}
+
+	return newError(customErrors.CodeDatabase, "", "")
+}
@@ -0,0 +1,112 @@
+// Package graph serves cars and their engines over GraphQL. Queries and mutations go through the same services
+// and validation as the REST API, engines are loaded in batches so that a page of cars costs one engine query.
+package graph
+
+import (
+	"context"
+	_ "embed" // the schema is embedded
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync/atomic"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/problem"
+	"carAPI/service"
+	"carAPI/store"
+	"carAPI/validation"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	// maxFirst is the largest page of cars, fields of a page are resolved in parallel so that the engines
+	// of a page are loaded in one batch
+	maxFirst = 100
+
+	// maxPages bounds the pages of cars of a request, so that aliases do not multiply the queries of a request
+	maxPages = 5
+
+	// maxDepth is deep enough for the schema and for the introspection queries of GraphQL tools
+	maxDepth = 15
+
+	// maxBodySize bounds the body of a request, queries are short
+	maxBodySize = 64 << 10
+)
+
+type handler struct {
+	schema  *graphql.Schema
+	engines store.EngineStore
+}
+
+// New parses the schema and binds it to the resolvers of cars and engines
+//
+//nolint:revive //handler should not be exported
+func New(cars service.CarService, engines store.EngineStore, c validation.Catalog) (handler, error) {
+	s, err := graphql.ParseSchema(schema, &resolver{cars: cars, catalog: c},
+		graphql.UseStringDescriptions(), graphql.MaxParallelism(maxFirst), graphql.MaxDepth(maxDepth))
+	if err != nil {
+		return handler{}, err
+	}
+
+	return handler{schema: s, engines: engines}, nil
+}
+
+// request is the body of a GraphQL request
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeHTTP executes the GraphQL request of the body. Errors of resolvers are part of the response,
+// their code is in the extensions of the error
+func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// bodies declared too large are rejected before they are read, the limited reader stops bodies of unknown length
+	if r.ContentLength > maxBodySize {
+		problem.Write(w, r, customErrors.CodeTooLarge, fmt.Sprintf("the body must not be larger than %v bytes", maxBodySize))
+		return
+	}
+
+	var req request
+
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&req)
+	if err != nil {
+		log.Println(err)
+		problem.Write(w, r, customErrors.CodeMalformedBody, err.Error())
+
+		return
+	}
+
+	// every request has its own loader, so that engines are never cached across requests
+	ctx := context.WithValue(r.Context(), loaderKey{}, newEngineLoader(h.engines))
+	ctx = context.WithValue(ctx, pagesKey{}, new(int32))
+
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		log.Println(err)
+		problem.Write(w, r, customErrors.CodeEncoding, "")
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// pagesKey is the context key of the number of pages of cars a request has fetched
+type pagesKey struct{}
+
+// takePage counts a page of cars against the pages of the request of ctx, false is returned if it has fetched maxPages.
+// The fields of a query are resolved in parallel, so the count is atomic
+func takePage(ctx context.Context) bool {
+	pages := ctx.Value(pagesKey{}).(*int32)
+
+	return atomic.AddInt32(pages, 1) <= maxPages
+}
@@ -0,0 +1,368 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nsf/jsondiff"
+	"github.com/stretchr/testify/assert"
+
+	"carAPI/middleware"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func id1() string {
+	return "86a4cc77-4a2b-4215-8a2c-ff3ecca19627"
+}
+
+func id2() string {
+	return "4924f6ff-5684-4d3c-8ca3-24486a1fc205"
+}
+
+func car1() *model.Car {
+	return &model.Car{
+		ID:                id1(),
+		Name:              "Roadster",
+		YearOfManufacture: 2000,
+		Brand:             "Tesla",
+		FuelType:          "Electric",
+		Engine:            model.Engine{ID: "e1", Range: 500},
+		Status:            model.StatusOnLot,
+		Price:             &model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000},
+	}
+}
+
+func car2() *model.Car {
+	return &model.Car{
+		ID:                id2(),
+		Name:              "Model S",
+		YearOfManufacture: 2020,
+		Brand:             "Tesla",
+		FuelType:          "Electric",
+		Engine:            model.Engine{ID: "e2", Range: 600, NoOfMotors: 2},
+		Status:            model.StatusInTransit,
+	}
+}
+
+func catalog(ctrl *gomock.Controller) *mocks.MockCatalog {
+	c := mocks.NewMockCatalog(ctrl)
+
+	c.EXPECT().Brands().Return([]string{"Tesla", "BMW"}, nil).AnyTimes()
+	c.EXPECT().FuelTypes().Return([]string{"Electric", "Petrol"}, nil).AnyTimes()
+	c.EXPECT().EngineRules().Return(map[string]model.EngineRule{
+		"Electric": {Required: []string{"range"}, Forbidden: []string{"displacement", "noOfCylinders", "electricRange", "tankCapacity"}},
+		"Petrol":   {Required: []string{"displacement", "noOfCylinders"}, Forbidden: []string{"batteryCapacity", "electricRange", "noOfMotors"}},
+	}, nil).AnyTimes()
+
+	return c
+}
+
+// serve sends query to h with the API key key and returns the response
+func serve(t *testing.T, h http.Handler, key, query string) *http.Response {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(query))
+	r.Header.Set("x-api-key", key)
+
+	w := httptest.NewRecorder()
+
+	middleware.Auth(map[string]model.APIKey{
+		"admin-key":  {Role: model.RoleAdmin},
+		"viewer-key": {Role: model.RoleViewer},
+		"north-key":  {Role: model.RoleViewer, DealershipID: "d1"},
+	})(h).ServeHTTP(w, r)
+
+	return w.Result()
+}
+
+func assertBody(t *testing.T, i int, desc string, result *http.Response, statusCode int, resp string) {
+	t.Helper()
+
+	body, _ := io.ReadAll(result.Body)
+
+	result.Body.Close()
+
+	assert.Equalf(t, statusCode, result.StatusCode, "Testcase[%v] (%v)", i, desc)
+
+	options := jsondiff.DefaultConsoleOptions()
+	diff, _ := jsondiff.Compare([]byte(resp), body, &options)
+
+	if diff != jsondiff.FullMatch {
+		t.Errorf("Testcase[%v] failed (%v)\nExpected:\n%v\nGot:\n%v", i, desc, resp, string(body))
+	}
+}
+
+func TestHandler_Query(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+	e := mocks.NewMockEngineStore(mockCtrl)
+
+	h, err := New(m, e, catalog(mockCtrl))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withoutEngines := func(cars ...*model.Car) []model.Car {
+		list := make([]model.Car, len(cars))
+		for i, car := range cars {
+			list[i] = *car
+			list[i].Engine = model.Engine{ID: car.Engine.ID}
+		}
+
+		return list
+	}
+
+	// the store fetches the page, it is never sliced in memory
+	m.EXPECT().Page(model.CarFilter{Brand: "Tesla"}, 2, 0).Return(withoutEngines(car1(), car2()), 3, nil)
+	// the engines of a page are fetched in one batch, an engine of two cars is only fetched once
+	e.EXPECT().GetByIDs(gomock.Any()).DoAndReturn(func(ids []string) (map[string]model.Engine, error) {
+		assert.ElementsMatch(t, []string{"e1", "e2"}, ids)
+
+		return map[string]model.Engine{"e1": car1().Engine, "e2": car2().Engine}, nil
+	})
+	m.EXPECT().Page(model.CarFilter{}, 20, 1).Return(withoutEngines(car2()), 2, nil)
+	m.EXPECT().Page(model.CarFilter{}, 20, 0).Return(nil, 0, errors.New("DB error"))
+	m.EXPECT().GetByID(id1()).Return(car1(), nil).Times(2)
+
+	// prices above the range of Int are exact, in the output and in filters
+	expensive := car1()
+	expensive.Price = &model.Price{Currency: "JPY", List: 3000000000, Minimum: 2900000000}
+	m.EXPECT().GetByID(id2()).Return(expensive, nil)
+	m.EXPECT().Page(model.CarFilter{Currency: "JPY", MinPrice: 3000000000, MaxPrice: 5000000000}, 20, 0).
+		Return([]model.Car{}, 0, nil).Times(2)
+
+	tests := []struct {
+		desc  string
+		key   string
+		query string
+		resp  string
+	}{
+		{
+			"Page of cars with their engines",
+			"admin-key",
+			`{"query":"{ cars(filter: {brand: \"Tesla\"}, first: 2) { totalCount hasNextPage nodes { name engine { id range noOfMotors } price { list minimum } } } }"}`,
+			`{"data":{"cars":{"totalCount":3,"hasNextPage":true,"nodes":[` +
+				`{"name":"Roadster","engine":{"id":"e1","range":500,"noOfMotors":null},"price":{"list":"8999900","minimum":"8500000"}},` +
+				`{"name":"Model S","engine":{"id":"e2","range":600,"noOfMotors":2},"price":null}]}}}`,
+		},
+		{
+			"Engines are only fetched if they are selected",
+			"viewer-key",
+			`{"query":"{ cars(offset: 1) { hasNextPage nodes { id status } } }"}`,
+			`{"data":{"cars":{"hasNextPage":false,"nodes":[{"id":"` + id2() + `","status":"in-transit"}]}}}`,
+		},
+		{
+			"Invalid filter",
+			"admin-key",
+			`{"query":"{ cars(filter: {status: \"parked\"}) { totalCount } }"}`,
+			`{"errors":[{"message":"status must be one of in-transit, on-lot, reserved, sold, returned","path":["cars"],` +
+				`"extensions":{"code":"invalid-query-param"}}],"data":null}`,
+		},
+		{
+			"Page size",
+			"admin-key",
+			`{"query":"{ cars(first: 101) { totalCount } }"}`,
+			`{"errors":[{"message":"first must be between 1 and 100","path":["cars"],"extensions":{"code":"invalid-query-param"}}],"data":null}`,
+		},
+		{
+			"DB error",
+			"admin-key",
+			`{"query":"{ cars { totalCount } }"}`,
+			`{"errors":[{"message":"Database error","path":["cars"],"extensions":{"code":"database-error"}}],"data":null}`,
+		},
+		{
+			"Car with the engine fetched by the service and the minimum price hidden",
+			"viewer-key",
+			`{"query":"query($id: ID!) { car(id: $id) { name engine { range } price { currency list minimum } } }",` +
+				`"variables":{"id":"` + id1() + `"}}`,
+			`{"data":{"car":{"name":"Roadster","engine":{"range":500},"price":{"currency":"EUR","list":"8999900","minimum":null}}}}`,
+		},
+		{
+			"Price above the range of Int",
+			"admin-key",
+			`{"query":"{ car(id: \"` + id2() + `\") { price { list minimum } } }"}`,
+			`{"data":{"car":{"price":{"list":"3000000000","minimum":"2900000000"}}}}`,
+		},
+		{
+			"Price bounds above the range of Int",
+			"admin-key",
+			`{"query":"{ cars(filter: {currency: \"JPY\", minPrice: \"3000000000\", maxPrice: \"5000000000\"}) { totalCount } }"}`,
+			`{"data":{"cars":{"totalCount":0}}}`,
+		},
+		{
+			"Price bounds in variables",
+			"admin-key",
+			`{"query":"query($min: Long, $max: Long) { cars(filter: {currency: \"JPY\", minPrice: $min, maxPrice: $max}) { totalCount } }",` +
+				`"variables":{"min":3000000000,"max":"5000000000"}}`,
+			`{"data":{"cars":{"totalCount":0}}}`,
+		},
+		{
+			"Price bound without currency",
+			"admin-key",
+			`{"query":"{ cars(filter: {maxPrice: \"5000000\"}) { totalCount } }"}`,
+			`{"errors":[{"message":"currency is required with minPrice and maxPrice","path":["cars"],` +
+				`"extensions":{"code":"invalid-query-param"}}],"data":null}`,
+		},
+		{
+			"Price bound which is not an integer",
+			"admin-key",
+			`{"query":"query($min: Long) { cars(filter: {minPrice: $min}) { totalCount } }","variables":{"min":1.5}}`,
+			`{"errors":[{"message":"a Long must be an integer of at most 2^53, larger values must be strings"}],"data":{}}`,
+		},
+		{
+			"Car of another dealership",
+			"north-key",
+			`{"query":"{ car(id: \"` + id1() + `\") { name } }"}`,
+			`{"errors":[{"message":"Car not exists","path":["car"],"extensions":{"code":"entity-not-found","id":"` + id1() + `"}}],` +
+				`"data":{"car":null}}`,
+		},
+		{
+			"Invalid ID",
+			"admin-key",
+			`{"query":"{ car(id: \"1\") { name } }"}`,
+			`{"errors":[{"message":"id must be a valid UUID","path":["car"],"extensions":{"code":"invalid-id","id":"1"}}],` +
+				`"data":{"car":null}}`,
+		},
+	}
+
+	for i, tc := range tests {
+		assertBody(t, i, tc.desc, serve(t, h, tc.key, tc.query), http.StatusOK, tc.resp)
+	}
+}
+
+func TestHandler_Limits(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	h, err := New(m, mocks.NewMockEngineStore(mockCtrl), catalog(mockCtrl))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.EXPECT().Page(model.CarFilter{}, 1, 0).Return([]model.Car{}, 0, nil).Times(maxPages)
+
+	// the fields of a query are resolved in parallel, so any one of the pages may be the one which is rejected
+	aliases := make([]string, maxPages+1)
+	for i := range aliases {
+		aliases[i] = fmt.Sprintf("p%v: cars(first: 1) { totalCount }", i)
+	}
+
+	var resp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	result := serve(t, h, "admin-key", `{"query":"{ `+strings.Join(aliases, " ")+` }"}`)
+
+	err = json.NewDecoder(result.Body).Decode(&resp)
+	result.Body.Close()
+
+	assert.Nil(t, err)
+	assert.Len(t, resp.Errors, 1)
+	assert.Equal(t, "a request fetches at most 5 pages of cars", resp.Errors[0].Message)
+
+	// introspection queries of GraphQL tools nest type references, deeper queries are rejected before they are executed
+	query := `{"query":"{ __schema { types { fields { type ` + strings.Repeat("{ ofType ", maxDepth-3) + `{ name }` +
+		strings.Repeat(" }", maxDepth-3) + ` } } } }"}`
+
+	result = serve(t, h, "admin-key", query)
+	body, _ := io.ReadAll(result.Body)
+	result.Body.Close()
+
+	assert.Contains(t, string(body), "exceeds max depth 15")
+
+	result = serve(t, h, "admin-key", `{"query":"{ cars { totalCount } }",`+
+		`"variables":{"padding":"`+strings.Repeat("a", maxBodySize)+`"}}`)
+	result.Body.Close()
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, result.StatusCode)
+}
+
+func TestHandler_Mutation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	// the engines of changed cars are returned by the service, so that the engine store is never called
+	h, err := New(m, mocks.NewMockEngineStore(mockCtrl), catalog(mockCtrl))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created := car2()
+	created.DealershipID = "d1"
+
+	m.EXPECT().Create(&model.Car{Name: "Model S", YearOfManufacture: 2020, Brand: "Tesla", FuelType: "Electric",
+		Engine: model.Engine{Range: 600, NoOfMotors: 2}, DealershipID: "d1"}).Return(created, nil)
+	m.EXPECT().GetByID(id2()).Return(created, nil).Times(2)
+	m.EXPECT().Update(gomock.Any()).DoAndReturn(func(car *model.Car) (*model.Car, error) {
+		assert.Equal(t, id2(), car.ID)
+
+		return created, nil
+	})
+	m.EXPECT().Delete(id2()).Return(nil)
+
+	tests := []struct {
+		desc  string
+		query string
+		resp  string
+	}{
+		{
+			"Create",
+			`{"query":"mutation { createCar(car: {name: \"Model S\", yearOfManufacture: 2020, brand: \"Tesla\", fuelType: \"Electric\", ` +
+				`engine: {range: 600, noOfMotors: 2}}) { id dealershipId engine { range } } }"}`,
+			`{"data":{"createCar":{"id":"` + id2() + `","dealershipId":"d1","engine":{"range":600}}}}`,
+		},
+		{
+			"Invalid car",
+			`{"query":"mutation { createCar(car: {name: \"Model S\", yearOfManufacture: 2020, brand: \"Fiat\", fuelType: \"Electric\", ` +
+				`engine: {range: 600}}) { id } }"}`,
+			`{"errors":[{"message":"Request body has invalid field(s)","path":["createCar"],"extensions":{"code":"validation-failed",` +
+				`"errors":[{"path":"/brand","code":"invalid-value","message":"Fiat is not a valid brand","allowed":["Tesla","BMW"]}]}}],` +
+				`"data":null}`,
+		},
+		{
+			"Update",
+			`{"query":"mutation { updateCar(id: \"` + id2() + `\", car: {name: \"Model S\", yearOfManufacture: 2020, brand: \"Tesla\", ` +
+				`fuelType: \"Electric\", engine: {range: 600}}) { name } }"}`,
+			`{"data":{"updateCar":{"name":"Model S"}}}`,
+		},
+		{
+			"Delete",
+			`{"query":"mutation { deleteCar(id: \"` + id2() + `\") }"}`,
+			`{"data":{"deleteCar":"` + id2() + `"}}`,
+		},
+	}
+
+	for i, tc := range tests {
+		assertBody(t, i, tc.desc, serve(t, h, "north-key", tc.query), http.StatusOK, tc.resp)
+	}
+}
+
+func TestHandler_MalformedBody(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	h, err := New(mocks.NewMockCarService(mockCtrl), mocks.NewMockEngineStore(mockCtrl), catalog(mockCtrl))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertBody(t, 0, "Malformed body", serve(t, h, "admin-key", `{"query":`), http.StatusBadRequest,
+		`{"type":"/problems/malformed-body","title":"Cannot parse given body","status":400,"detail":"unexpected EOF",`+
+			`"instance":"/graphql","code":"malformed-body"}`)
+}
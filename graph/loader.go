@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/dataloader"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store"
+)
+
+// loaderKey is the context key of the engine loader of a request
+type loaderKey struct{}
+
+// newEngineLoader returns a loader which collects the engine IDs requested while a query is resolved
+// and fetches them with one call of engines.GetByIDs
+func newEngineLoader(engines store.EngineStore) *dataloader.Loader {
+	return dataloader.NewBatchedLoader(func(_ context.Context, keys dataloader.Keys) []*dataloader.Result {
+		results := make([]*dataloader.Result, len(keys))
+
+		found, err := engines.GetByIDs(keys.Keys())
+
+		for i, key := range keys {
+			engine, ok := found[key.String()]
+
+			switch {
+			case err != nil:
+				results[i] = &dataloader.Result{Error: err}
+			case !ok:
+				results[i] = &dataloader.Result{Error: customErrors.EngineNotExists()}
+			default:
+				results[i] = &dataloader.Result{Data: engine}
+			}
+		}
+
+		return results
+	})
+}
+
+// loadEngine loads the engine with given ID through the loader of ctx
+func loadEngine(ctx context.Context, id string) (model.Engine, error) {
+	loader := ctx.Value(loaderKey{}).(*dataloader.Loader)
+
+	v, err := loader.Load(ctx, dataloader.StringKey(id))()
+	if err != nil {
+		return model.Engine{}, err
+	}
+
+	return v.(model.Engine), nil
+}
+
+// primeEngine adds the engine of car to the loader of ctx, the engines of cars returned by the service are already loaded
+func primeEngine(ctx context.Context, car *model.Car) {
+	loader := ctx.Value(loaderKey{}).(*dataloader.Loader)
+
+	loader.Prime(ctx, dataloader.StringKey(car.Engine.ID), car.Engine)
+}
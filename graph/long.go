@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// maxExactFloat is the largest integer every float64 up to is exact, JSON variables are decoded as float64
+const maxExactFloat = 1 << 53
+
+// Long is the Long scalar, a 64-bit integer. It is written as a string so that it is exact in every client,
+// GraphQL Int is a 32-bit integer which cannot hold the prices of cars in minor units
+type Long int64
+
+// ImplementsGraphQLType maps Long to the Long scalar of the schema
+func (Long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+// UnmarshalGraphQL reads a Long from a string of decimal digits or from an integer,
+// numbers which are not integers or cannot be represented exactly are rejected
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		n, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a 64-bit integer", input)
+		}
+
+		*l = Long(n)
+	case int32:
+		*l = Long(input)
+	case float64:
+		if input != math.Trunc(input) || math.Abs(input) > maxExactFloat {
+			return errors.New("a Long must be an integer of at most 2^53, larger values must be strings")
+		}
+
+		*l = Long(input)
+	default:
+		return fmt.Errorf("wrong type for Long: %T", input)
+	}
+
+	return nil
+}
+
+// MarshalJSON writes l as a string
+func (l Long) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(l), 10))
+}
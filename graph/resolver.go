@@ -0,0 +1,337 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/middleware"
+	"carAPI/model"
+	"carAPI/service"
+	"carAPI/validation"
+)
+
+// resolver resolves the fields of Query and Mutation
+type resolver struct {
+	cars    service.CarService
+	catalog validation.Catalog
+}
+
+type carsArgs struct {
+	Filter *carFilter
+	First  int32
+	Offset int32
+}
+
+func (r *resolver) Cars(ctx context.Context, args carsArgs) (*carPage, error) {
+	if args.First < 1 || args.First > maxFirst {
+		return nil, newError(customErrors.CodeInvalidQuery, fmt.Sprintf("first must be between 1 and %v", maxFirst), "")
+	}
+
+	if args.Offset < 0 {
+		return nil, newError(customErrors.CodeInvalidQuery, "offset must be a non-negative integer", "")
+	}
+
+	filter, err := args.Filter.filter()
+	if err != nil {
+		return nil, err
+	}
+
+	filter.DealershipID = middleware.Dealership(ctx)
+
+	if !takePage(ctx) {
+		return nil, newError(customErrors.CodeInvalidQuery, fmt.Sprintf("a request fetches at most %v pages of cars", maxPages), "")
+	}
+
+	// the engines are left to the loader, which only fetches them if they are selected
+	cars, total, err := r.cars.Page(filter, int(args.First), int(args.Offset))
+	if err != nil {
+		return nil, classify(err, "")
+	}
+
+	return newCarPage(cars, total, int(args.Offset)), nil
+}
+
+func (r *resolver) Car(ctx context.Context, args struct{ ID graphql.ID }) (*carResolver, error) {
+	car, err := r.owned(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	primeEngine(ctx, car)
+
+	return &carResolver{car: *car}, nil
+}
+
+type carArgs struct {
+	Car carInput
+}
+
+func (r *resolver) CreateCar(ctx context.Context, args carArgs) (*carResolver, error) {
+	car := args.Car.car()
+
+	err := validation.Car(&car, r.catalog)
+	if err != nil {
+		return nil, classify(err, "")
+	}
+
+	// new cars are stocked at the dealership of the API key
+	car.DealershipID = middleware.Dealership(ctx)
+
+	newCar, err := r.cars.Create(&car)
+	if err != nil {
+		return nil, classify(err, "")
+	}
+
+	primeEngine(ctx, newCar)
+
+	return &carResolver{car: *newCar}, nil
+}
+
+type updateCarArgs struct {
+	ID  graphql.ID
+	Car carInput
+}
+
+func (r *resolver) UpdateCar(ctx context.Context, args updateCarArgs) (*carResolver, error) {
+	_, err := r.owned(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	car := args.Car.car()
+
+	err = validation.Car(&car, r.catalog)
+	if err != nil {
+		return nil, classify(err, string(args.ID))
+	}
+
+	car.ID = string(args.ID)
+
+	updatedCar, err := r.cars.Update(&car)
+	if err != nil {
+		return nil, classify(err, car.ID)
+	}
+
+	primeEngine(ctx, updatedCar)
+
+	return &carResolver{car: *updatedCar}, nil
+}
+
+func (r *resolver) DeleteCar(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	_, err := r.owned(ctx, args.ID)
+	if err != nil {
+		return "", err
+	}
+
+	err = r.cars.Delete(string(args.ID))
+	if err != nil {
+		return "", classify(err, string(args.ID))
+	}
+
+	return args.ID, nil
+}
+
+// owned fetches the car with given ID, the cars of other dealerships than the one of the API key are reported as not existing
+func (r *resolver) owned(ctx context.Context, id graphql.ID) (*model.Car, error) {
+	_, err := uuid.Parse(string(id))
+	if err != nil {
+		return nil, newError(customErrors.CodeInvalidID, "id must be a valid UUID", string(id))
+	}
+
+	car, err := r.cars.GetByID(string(id))
+	if err != nil {
+		return nil, classify(err, string(id))
+	}
+
+	if car.DealershipID != middleware.Dealership(ctx) {
+		return nil, classify(customErrors.CarNotExists(), string(id))
+	}
+
+	return car, nil
+}
+
+type carFilter struct {
+	Brand        *string
+	Status       *string
+	Transmission *string
+	Drivetrain   *string
+	MinPower     *int32
+	MaxPower     *int32
+	Currency     *string
+	MinPrice     *Long
+	MaxPrice     *Long
+}
+
+// filter returns the car filter of f, the enums and bounds are checked like the query params of the REST API
+func (f *carFilter) filter() (model.CarFilter, error) {
+	if f == nil {
+		return model.CarFilter{}, nil
+	}
+
+	filter := model.CarFilter{
+		Brand:        str(f.Brand),
+		Status:       model.Status(str(f.Status)),
+		Transmission: str(f.Transmission),
+		Drivetrain:   str(f.Drivetrain),
+		Currency:     str(f.Currency),
+		MinPower:     int(num(f.MinPower)),
+		MaxPower:     int(num(f.MaxPower)),
+		MinPrice:     int64(long(f.MinPrice)),
+		MaxPrice:     int64(long(f.MaxPrice)),
+	}
+
+	enums := []struct {
+		name    string
+		value   string
+		allowed []string
+	}{
+		{"status", string(filter.Status), validation.Statuses()},
+		{"transmission", filter.Transmission, validation.Transmissions()},
+		{"drivetrain", filter.Drivetrain, validation.Drivetrains()},
+		{"currency", filter.Currency, validation.Currencies()},
+	}
+
+	for _, e := range enums {
+		if e.value != "" && !contains(e.allowed, e.value) {
+			return filter, newError(customErrors.CodeInvalidQuery, fmt.Sprintf("%v must be one of %v", e.name, strings.Join(e.allowed, ", ")), "")
+		}
+	}
+
+	bounds := []struct {
+		name string
+		n    int64
+	}{
+		{"minPower", int64(filter.MinPower)},
+		{"maxPower", int64(filter.MaxPower)},
+		{"minPrice", filter.MinPrice},
+		{"maxPrice", filter.MaxPrice},
+	}
+
+	for _, b := range bounds {
+		if b.n < 0 {
+			return filter, newError(customErrors.CodeInvalidQuery, fmt.Sprintf("%v must be a non-negative integer", b.name), "")
+		}
+	}
+
+	if (filter.MinPrice != 0 || filter.MaxPrice != 0) && filter.Currency == "" {
+		return filter, newError(customErrors.CodeInvalidQuery, "currency is required with minPrice and maxPrice", "")
+	}
+
+	return filter, nil
+}
+
+type carInput struct {
+	Name              string
+	YearOfManufacture int32
+	Brand             string
+	FuelType          string
+	TrimID            *graphql.ID
+	Engine            *engineInput
+	VIN               *string
+	Status            *string
+}
+
+// car returns the car of the input
+func (c carInput) car() model.Car {
+	car := model.Car{
+		Name:              c.Name,
+		YearOfManufacture: int(c.YearOfManufacture),
+		Brand:             c.Brand,
+		FuelType:          c.FuelType,
+		VIN:               str(c.VIN),
+		Status:            model.Status(str(c.Status)),
+	}
+
+	if c.TrimID != nil {
+		car.TrimID = string(*c.TrimID)
+	}
+
+	if c.Engine != nil {
+		car.Engine = c.Engine.engine()
+	}
+
+	return car
+}
+
+type engineInput struct {
+	Displacement    *int32
+	NoOfCylinders   *int32
+	Range           *int32
+	BatteryCapacity *float64
+	ElectricRange   *int32
+	TankCapacity    *float64
+	NoOfMotors      *int32
+	Power           *int32
+	Torque          *int32
+	Transmission    *string
+	Gears           *int32
+	Drivetrain      *string
+}
+
+// engine returns the engine of the input, specs which are left out are zero
+func (e engineInput) engine() model.Engine {
+	engine := model.Engine{
+		Displacement:  int(num(e.Displacement)),
+		NoOfCylinders: int(num(e.NoOfCylinders)),
+		Range:         int(num(e.Range)),
+		ElectricRange: int(num(e.ElectricRange)),
+		NoOfMotors:    int(num(e.NoOfMotors)),
+		Power:         int(num(e.Power)),
+		Torque:        int(num(e.Torque)),
+		Transmission:  str(e.Transmission),
+		Gears:         int(num(e.Gears)),
+		Drivetrain:    str(e.Drivetrain),
+	}
+
+	if e.BatteryCapacity != nil {
+		engine.BatteryCapacity = *e.BatteryCapacity
+	}
+
+	if e.TankCapacity != nil {
+		engine.TankCapacity = *e.TankCapacity
+	}
+
+	return engine
+}
+
+// str returns the value of an optional string, the empty string if it is left out
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+// num returns the value of an optional integer, zero if it is left out
+func num(n *int32) int32 {
+	if n == nil {
+		return 0
+	}
+
+	return *n
+}
+
+// long returns the value of an optional Long, zero if it is left out
+func long(n *Long) Long {
+	if n == nil {
+		return 0
+	}
+
+	return *n
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
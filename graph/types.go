@@ -0,0 +1,212 @@
+package graph
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"carAPI/middleware"
+	"carAPI/model"
+)
+
+// carPage is a page of the cars which match a filter
+type carPage struct {
+	cars    []model.Car
+	total   int
+	hasNext bool
+}
+
+// newCarPage returns the page of cars which starts at offset, total is the number of all cars which match the filter
+func newCarPage(cars []model.Car, total, offset int) *carPage {
+	return &carPage{cars: cars, total: total, hasNext: offset+len(cars) < total}
+}
+
+func (p *carPage) TotalCount() int32 {
+	return int32(p.total)
+}
+
+func (p *carPage) HasNextPage() bool {
+	return p.hasNext
+}
+
+func (p *carPage) Nodes() []*carResolver {
+	nodes := make([]*carResolver, len(p.cars))
+	for i := range p.cars {
+		nodes[i] = &carResolver{car: p.cars[i]}
+	}
+
+	return nodes
+}
+
+type carResolver struct {
+	car model.Car
+}
+
+func (c *carResolver) ID() graphql.ID {
+	return graphql.ID(c.car.ID)
+}
+
+func (c *carResolver) Name() string {
+	return c.car.Name
+}
+
+func (c *carResolver) YearOfManufacture() int32 {
+	return int32(c.car.YearOfManufacture)
+}
+
+func (c *carResolver) Brand() string {
+	return c.car.Brand
+}
+
+func (c *carResolver) FuelType() string {
+	return c.car.FuelType
+}
+
+func (c *carResolver) TrimID() *graphql.ID {
+	if c.car.TrimID == "" {
+		return nil
+	}
+
+	id := graphql.ID(c.car.TrimID)
+
+	return &id
+}
+
+// Engine loads the engine of the car, the engines of all cars of a response are fetched together
+func (c *carResolver) Engine(ctx context.Context) (*engineResolver, error) {
+	engine, err := loadEngine(ctx, c.car.Engine.ID)
+	if err != nil {
+		return nil, classify(err, c.car.Engine.ID)
+	}
+
+	return &engineResolver{engine: engine}, nil
+}
+
+func (c *carResolver) VIN() *string {
+	return optString(c.car.VIN)
+}
+
+func (c *carResolver) Status() string {
+	return string(c.car.Status)
+}
+
+// Price returns the price of the car, its minimum price is left out unless the API key is an admin key
+func (c *carResolver) Price(ctx context.Context) *priceResolver {
+	if c.car.Price == nil {
+		return nil
+	}
+
+	return &priceResolver{price: *c.car.Price, withMinimum: middleware.Role(ctx) == model.RoleAdmin}
+}
+
+func (c *carResolver) DealershipID() graphql.ID {
+	return graphql.ID(c.car.DealershipID)
+}
+
+type engineResolver struct {
+	engine model.Engine
+}
+
+func (e *engineResolver) ID() graphql.ID {
+	return graphql.ID(e.engine.ID)
+}
+
+func (e *engineResolver) Displacement() *int32 {
+	return optInt(e.engine.Displacement)
+}
+
+func (e *engineResolver) NoOfCylinders() *int32 {
+	return optInt(e.engine.NoOfCylinders)
+}
+
+func (e *engineResolver) Range() *int32 {
+	return optInt(e.engine.Range)
+}
+
+func (e *engineResolver) BatteryCapacity() *float64 {
+	return optFloat(e.engine.BatteryCapacity)
+}
+
+func (e *engineResolver) ElectricRange() *int32 {
+	return optInt(e.engine.ElectricRange)
+}
+
+func (e *engineResolver) TankCapacity() *float64 {
+	return optFloat(e.engine.TankCapacity)
+}
+
+func (e *engineResolver) NoOfMotors() *int32 {
+	return optInt(e.engine.NoOfMotors)
+}
+
+func (e *engineResolver) Power() *int32 {
+	return optInt(e.engine.Power)
+}
+
+func (e *engineResolver) Torque() *int32 {
+	return optInt(e.engine.Torque)
+}
+
+func (e *engineResolver) Transmission() *string {
+	return optString(e.engine.Transmission)
+}
+
+func (e *engineResolver) Gears() *int32 {
+	return optInt(e.engine.Gears)
+}
+
+func (e *engineResolver) Drivetrain() *string {
+	return optString(e.engine.Drivetrain)
+}
+
+type priceResolver struct {
+	price       model.Price
+	withMinimum bool
+}
+
+func (p *priceResolver) Currency() string {
+	return p.price.Currency
+}
+
+func (p *priceResolver) List() Long {
+	return Long(p.price.List)
+}
+
+func (p *priceResolver) Minimum() *Long {
+	if !p.withMinimum || p.price.Minimum == 0 {
+		return nil
+	}
+
+	minimum := Long(p.price.Minimum)
+
+	return &minimum
+}
+
+// optInt returns nil for zero, which stands for an unknown value
+func optInt(n int) *int32 {
+	if n == 0 {
+		return nil
+	}
+
+	v := int32(n)
+
+	return &v
+}
+
+// optFloat returns nil for zero, which stands for an unknown value
+func optFloat(f float64) *float64 {
+	if f == 0 {
+		return nil
+	}
+
+	return &f
+}
+
+// optString returns nil for the empty string, which stands for an unknown value
+func optString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
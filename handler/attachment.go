@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/problem"
+	"carAPI/service"
+	"carAPI/validation"
+)
+
+// multipartOverhead is the room left for the headers and boundaries of a multipart body besides the file
+const multipartOverhead = 1 << 20
+
+type attachmentHandler struct {
+	svc service.AttachmentService
+}
+
+//nolint:revive //attachmentHandler should not be exported
+func NewAttachment(s service.AttachmentService) attachmentHandler {
+	return attachmentHandler{svc: s}
+}
+
+func (h attachmentHandler) GetByCar(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	attachments, err := h.svc.GetByCar(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, attachments)
+}
+
+// Upload attaches the file of a multipart/form-data body to a car, its content type is sniffed from the content
+func (h attachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	maxBody := int64(model.MaxDocumentSize + multipartOverhead)
+
+	// bodies declared too large are rejected before they are read, the limited reader stops bodies of unknown length
+	if r.ContentLength > maxBody {
+		writeTooLarge(w, r, maxBody)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+
+	file, header, err := r.FormFile(model.ParamFile)
+	if err != nil {
+		handleParseErr(w, r, err)
+		return
+	}
+
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		handleParseErr(w, r, err)
+		return
+	}
+
+	contentType := http.DetectContentType(data)
+
+	kind, ok := validation.AttachmentKind(contentType)
+	if !ok {
+		problem.Write(w, r, customErrors.CodeUnsupported,
+			contentType+" is not supported, allowed are "+strings.Join(validation.ContentTypes(), ", "))
+
+		return
+	}
+
+	if maxSize := validation.MaxAttachmentSize(kind); int64(len(data)) > maxSize {
+		writeTooLarge(w, r, maxSize)
+		return
+	}
+
+	attachment := model.Attachment{CarID: id, Kind: kind, FileName: fileName(header.Filename), ContentType: contentType}
+
+	newAttachment, err := h.svc.Upload(&attachment, data)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusCreated, newAttachment)
+}
+
+// Download writes the content of an attachment, photos are shown inline and documents are downloaded
+func (h attachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	carID, attachmentID, ok := readAttachmentID(w, r)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := h.svc.Open(carID, attachmentID)
+	if err != nil {
+		handleServerErr(w, r, err, attachmentID)
+		return
+	}
+
+	defer content.Close()
+
+	disposition := "attachment"
+	if attachment.Kind == model.AttachmentPhoto {
+		disposition = "inline"
+	}
+
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+
+	writeContent(w, attachment.ContentType, content)
+}
+
+func (h attachmentHandler) Thumbnail(w http.ResponseWriter, r *http.Request) {
+	carID, attachmentID, ok := readAttachmentID(w, r)
+	if !ok {
+		return
+	}
+
+	_, content, err := h.svc.OpenThumbnail(carID, attachmentID)
+	if err != nil {
+		handleServerErr(w, r, err, attachmentID)
+		return
+	}
+
+	defer content.Close()
+
+	writeContent(w, "image/jpeg", content)
+}
+
+func (h attachmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	carID, attachmentID, ok := readAttachmentID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.svc.Delete(carID, attachmentID)
+	if err != nil {
+		handleServerErr(w, r, err, attachmentID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readAttachmentID parses the id and attachmentId path variables, false is returned if an error response has been written
+func readAttachmentID(w http.ResponseWriter, r *http.Request) (carID, attachmentID string, ok bool) {
+	carID, ok = readID(w, r)
+	if !ok {
+		return "", "", false
+	}
+
+	attachmentID = mux.Vars(r)["attachmentId"]
+
+	err := parseID(attachmentID)
+	if err != nil {
+		handleIDErr(w, r, err, attachmentID)
+		return "", "", false
+	}
+
+	return carID, attachmentID, true
+}
+
+// writeContent writes content as the body of the response, the content type is never sniffed by browsers
+func writeContent(w http.ResponseWriter, contentType string, content io.Reader) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	_, err := io.Copy(w, content)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func writeTooLarge(w http.ResponseWriter, r *http.Request, maxSize int64) {
+	problem.Write(w, r, customErrors.CodeTooLarge, model.ParamFile+" must not be larger than "+strconv.FormatInt(maxSize, 10)+" bytes")
+}
+
+// fileName keeps the base name of a file name sent by a client, cut to the maximum length
+func fileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+
+	if name == "." || name == "/" {
+		return model.ParamFile
+	}
+
+	if len(name) > model.MaxFileNameLength {
+		// a character cut in half is dropped
+		name = strings.ToValidUTF8(name[:model.MaxFileNameLength], "")
+	}
+
+	return name
+}
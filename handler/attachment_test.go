@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+// pngSignature starts the content of every PNG image, it is enough for the content type to be sniffed
+func pngSignature() []byte {
+	return []byte("\x89PNG\r\n\x1a\n")
+}
+
+// multipartBody returns a multipart/form-data body with content as its file and the content type of the body
+func multipartBody(field, name string, content []byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+
+	fw, _ := mw.CreateFormFile(field, name)
+	_, _ = fw.Write(content)
+
+	mw.Close()
+
+	return &body, mw.FormDataContentType()
+}
+
+func TestAttachmentHandler_Upload(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockAttachmentService(mockCtrl)
+
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	m.EXPECT().Upload(&model.Attachment{CarID: id1(), Kind: model.AttachmentPhoto, FileName: "front.png", ContentType: "image/png"},
+		pngSignature()).
+		Return(&model.Attachment{ID: id2(), CarID: id1(), Kind: model.AttachmentPhoto, FileName: "front.png", ContentType: "image/png",
+			Size: 8, HasThumbnail: true, CreatedAt: at}, nil)
+
+	tests := []struct {
+		desc       string
+		field      string
+		name       string
+		content    []byte
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Photo",
+			"file",
+			`C:\photos\front.png`,
+			pngSignature(),
+			http.StatusCreated,
+			[]byte(`{"attachmentId":"4924f6ff-5684-4d3c-8ca3-24486a1fc205","carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627",
+							"kind":"photo","fileName":"front.png","contentType":"image/png","size":8,"hasThumbnail":true,
+							"createdAt":"2024-05-01T10:00:00Z"}`),
+		},
+		{
+			"Unsupported content type",
+			"file",
+			"notes.txt",
+			[]byte("call the customer back"),
+			http.StatusUnsupportedMediaType,
+			[]byte(`{"type":"/problems/unsupported-media-type","title":"Media type is not supported","status":415,
+							"detail":"text/plain; charset=utf-8 is not supported, allowed are image/jpeg, image/png, image/gif, application/pdf",
+							"instance":"/car/attachments","code":"unsupported-media-type"}`),
+		},
+		{
+			"Photo too large",
+			"file",
+			"huge.png",
+			append(pngSignature(), make([]byte, model.MaxPhotoSize)...),
+			http.StatusRequestEntityTooLarge,
+			[]byte(`{"type":"/problems/payload-too-large","title":"Request body is too large","status":413,
+							"detail":"file must not be larger than 10485760 bytes","instance":"/car/attachments","code":"payload-too-large"}`),
+		},
+		{
+			"Missing file",
+			"photo",
+			"front.png",
+			pngSignature(),
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/malformed-body","title":"Cannot parse given body","status":400,
+							"detail":"http: no such file","instance":"/car/attachments","code":"malformed-body"}`),
+		},
+	}
+
+	h := NewAttachment(m)
+
+	for i, tc := range tests {
+		body, contentType := multipartBody(tc.field, tc.name, tc.content)
+
+		r := httptest.NewRequest(http.MethodPost, "/car/attachments", body)
+		r.Header.Set("Content-Type", contentType)
+		r = mux.SetURLVars(r, map[string]string{"id": id1()})
+		w := httptest.NewRecorder()
+
+		h.Upload(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestAttachmentHandler_Download(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockAttachmentService(mockCtrl)
+
+	report := &model.Attachment{ID: id2(), CarID: id1(), Kind: model.AttachmentDocument, FileName: "inspection report.pdf",
+		ContentType: "application/pdf", Size: 8}
+
+	m.EXPECT().Open(id1(), id2()).Return(report, io.NopCloser(bytes.NewReader([]byte("%PDF-1.7"))), nil)
+	m.EXPECT().Open(id1(), id3()).Return(nil, nil, customErrors.AttachmentNotExists())
+
+	h := NewAttachment(m)
+
+	r := httptest.NewRequest(http.MethodGet, "/car/attachments", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": id1(), "attachmentId": id2()})
+	w := httptest.NewRecorder()
+
+	h.Download(w, r)
+
+	result := w.Result()
+	body, _ := io.ReadAll(result.Body)
+
+	result.Body.Close()
+
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Equal(t, "%PDF-1.7", string(body))
+	assert.Equal(t, "application/pdf", result.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="inspection report.pdf"`, result.Header.Get("Content-Disposition"))
+	assert.Equal(t, "nosniff", result.Header.Get("X-Content-Type-Options"))
+
+	r = httptest.NewRequest(http.MethodGet, "/car/attachments", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": id1(), "attachmentId": id3()})
+	w = httptest.NewRecorder()
+
+	h.Download(w, r)
+
+	assertResponse(t, 1, "Attachment not exists", w.Result(), http.StatusNotFound,
+		[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Attachment not exists",
+							"instance":"/car/attachments","code":"entity-not-found","id":"568492e8-df97-47ff-a0f2-18b638f767a6"}`))
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/problem"
+	"carAPI/service"
+	"carAPI/validation"
+)
+
+type bookingHandler struct {
+	svc  service.BookingService
+	cars service.CarService
+}
+
+// NewBooking returns the handler of bookings, bookings are scoped to the dealership of the API key through their cars,
+// which are fetched from c
+//
+//nolint:revive //bookingHandler should not be exported
+func NewBooking(s service.BookingService, c service.CarService) bookingHandler {
+	return bookingHandler{svc: s, cars: c}
+}
+
+func (h bookingHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	booking, ok := h.owned(w, r, id)
+	if !ok {
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, booking)
+}
+
+// owned fetches the booking with given ID, bookings of cars of other dealerships than the one of the API key of r
+// are not found. false is returned if an error response has been written
+func (h bookingHandler) owned(w http.ResponseWriter, r *http.Request, id string) (*model.Booking, bool) {
+	booking, err := h.svc.GetByID(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return nil, false
+	}
+
+	owns, err := ownsCarID(h.cars, r, booking.CarID)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return nil, false
+	}
+
+	if !owns {
+		handleServerErr(w, r, customErrors.BookingNotExists(), id)
+		return nil, false
+	}
+
+	return booking, true
+}
+
+// GetByCar fetches all bookings of the car with the id of the path
+func (h bookingHandler) GetByCar(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	bookings, err := h.svc.GetByCar(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, bookings)
+}
+
+func (h bookingHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var booking model.Booking
+
+	if !readBody(w, r, &booking) {
+		return
+	}
+
+	err := validation.Booking(&booking)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return
+	}
+
+	// cars of other dealerships cannot be booked, they are not found as by Owned
+	owns, err := ownsCarID(h.cars, r, booking.CarID)
+	if err != nil {
+		handleServerErr(w, r, err, booking.CarID)
+		return
+	}
+
+	if !owns {
+		handleServerErr(w, r, customErrors.CarNotExists(), booking.CarID)
+		return
+	}
+
+	newBooking, err := h.svc.Create(&booking)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusCreated, newBooking)
+}
+
+func (h bookingHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	_, ok = h.owned(w, r, id)
+	if !ok {
+		return
+	}
+
+	booking, err := h.svc.Cancel(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, booking)
+}
+
+// Availability returns the free slots of the car with the id of the path between the from and to dates, both included.
+// Dates are in UTC.
+func (h bookingHandler) Availability(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	slot, ok := readDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	slots, err := h.svc.Availability(id, slot)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, slots)
+}
+
+// readDateRange reads the from and to dates from the query params into a slot which ends at the end of the to date,
+// false is returned if an error response has been written
+func readDateRange(w http.ResponseWriter, r *http.Request) (model.Slot, bool) {
+	q := r.URL.Query()
+
+	var dates [2]time.Time
+
+	for i, param := range []string{model.ParamFrom, model.ParamTo} {
+		date, err := time.Parse(model.DateLayout, q.Get(param))
+		if err != nil {
+			writeQueryErr(w, r, param, fmt.Sprintf("%v must be a date formatted as YYYY-MM-DD", param))
+			return model.Slot{}, false
+		}
+
+		dates[i] = date
+	}
+
+	slot := model.Slot{Start: dates[0], End: dates[1].AddDate(0, 0, 1)}
+
+	if !slot.End.After(slot.Start) || slot.End.After(slot.Start.AddDate(0, 0, model.MaxAvailabilityDays)) {
+		writeQueryErr(w, r, model.ParamTo,
+			fmt.Sprintf("%v must not be before %v and at most %v days after it", model.ParamTo, model.ParamFrom, model.MaxAvailabilityDays-1))
+
+		return model.Slot{}, false
+	}
+
+	return slot, true
+}
+
+func writeQueryErr(w http.ResponseWriter, r *http.Request, param, detail string) {
+	p := problem.New(customErrors.CodeInvalidQuery, detail)
+	p.Param = param
+	p.Write(w, r)
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestBookingHandler_Availability(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockBookingService(mockCtrl)
+
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	m.EXPECT().Availability(id1(), model.Slot{Start: day, End: day.AddDate(0, 0, 2)}).
+		Return([]model.Slot{{Start: day, End: day.Add(10 * time.Hour)}, {Start: day.Add(11 * time.Hour), End: day.AddDate(0, 0, 2)}}, nil)
+
+	tests := []struct {
+		desc       string
+		params     string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success, the to date is included",
+			"?from=2024-05-01&to=2024-05-02",
+			http.StatusOK,
+			[]byte(`[{"start":"2024-05-01T00:00:00Z","end":"2024-05-01T10:00:00Z"},{"start":"2024-05-01T11:00:00Z","end":"2024-05-03T00:00:00Z"}]`),
+		},
+		{
+			"Missing from",
+			"?to=2024-05-02",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,
+							"detail":"from must be a date formatted as YYYY-MM-DD","instance":"/car/availability","code":"invalid-query-param",
+							"param":"from"}`),
+		},
+		{
+			"Range too long",
+			"?from=2024-05-01&to=2024-06-01",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,
+							"detail":"to must not be before from and at most 30 days after it","instance":"/car/availability",
+							"code":"invalid-query-param","param":"to"}`),
+		},
+	}
+
+	h := NewBooking(m, nil)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/car/availability"+tc.params, nil)
+		r = mux.SetURLVars(r, map[string]string{"id": id1()})
+		w := httptest.NewRecorder()
+
+		h.Availability(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func booking1() *model.Booking {
+	start := time.Date(2099, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	return &model.Booking{ID: id2(), CarID: id1(), CustomerID: "c1", Kind: model.BookingTestDrive, Start: start,
+		End: start.Add(time.Hour), Status: model.BookingActive}
+}
+
+// TestBookingHandler_Dealership tests that bookings are scoped to the dealership of the API key through their car
+func TestBookingHandler_Dealership(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockBookingService(mockCtrl)
+	cars := mocks.NewMockCarService(mockCtrl)
+
+	north := car1()
+	north.DealershipID = "d1"
+
+	cancelled := booking1()
+	cancelled.Status = model.BookingCancelled
+
+	m.EXPECT().GetByID(id2()).Return(booking1(), nil).Times(4)
+	m.EXPECT().Cancel(id2()).Return(cancelled, nil)
+	m.EXPECT().Create(gomock.Any()).Return(booking1(), nil)
+	cars.EXPECT().GetByID(id1()).Return(north, nil).Times(6)
+
+	bookingResp := func(status string) []byte {
+		return []byte(`{"bookingId":"` + id2() + `","carId":"` + id1() + `","customerId":"c1","kind":"test-drive",` +
+			`"start":"2099-05-01T10:00:00Z","end":"2099-05-01T11:00:00Z","status":"` + status + `","createdAt":"0001-01-01T00:00:00Z"}`)
+	}
+
+	notExists := func(entity, id string) []byte {
+		return []byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"` + entity +
+			` not exists","instance":"/bookings","code":"entity-not-found","id":"` + id + `"}`)
+	}
+
+	body := `{"carId":"` + id1() + `","kind":"test-drive","start":"2099-05-01T10:00:00Z","end":"2099-05-01T11:00:00Z"}`
+
+	h := NewBooking(m, cars)
+
+	tests := []struct {
+		desc       string
+		method     string
+		handler    http.HandlerFunc
+		body       string
+		key        string
+		statusCode int
+		resp       []byte
+	}{
+		{"Booking of the dealership of the key", http.MethodGet, h.GetByID, "", "north-key", http.StatusOK, bookingResp("active")},
+		{"Booking of another dealership", http.MethodGet, h.GetByID, "", "south-key", http.StatusNotFound, notExists("Booking", id2())},
+		{"Cancel a booking of another dealership", http.MethodPost, h.Cancel, "", "south-key", http.StatusNotFound,
+			notExists("Booking", id2())},
+		{"Cancel a booking of the dealership of the key", http.MethodPost, h.Cancel, "", "north-key", http.StatusOK,
+			bookingResp("cancelled")},
+		{"Book a car of another dealership", http.MethodPost, h.Create, body, "south-key", http.StatusNotFound,
+			notExists("Car", id1())},
+		{"Book a car of the dealership of the key", http.MethodPost, h.Create, body, "north-key", http.StatusCreated,
+			bookingResp("active")},
+	}
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(tc.method, "/bookings", strings.NewReader(tc.body))
+		r = mux.SetURLVars(r, map[string]string{"id": id2()})
+		w := httptest.NewRecorder()
+
+		withKey(tc.handler, r, tc.key).ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestBookingHandler_CarNotExists(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockBookingService(mockCtrl)
+	cars := mocks.NewMockCarService(mockCtrl)
+
+	m.EXPECT().GetByID(id2()).Return(booking1(), nil)
+	cars.EXPECT().GetByID(id1()).Return(nil, customErrors.CarNotExists())
+
+	r := httptest.NewRequest(http.MethodGet, "/bookings", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": id2()})
+	w := httptest.NewRecorder()
+
+	withKey(NewBooking(m, cars).GetByID, r, "north-key").ServeHTTP(w, r)
+
+	assertResponse(t, 0, "Booking of a deleted car", w.Result(), http.StatusNotFound,
+		[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Booking not exists",
+					"instance":"/bookings","code":"entity-not-found","id":"`+id2()+`"}`))
+}
@@ -0,0 +1,322 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/middleware"
+	"carAPI/model"
+	"carAPI/problem"
+	"carAPI/validation"
+)
+
+// bulkResult is the outcome of one item of a best-effort bulk request, Car is the body of the car
+// in the version of the API of the request
+type bulkResult struct {
+	Index  int                        `json:"index"`
+	Status int                        `json:"status"`
+	Car    interface{}                `json:"car,omitempty"`
+	ID     string                     `json:"carId,omitempty"`
+	Errors customErrors.InvalidFields `json:"errors,omitempty"`
+}
+
+// bulkRequest runs the items of a bulk request which have passed the checks of the handler through the service
+type bulkRequest struct {
+	// n is the number of items, errs holds the invalid fields of the items by their index
+	n      int
+	atomic bool
+	errs   map[int]customErrors.InvalidFields
+
+	// apply passes the items with given indexes to the service, it returns their invalid fields by their position in indexes
+	apply func(indexes []int) (map[int]customErrors.InvalidFields, error)
+
+	// result gives the outcome of the item with given index once it has been applied
+	result func(i int) bulkResult
+
+	// done writes the response of an atomic request once all items have been applied
+	done func()
+}
+
+// CreateMany creates the cars of the body, an array of cars, at the dealership of the API key
+func (h handler) CreateMany(w http.ResponseWriter, r *http.Request) {
+	var cars []model.Car
+
+	atomic, ok := readBulk(w, r, &cars, func() int { return len(cars) })
+	if !ok {
+		return
+	}
+
+	errs := make(map[int]customErrors.InvalidFields)
+
+	for i := range cars {
+		if !h.validateBulkCar(w, r, &cars[i], i, errs) {
+			return
+		}
+
+		// new cars are stocked at the dealership of the API key
+		cars[i].DealershipID = middleware.Dealership(r.Context())
+	}
+
+	bulkRequest{
+		n:      len(cars),
+		atomic: atomic,
+		errs:   errs,
+		apply: func(indexes []int) (map[int]customErrors.InvalidFields, error) {
+			return h.svc.CreateMany(carsAt(cars, indexes), atomic)
+		},
+		result: func(i int) bulkResult {
+			hideMinimumPrice(r, &cars[i])
+			return bulkResult{Index: i, Status: http.StatusCreated, Car: middleware.Version(r.Context()).Response(&cars[i])}
+		},
+		done: func() {
+			hideMinimumPrices(r, cars)
+			writeResponse(w, r, http.StatusCreated, cars)
+		},
+	}.run(w, r)
+}
+
+// UpdateMany updates the cars of the body, an array of cars which are identified by their carId
+func (h handler) UpdateMany(w http.ResponseWriter, r *http.Request) {
+	var cars []model.Car
+
+	atomic, ok := readBulk(w, r, &cars, func() int { return len(cars) })
+	if !ok {
+		return
+	}
+
+	errs := make(map[int]customErrors.InvalidFields)
+
+	for i := range cars {
+		if parseID(cars[i].ID) != nil {
+			errs[i] = customErrors.InvalidFields{{
+				Path:    "/" + model.ParamCarID,
+				Code:    customErrors.FieldInvalid,
+				Message: "carId must be a valid UUID",
+			}}
+			continue
+		}
+
+		if !h.validateBulkCar(w, r, &cars[i], i, errs) {
+			return
+		}
+	}
+
+	bulkRequest{
+		n:      len(cars),
+		atomic: atomic,
+		errs:   errs,
+		apply: func(indexes []int) (map[int]customErrors.InvalidFields, error) {
+			return h.svc.UpdateMany(middleware.Dealership(r.Context()), carsAt(cars, indexes), atomic)
+		},
+		result: func(i int) bulkResult {
+			hideMinimumPrice(r, &cars[i])
+			return bulkResult{Index: i, Status: http.StatusOK, Car: middleware.Version(r.Context()).Response(&cars[i])}
+		},
+		done: func() {
+			hideMinimumPrices(r, cars)
+			writeResponse(w, r, http.StatusOK, cars)
+		},
+	}.run(w, r)
+}
+
+// DeleteMany deletes the cars of the body, an array of car IDs
+func (h handler) DeleteMany(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+
+	atomic, ok := readBulk(w, r, &ids, func() int { return len(ids) })
+	if !ok {
+		return
+	}
+
+	errs := make(map[int]customErrors.InvalidFields)
+
+	for i, id := range ids {
+		if parseID(id) != nil {
+			errs[i] = customErrors.InvalidFields{{Code: customErrors.FieldInvalid, Message: "id must be a valid UUID"}}
+		}
+	}
+
+	bulkRequest{
+		n:      len(ids),
+		atomic: atomic,
+		errs:   errs,
+		apply: func(indexes []int) (map[int]customErrors.InvalidFields, error) {
+			pending := make([]string, len(indexes))
+			for j, i := range indexes {
+				pending[j] = ids[i]
+			}
+
+			return h.svc.DeleteMany(middleware.Dealership(r.Context()), pending, atomic)
+		},
+		result: func(i int) bulkResult {
+			return bulkResult{Index: i, Status: http.StatusNoContent, ID: ids[i]}
+		},
+		done: func() {
+			w.WriteHeader(http.StatusNoContent)
+		},
+	}.run(w, r)
+}
+
+// run applies the items without errors and writes the response. Atomic requests fail as a whole with the invalid fields
+// of all items, best-effort requests respond with the outcome of every item
+func (b bulkRequest) run(w http.ResponseWriter, r *http.Request) {
+	if b.atomic && len(b.errs) != 0 {
+		writeBulkErr(w, r, b.errs)
+		return
+	}
+
+	indexes := make([]int, 0, b.n)
+
+	for i := 0; i < b.n; i++ {
+		if _, ok := b.errs[i]; !ok {
+			indexes = append(indexes, i)
+		}
+	}
+
+	errs, err := b.apply(indexes)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	// the service reports items by their position in indexes
+	for j, fields := range errs {
+		b.errs[indexes[j]] = fields
+	}
+
+	if b.atomic {
+		if len(b.errs) != 0 {
+			writeBulkErr(w, r, b.errs)
+			return
+		}
+
+		b.done()
+
+		return
+	}
+
+	results := make([]bulkResult, b.n)
+
+	for i := range results {
+		fields, ok := b.errs[i]
+		if !ok {
+			results[i] = b.result(i)
+			continue
+		}
+
+		results[i] = bulkResult{Index: i, Status: itemStatus(fields), Errors: fields}
+	}
+
+	writeResponse(w, r, http.StatusMultiStatus, results)
+}
+
+// itemStatus returns the status of an item of a bulk request with given invalid fields,
+// items which conflict with existing data are answered as conflicts
+func itemStatus(fields customErrors.InvalidFields) int {
+	for _, field := range fields {
+		if field.Code == customErrors.FieldConflict {
+			return http.StatusConflict
+		}
+	}
+
+	return http.StatusUnprocessableEntity
+}
+
+// readBulk reads the mode query param and unmarshals the body into items, count gives the number of items read.
+// False is returned if an error response has been written
+func readBulk(w http.ResponseWriter, r *http.Request, items interface{}, count func() int) (atomic, ok bool) {
+	mode := r.URL.Query().Get(model.ParamMode)
+
+	switch mode {
+	case "", model.BulkModeAtomic:
+		atomic = true
+	case model.BulkModeBestEffort:
+	default:
+		p := problem.New(customErrors.CodeInvalidQuery,
+			fmt.Sprintf("%v must be one of %v, %v", model.ParamMode, model.BulkModeAtomic, model.BulkModeBestEffort))
+		p.Param = model.ParamMode
+		p.Write(w, r)
+
+		return false, false
+	}
+
+	// the number of items is only known once the body is read, its size bounds the body read up to then
+	if r.ContentLength > model.MaxBulkBodySize {
+		problem.Write(w, r, customErrors.CodeTooLarge, fmt.Sprintf("a bulk request has at most %v bytes", model.MaxBulkBodySize))
+		return false, false
+	}
+
+	if !readLimitedBody(w, r, items, model.MaxBulkBodySize) {
+		return false, false
+	}
+
+	if count() > model.MaxBulkItems {
+		problem.Write(w, r, customErrors.CodeTooLarge, fmt.Sprintf("a bulk request has at most %v items", model.MaxBulkItems))
+		return false, false
+	}
+
+	return atomic, true
+}
+
+// validateBulkCar validates the car at index i of a bulk request and adds its invalid fields to errs,
+// false is returned if an error response has been written
+func (h handler) validateBulkCar(w http.ResponseWriter, r *http.Request, car *model.Car, i int,
+	errs map[int]customErrors.InvalidFields) bool {
+	var fields customErrors.InvalidFields
+
+	err := validation.Car(car, h.catalog)
+	if errors.As(err, &fields) {
+		errs[i] = append(errs[i], fields...)
+		return true
+	}
+
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return false
+	}
+
+	return true
+}
+
+// writeBulkErr writes the invalid fields of all items of a bulk request,
+// their paths are prefixed with the index of their item
+func writeBulkErr(w http.ResponseWriter, r *http.Request, errs map[int]customErrors.InvalidFields) {
+	indexes := make([]int, 0, len(errs))
+	for i := range errs {
+		indexes = append(indexes, i)
+	}
+
+	sort.Ints(indexes)
+
+	var fields customErrors.InvalidFields
+
+	for _, i := range indexes {
+		for _, field := range errs[i] {
+			field.Path = "/" + strconv.Itoa(i) + field.Path
+			fields = append(fields, field)
+		}
+	}
+
+	handleValidationErr(w, r, fields)
+}
+
+// carsAt returns pointers to the cars with given indexes
+func carsAt(cars []model.Car, indexes []int) []*model.Car {
+	pending := make([]*model.Car, len(indexes))
+	for j, i := range indexes {
+		pending[j] = &cars[i]
+	}
+
+	return pending
+}
+
+// hideMinimumPrices removes the minimum prices from cars unless the API key of r may see them
+func hideMinimumPrices(r *http.Request, cars []model.Car) {
+	for i := range cars {
+		hideMinimumPrice(r, &cars[i])
+	}
+}
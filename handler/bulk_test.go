@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestHandler_CreateMany(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	roadster := model.Car{Name: "Roadster", YearOfManufacture: 2000, Brand: "Tesla", FuelType: "Electric",
+		Engine: model.Engine{Range: 500}, DealershipID: "d1"}
+	abc := model.Car{Name: "Abc", YearOfManufacture: 2020, Brand: "Ferrari", FuelType: "Diesel",
+		Engine: model.Engine{Displacement: 600, NoOfCylinders: 4}, DealershipID: "d1"}
+
+	// the service gives the created cars their IDs
+	m.EXPECT().CreateMany([]*model.Car{&roadster, &abc}, true).
+		DoAndReturn(func(cars []*model.Car, atomic bool) (map[int]customErrors.InvalidFields, error) {
+			cars[0].ID, cars[0].Engine.ID = id1(), id2()
+			cars[1].ID, cars[1].Engine.ID = id3(), id4()
+
+			return map[int]customErrors.InvalidFields{}, nil
+		})
+
+	// the second car is reported by its position among the cars passed to the service
+	m.EXPECT().CreateMany([]*model.Car{&roadster, &abc}, false).
+		DoAndReturn(func(cars []*model.Car, atomic bool) (map[int]customErrors.InvalidFields, error) {
+			cars[0].ID, cars[0].Engine.ID = id1(), id2()
+
+			return map[int]customErrors.InvalidFields{
+				1: {{Path: "/trimId", Code: customErrors.FieldInvalid, Message: "trim t1 not exists"}},
+			}, nil
+		})
+
+	m.EXPECT().CreateMany([]*model.Car{&roadster}, true).Return(nil, errors.New("server error"))
+
+	// cars which conflict with existing data are answered as conflicts
+	m.EXPECT().CreateMany([]*model.Car{&roadster}, false).Return(map[int]customErrors.InvalidFields{
+		0: {{Path: "/vin", Code: customErrors.FieldConflict, Message: "a car with VIN XP7YGCEL0YB000001 already exists"}},
+	}, nil)
+
+	roadsterBody := `{"name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric","engine":{"range":500}}`
+	abcBody := `{"name":"Abc","yearOfManufacture":2020,"brand":"Ferrari","fuelType":"Diesel","engine":{"displacement":600,"noOfCylinders":4}}`
+	invalidBody := `{"name":"Roadster","yearOfManufacture":2000,"brand":"Pesla","fuelType":"Electric","engine":{"range":500}}`
+
+	tests := []struct {
+		desc       string
+		query      string
+		body       io.Reader
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Atomic success",
+			"",
+			strings.NewReader("[" + roadsterBody + "," + abcBody + "]"),
+			http.StatusCreated,
+			[]byte(`[{"carId":"` + id1() + `","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric",
+						"engine":{"engineId":"` + id2() + `","displacement":0,"noOfCylinders":0,"range":500},"dealershipId":"d1"},
+					{"carId":"` + id3() + `","name":"Abc","yearOfManufacture":2020,"brand":"Ferrari","fuelType":"Diesel",
+						"engine":{"engineId":"` + id4() + `","displacement":600,"noOfCylinders":4,"range":0},"dealershipId":"d1"}]`),
+		},
+		{
+			"Atomic validation error",
+			"?mode=atomic",
+			strings.NewReader("[" + roadsterBody + "," + invalidBody + "]"),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car/bulk",
+						"code":"validation-failed","errors":[{"path":"/1/brand","code":"invalid-value","message":"Pesla is not a valid brand",
+						"allowed":["Tesla","Ferrari","BMW","Porsche"]}]}`),
+		},
+		{
+			"Best effort",
+			"?mode=best-effort",
+			strings.NewReader("[" + roadsterBody + "," + invalidBody + "," + abcBody + "]"),
+			http.StatusMultiStatus,
+			[]byte(`[{"index":0,"status":201,"car":{"carId":"` + id1() + `","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla",
+						"fuelType":"Electric","engine":{"engineId":"` + id2() + `","displacement":0,"noOfCylinders":0,"range":500},"dealershipId":"d1"}},
+					{"index":1,"status":422,"errors":[{"path":"/brand","code":"invalid-value","message":"Pesla is not a valid brand",
+						"allowed":["Tesla","Ferrari","BMW","Porsche"]}]},
+					{"index":2,"status":422,"errors":[{"path":"/trimId","code":"invalid-value","message":"trim t1 not exists"}]}]`),
+		},
+		{
+			"Best effort conflict",
+			"?mode=best-effort",
+			strings.NewReader("[" + roadsterBody + "]"),
+			http.StatusMultiStatus,
+			[]byte(`[{"index":0,"status":409,"errors":[{"path":"/vin","code":"conflict",
+						"message":"a car with VIN XP7YGCEL0YB000001 already exists"}]}]`),
+		},
+		{
+			"Server error",
+			"",
+			strings.NewReader("[" + roadsterBody + "]"),
+			http.StatusInternalServerError,
+			[]byte(`{"type":"/problems/database-error","title":"Database error","status":500,"instance":"/car/bulk","code":"database-error"}`),
+		},
+		{
+			"Invalid mode",
+			"?mode=some",
+			strings.NewReader("[]"),
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,
+						"detail":"mode must be one of atomic, best-effort","instance":"/car/bulk","code":"invalid-query-param","param":"mode"}`),
+		},
+		{
+			"Too many cars",
+			"",
+			strings.NewReader("[" + strings.Repeat(roadsterBody+",", model.MaxBulkItems) + roadsterBody + "]"),
+			http.StatusRequestEntityTooLarge,
+			[]byte(`{"type":"/problems/payload-too-large","title":"Request body is too large","status":413,
+						"detail":"a bulk request has at most 500 items","instance":"/car/bulk","code":"payload-too-large"}`),
+		},
+		{
+			"Body too large",
+			"",
+			bytes.NewReader(bytes.Repeat([]byte(" "), model.MaxBulkBodySize+1)),
+			http.StatusRequestEntityTooLarge,
+			[]byte(`{"type":"/problems/payload-too-large","title":"Request body is too large","status":413,
+						"detail":"a bulk request has at most 2048000 bytes","instance":"/car/bulk","code":"payload-too-large"}`),
+		},
+		{
+			// the size of the body is not declared
+			"Body of unknown size too large",
+			"",
+			io.MultiReader(bytes.NewReader(bytes.Repeat([]byte(" "), model.MaxBulkBodySize+1))),
+			http.StatusRequestEntityTooLarge,
+			[]byte(`{"type":"/problems/payload-too-large","title":"Request body is too large","status":413,
+						"detail":"the body is too large","instance":"/car/bulk","code":"payload-too-large"}`),
+		},
+		{
+			"Not an array",
+			"",
+			bytes.NewReader([]byte(roadsterBody)),
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/malformed-body","title":"Cannot parse given body","status":400,
+						"detail":"the body has members of the wrong type","instance":"/car/bulk","code":"malformed-body",
+						"errors":[{"path":"","code":"invalid-value","message":"the body must be an array"}]}`),
+		},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/car/bulk"+tc.query, tc.body)
+		w := httptest.NewRecorder()
+
+		withKey(h.CreateMany, r, "north-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestHandler_UpdateMany(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	roadster := car3()
+	roadster.Engine.Range = 500
+
+	m.EXPECT().UpdateMany("d1", []*model.Car{roadster}, false).
+		DoAndReturn(func(dealershipID string, cars []*model.Car, atomic bool) (map[int]customErrors.InvalidFields, error) {
+			cars[0].Engine.ID = id2()
+			cars[0].Price = &model.Price{Currency: "EUR", List: 5000000, Minimum: 4500000}
+			return map[int]customErrors.InvalidFields{}, nil
+		})
+
+	roadsterBody := `{"carId":"` + id1() + `","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric",
+		"engine":{"range":500}}`
+
+	tests := []struct {
+		desc       string
+		body       io.Reader
+		statusCode int
+		resp       []byte
+	}{
+		{
+			// the minimum price is hidden from viewer keys
+			"Best effort with invalid carId",
+			strings.NewReader("[" + roadsterBody + `,{"carId":"1","name":"Roadster"}]`),
+			http.StatusMultiStatus,
+			[]byte(`[{"index":0,"status":200,"car":{"carId":"` + id1() + `","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla",
+						"fuelType":"Electric","engine":{"engineId":"` + id2() + `","displacement":0,"noOfCylinders":0,"range":500},
+						"price":{"currency":"EUR","list":5000000}}},
+					{"index":1,"status":422,"errors":[{"path":"/carId","code":"invalid-value","message":"carId must be a valid UUID"}]}]`),
+		},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPut, "/car/bulk?mode=best-effort", tc.body)
+		w := httptest.NewRecorder()
+
+		withKey(h.UpdateMany, r, "north-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestHandler_DeleteMany(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	notExists := customErrors.InvalidFields{{Code: customErrors.FieldInvalid, Message: "car " + id2() + " not exists or is listed more than once"}}
+
+	m.EXPECT().DeleteMany("d1", []string{id1(), id2()}, true).Return(map[int]customErrors.InvalidFields{}, nil)
+	m.EXPECT().DeleteMany("d1", []string{id1(), id2()}, true).Return(map[int]customErrors.InvalidFields{1: notExists}, nil)
+
+	tests := []struct {
+		desc       string
+		body       io.Reader
+		statusCode int
+		resp       []byte
+	}{
+		{"Success", strings.NewReader(`["` + id1() + `","` + id2() + `"]`), http.StatusNoContent, nil},
+		{
+			"Car not exists",
+			strings.NewReader(`["` + id1() + `","` + id2() + `"]`),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car/bulk",
+						"code":"validation-failed","errors":[{"path":"/1","code":"invalid-value",
+						"message":"car ` + id2() + ` not exists or is listed more than once"}]}`),
+		},
+		{
+			"Invalid ID",
+			strings.NewReader(`["` + id1() + `","1"]`),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car/bulk",
+						"code":"validation-failed","errors":[{"path":"/1","code":"invalid-value","message":"id must be a valid UUID"}]}`),
+		},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodDelete, "/car/bulk", tc.body)
+		w := httptest.NewRecorder()
+
+		withKey(h.DeleteMany, r, "north-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"carAPI/model"
+	"carAPI/service"
+	"carAPI/validation"
+)
+
+type catalogHandler struct {
+	svc service.CatalogService
+}
+
+//nolint:revive //catalogHandler should not be exported
+func NewCatalog(s service.CatalogService) catalogHandler {
+	return catalogHandler{svc: s}
+}
+
+func (h catalogHandler) GetBrands(w http.ResponseWriter, r *http.Request) {
+	brands, err := h.svc.GetBrands()
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, brands)
+}
+
+func (h catalogHandler) CreateBrand(w http.ResponseWriter, r *http.Request) {
+	var brand model.Brand
+
+	if !readCatalogEntry(w, r, &brand, &brand.Name) {
+		return
+	}
+
+	newBrand, err := h.svc.CreateBrand(&brand)
+	if err != nil {
+		handleServerErr(w, r, err, brand.Name)
+		return
+	}
+
+	writeResponse(w, r, http.StatusCreated, newBrand)
+}
+
+func (h catalogHandler) UpdateBrand(w http.ResponseWriter, r *http.Request) {
+	var brand model.Brand
+
+	if !readCatalogEntry(w, r, &brand, &brand.Name) {
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+
+	updatedBrand, err := h.svc.UpdateBrand(name, &brand)
+	if err != nil {
+		handleServerErr(w, r, err, name)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, updatedBrand)
+}
+
+func (h catalogHandler) DeleteBrand(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	err := h.svc.DeleteBrand(name)
+	if err != nil {
+		handleServerErr(w, r, err, name)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h catalogHandler) GetFuelTypes(w http.ResponseWriter, r *http.Request) {
+	fuelTypes, err := h.svc.GetFuelTypes()
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, fuelTypes)
+}
+
+func (h catalogHandler) CreateFuelType(w http.ResponseWriter, r *http.Request) {
+	var fuelType model.FuelType
+
+	if !readFuelType(w, r, &fuelType, false) {
+		return
+	}
+
+	newFuelType, err := h.svc.CreateFuelType(&fuelType)
+	if err != nil {
+		handleServerErr(w, r, err, fuelType.Name)
+		return
+	}
+
+	writeResponse(w, r, http.StatusCreated, newFuelType)
+}
+
+func (h catalogHandler) UpdateFuelType(w http.ResponseWriter, r *http.Request) {
+	var fuelType model.FuelType
+
+	if !readFuelType(w, r, &fuelType, true) {
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+
+	updatedFuelType, err := h.svc.UpdateFuelType(name, &fuelType)
+	if err != nil {
+		handleServerErr(w, r, err, name)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, updatedFuelType)
+}
+
+func (h catalogHandler) DeleteFuelType(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	err := h.svc.DeleteFuelType(name)
+	if err != nil {
+		handleServerErr(w, r, err, name)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h catalogHandler) GetModels(w http.ResponseWriter, r *http.Request) {
+	brand := mux.Vars(r)["name"]
+
+	models, err := h.svc.GetModels(brand)
+	if err != nil {
+		handleServerErr(w, r, err, brand)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, models)
+}
+
+func (h catalogHandler) CreateModel(w http.ResponseWriter, r *http.Request) {
+	var carModel model.CarModel
+
+	if !readBody(w, r, &carModel) {
+		return
+	}
+
+	// validate model
+	err := validation.CarModel(&carModel, h.svc)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	newModel, err := h.svc.CreateModel(&carModel)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusCreated, newModel)
+}
+
+func (h catalogHandler) UpdateModel(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	var carModel model.CarModel
+
+	if !readCatalogEntry(w, r, &carModel, &carModel.Name) {
+		return
+	}
+
+	carModel.ID = id
+
+	updatedModel, err := h.svc.UpdateModel(&carModel)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, updatedModel)
+}
+
+func (h catalogHandler) DeleteModel(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.svc.DeleteModel(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h catalogHandler) GetTrims(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	trims, err := h.svc.GetTrims(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, trims)
+}
+
+func (h catalogHandler) GetTrim(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	trim, err := h.svc.GetTrim(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, trim)
+}
+
+func (h catalogHandler) CreateTrim(w http.ResponseWriter, r *http.Request) {
+	var trim model.Trim
+
+	if !readBody(w, r, &trim) {
+		return
+	}
+
+	// validate trim
+	err := validation.Trim(&trim)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	newTrim, err := h.svc.CreateTrim(&trim)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusCreated, newTrim)
+}
+
+func (h catalogHandler) UpdateTrim(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	var trim model.Trim
+
+	if !readBody(w, r, &trim) {
+		return
+	}
+
+	trim.ID = id
+
+	err := validation.Trim(&trim)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	updatedTrim, err := h.svc.UpdateTrim(&trim)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, updatedTrim)
+}
+
+func (h catalogHandler) DeleteTrim(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.svc.DeleteTrim(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readCatalogEntry unmarshals the body into entry and validates its name,
+// false is returned if an error response has been written
+func readCatalogEntry(w http.ResponseWriter, r *http.Request, entry interface{}, name *string) bool {
+	if !readBody(w, r, entry) {
+		return false
+	}
+
+	err := validation.CatalogName(*name)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return false
+	}
+
+	return true
+}
+
+// readFuelType reads and validates a fuel type with its engine rule from the body of r,
+// false is returned if an error response has been written
+func readFuelType(w http.ResponseWriter, r *http.Request, fuelType *model.FuelType, update bool) bool {
+	if !readBody(w, r, fuelType) {
+		return false
+	}
+
+	err := validation.FuelType(fuelType, update)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return false
+	}
+
+	return true
+}
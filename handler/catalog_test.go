@@ -0,0 +1,321 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/nsf/jsondiff"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestCatalogHandler_GetBrands(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCatalogService(mockCtrl)
+
+	m.EXPECT().GetBrands().Return([]model.Brand{{Name: "BMW"}, {Name: "Tesla"}}, nil)
+	m.EXPECT().GetBrands().Return(nil, errors.New("server error"))
+
+	tests := []struct {
+		desc       string
+		statusCode int
+		resp       []byte
+	}{
+		{"Success", http.StatusOK, []byte(`[{"name":"BMW"},{"name":"Tesla"}]`)},
+		{
+			"Server error",
+			http.StatusInternalServerError,
+			[]byte(`{"type":"/problems/database-error","title":"Database error","status":500,"instance":"/brands","code":"database-error"}`),
+		},
+	}
+
+	h := NewCatalog(m)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/brands", nil)
+		w := httptest.NewRecorder()
+
+		h.GetBrands(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestCatalogHandler_CreateFuelType(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCatalogService(mockCtrl)
+
+	cng := &model.FuelType{Name: "CNG", Engine: &model.EngineRule{Required: []string{"tankCapacity"}, Forbidden: []string{}}}
+
+	m.EXPECT().CreateFuelType(cng).Return(cng, nil)
+	m.EXPECT().CreateFuelType(&model.FuelType{Name: "Diesel", Engine: &model.EngineRule{}}).
+		Return(nil, customErrors.Conflict{Entity: "FuelType", Reason: "duplicate entry"})
+
+	tests := []struct {
+		desc       string
+		body       io.Reader
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			bytes.NewReader([]byte(`{"name":"CNG","engine":{"required":["tankCapacity"],"forbidden":[]}}`)),
+			http.StatusCreated,
+			[]byte(`{"name":"CNG","engine":{"required":["tankCapacity"],"forbidden":[]}}`),
+		},
+		{
+			"Duplicate",
+			bytes.NewReader([]byte(`{"name":"Diesel","engine":{}}`)),
+			http.StatusConflict,
+			[]byte(`{"type":"/problems/conflict","title":"Entity conflicts with existing data","status":409,
+							"detail":"FuelType conflicts: duplicate entry","instance":"/fuel-types","code":"conflict","id":"Diesel"}`),
+		},
+		{
+			"Missing name and engine rule",
+			bytes.NewReader([]byte(`{}`)),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,
+							"instance":"/fuel-types","code":"validation-failed",
+							"errors":[{"path":"/name","code":"required","message":"name is required"},
+								{"path":"/engine","code":"required",
+									"message":"engine is required, it lists the required and forbidden engine params of the fuel type"}]}`),
+		},
+		{
+			"Unknown engine param",
+			bytes.NewReader([]byte(`{"name":"CNG","engine":{"required":["wheels"]}}`)),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,
+							"instance":"/fuel-types","code":"validation-failed",
+							"errors":[{"path":"/engine/required/0","code":"invalid-value","message":"wheels is not an engine param",
+								"allowed":["displacement","noOfCylinders","range","batteryCapacity","electricRange","tankCapacity","noOfMotors"]}]}`),
+		},
+	}
+
+	h := NewCatalog(m)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/fuel-types", tc.body)
+		w := httptest.NewRecorder()
+
+		h.CreateFuelType(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestCatalogHandler_DeleteBrand(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCatalogService(mockCtrl)
+
+	m.EXPECT().DeleteBrand("Audi").Return(nil)
+	m.EXPECT().DeleteBrand("Opel").Return(customErrors.BrandNotExists())
+
+	tests := []struct {
+		desc       string
+		name       string
+		statusCode int
+		resp       []byte
+	}{
+		{"Success", "Audi", http.StatusNoContent, nil},
+		{
+			"Brand not exists",
+			"Opel",
+			http.StatusNotFound,
+			[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Brand not exists",
+							"instance":"/brands/Opel","code":"entity-not-found","id":"Opel"}`),
+		},
+	}
+
+	h := NewCatalog(m)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodDelete, "/brands/"+tc.name, nil)
+		r = mux.SetURLVars(r, map[string]string{"name": tc.name})
+		w := httptest.NewRecorder()
+
+		h.DeleteBrand(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestCatalogHandler_GetModels(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCatalogService(mockCtrl)
+
+	m.EXPECT().GetModels("Tesla").Return([]model.CarModel{{ID: id1(), Brand: "Tesla", Name: "Model 3", CarCount: 2}}, nil)
+	m.EXPECT().GetModels("Opel").Return(nil, customErrors.BrandNotExists())
+
+	tests := []struct {
+		desc       string
+		brand      string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			"Tesla",
+			http.StatusOK,
+			[]byte(`[{"modelId":"` + id1() + `","brand":"Tesla","name":"Model 3","carCount":2}]`),
+		},
+		{
+			"Brand not exists",
+			"Opel",
+			http.StatusNotFound,
+			[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Brand not exists",
+							"instance":"/brands/Opel/models","code":"entity-not-found","id":"Opel"}`),
+		},
+	}
+
+	h := NewCatalog(m)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/brands/"+tc.brand+"/models", nil)
+		r = mux.SetURLVars(r, map[string]string{"name": tc.brand})
+		w := httptest.NewRecorder()
+
+		h.GetModels(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestCatalogHandler_CreateTrim(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCatalogService(mockCtrl)
+
+	m.EXPECT().CreateTrim(&model.Trim{ModelID: id1(), Name: "Long Range", Engine: model.Engine{Range: 500}}).
+		Return(&model.Trim{ID: id2(), ModelID: id1(), Name: "Long Range", Engine: model.Engine{ID: id3(), Range: 500}, Brand: "Tesla"}, nil)
+
+	tests := []struct {
+		desc       string
+		body       io.Reader
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			bytes.NewReader([]byte(`{"modelId":"` + id1() + `","name":"Long Range","engine":{"range":500}}`)),
+			http.StatusCreated,
+			[]byte(`{"trimId":"` + id2() + `","modelId":"` + id1() + `","name":"Long Range",
+							"engine":{"engineId":"` + id3() + `","displacement":0,"noOfCylinders":0,"range":500},"brand":"Tesla","carCount":0}`),
+		},
+		{
+			"Negative engine value",
+			bytes.NewReader([]byte(`{"modelId":"` + id1() + `","name":"Long Range","engine":{"range":-1}}`)),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,
+							"instance":"/trims","code":"validation-failed",
+							"errors":[{"path":"/engine/range","code":"out-of-range","message":"range must not be negative"}]}`),
+		},
+	}
+
+	h := NewCatalog(m)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/trims", tc.body)
+		w := httptest.NewRecorder()
+
+		h.CreateTrim(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestCatalogHandler_GetTrim(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCatalogService(mockCtrl)
+
+	m.EXPECT().GetTrim(id1()).Return(&model.Trim{ID: id1(), ModelID: id2(), Name: "Performance", Brand: "Tesla", CarCount: 1,
+		Engine: model.Engine{ID: id3(), Range: 450}}, nil)
+	m.EXPECT().GetTrim(id2()).Return(nil, customErrors.TrimNotExists())
+
+	tests := []struct {
+		desc       string
+		id         string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			id1(),
+			http.StatusOK,
+			[]byte(`{"trimId":"` + id1() + `","modelId":"` + id2() + `","name":"Performance",
+							"engine":{"engineId":"` + id3() + `","displacement":0,"noOfCylinders":0,"range":450},"brand":"Tesla","carCount":1}`),
+		},
+		{
+			"Trim not exists",
+			id2(),
+			http.StatusNotFound,
+			[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Trim not exists",
+							"instance":"/trims/` + id2() + `","code":"entity-not-found","id":"` + id2() + `"}`),
+		},
+		{
+			"Invalid ID",
+			"abc",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-id","title":"Invalid ID","status":400,"detail":"id must be a valid UUID",
+							"instance":"/trims/abc","code":"invalid-id","id":"abc"}`),
+		},
+	}
+
+	h := NewCatalog(m)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/trims/"+tc.id, nil)
+		r = mux.SetURLVars(r, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		h.GetTrim(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+// assertResponse checks the status code of result and compares its body as JSON, an empty body is expected for a nil resp
+func assertResponse(t *testing.T, i int, desc string, result *http.Response, statusCode int, resp []byte) {
+	t.Helper()
+
+	body, _ := io.ReadAll(result.Body)
+
+	result.Body.Close()
+
+	if result.StatusCode != statusCode {
+		t.Errorf("Testcase[%v] failed (%v)\nExpected status %v\tGot %v", i, desc, statusCode, result.StatusCode)
+	}
+
+	if resp == nil {
+		if len(body) != 0 {
+			t.Errorf("Testcase[%v] failed (%v)\nExpected empty body\nGot:\n%v", i, desc, string(body))
+		}
+
+		return
+	}
+
+	options := jsondiff.DefaultConsoleOptions()
+	diff, _ := jsondiff.Compare(resp, body, &options)
+
+	if diff != jsondiff.FullMatch {
+		t.Errorf("Testcase[%v] failed (%v)\nExpected:\n%v\nGot:\n%v", i, desc, string(resp), string(body))
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+
+	"carAPI/model"
+	"carAPI/service"
+	"carAPI/validation"
+)
+
+type customerHandler struct {
+	svc service.CustomerService
+}
+
+//nolint:revive //customerHandler should not be exported
+func NewCustomer(s service.CustomerService) customerHandler {
+	return customerHandler{svc: s}
+}
+
+func (h customerHandler) Get(w http.ResponseWriter, r *http.Request) {
+	customers, err := h.svc.GetAll()
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, customers)
+}
+
+func (h customerHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	customer, err := h.svc.GetByID(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, customer)
+}
+
+func (h customerHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var customer model.Customer
+
+	if !readBody(w, r, &customer) {
+		return
+	}
+
+	err := validation.Customer(&customer)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return
+	}
+
+	newCustomer, err := h.svc.Create(&customer)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusCreated, newCustomer)
+}
+
+func (h customerHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	var customer model.Customer
+
+	if !readBody(w, r, &customer) {
+		return
+	}
+
+	err := validation.Customer(&customer)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return
+	}
+
+	customer.ID = id
+
+	updatedCustomer, err := h.svc.Update(&customer)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, updatedCustomer)
+}
+
+func (h customerHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.svc.Delete(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestCustomerHandler_Create(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCustomerService(mockCtrl)
+
+	m.EXPECT().Create(&model.Customer{Name: "Jane Doe", Email: "jane@example.com"}).
+		Return(&model.Customer{ID: id2(), Name: "Jane Doe", Email: "jane@example.com"}, nil)
+	m.EXPECT().Create(&model.Customer{Name: "John Roe", Email: "jane@example.com"}).
+		Return(nil, customErrors.Conflict{Entity: "Customer", Reason: "duplicate entry"})
+
+	tests := []struct {
+		desc       string
+		body       string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			`{"name":"Jane Doe","email":"jane@example.com"}`,
+			http.StatusCreated,
+			[]byte(`{"customerId":"4924f6ff-5684-4d3c-8ca3-24486a1fc205","name":"Jane Doe","email":"jane@example.com"}`),
+		},
+		{
+			"Email exists",
+			`{"name":"John Roe","email":"jane@example.com"}`,
+			http.StatusConflict,
+			[]byte(`{"type":"/problems/conflict","title":"Entity conflicts with existing data","status":409,
+							"detail":"Customer conflicts: duplicate entry","instance":"/customers","code":"conflict"}`),
+		},
+		{
+			"Invalid email",
+			`{"name":"Jane Doe","email":"jane"}`,
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,
+							"instance":"/customers","code":"validation-failed",
+							"errors":[{"path":"/email","code":"invalid-value","message":"jane is not a valid email address"}]}`),
+		},
+	}
+
+	h := NewCustomer(m)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/customers", bytes.NewReader([]byte(tc.body)))
+		w := httptest.NewRecorder()
+
+		h.Create(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+
+	"carAPI/middleware"
+	"carAPI/model"
+	"carAPI/service"
+	"carAPI/validation"
+)
+
+type dealershipHandler struct {
+	svc service.DealershipService
+}
+
+//nolint:revive //dealershipHandler should not be exported
+func NewDealership(s service.DealershipService) dealershipHandler {
+	return dealershipHandler{svc: s}
+}
+
+func (h dealershipHandler) Get(w http.ResponseWriter, r *http.Request) {
+	dealerships, err := h.svc.GetAll()
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, dealerships)
+}
+
+func (h dealershipHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	dealership, err := h.svc.GetByID(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, dealership)
+}
+
+func (h dealershipHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var dealership model.Dealership
+
+	if !readBody(w, r, &dealership) {
+		return
+	}
+
+	err := validation.Dealership(&dealership)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return
+	}
+
+	newDealership, err := h.svc.Create(&dealership)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusCreated, newDealership)
+}
+
+func (h dealershipHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	var dealership model.Dealership
+
+	if !readBody(w, r, &dealership) {
+		return
+	}
+
+	err := validation.Dealership(&dealership)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return
+	}
+
+	dealership.ID = id
+
+	updatedDealership, err := h.svc.Update(&dealership)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, updatedDealership)
+}
+
+// Transfer moves a car to another dealership, the body holds the dealership to move it to
+func (h dealershipHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	var transfer model.Transfer
+
+	if !readBody(w, r, &transfer) {
+		return
+	}
+
+	err := validation.Transfer(&transfer)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return
+	}
+
+	// the car is always moved from its current dealership, and the actor is taken from the API key
+	transfer.From = ""
+	transfer.Actor = middleware.Actor(r.Context())
+
+	newTransfer, err := h.svc.Transfer(id, &transfer)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusCreated, newTransfer)
+}
+
+func (h dealershipHandler) GetTransfers(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	transfers, err := h.svc.GetTransfers(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, transfers)
+}
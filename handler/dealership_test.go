@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestDealershipHandler_Transfer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockDealershipService(mockCtrl)
+
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	m.EXPECT().Transfer(id1(), gomock.Any()).DoAndReturn(func(id string, transfer *model.Transfer) (*model.Transfer, error) {
+		assert.Equal(t, "d2", transfer.To)
+		assert.Empty(t, transfer.From)
+		assert.Regexp(t, "^admin:[0-9a-f]{8}$", transfer.Actor)
+
+		return &model.Transfer{From: "d1", To: "d2", Actor: "admin:1a2b3c4d", At: at}, nil
+	})
+	m.EXPECT().Transfer(id1(), gomock.Any()).
+		Return(nil, customErrors.InvalidFields{{Path: "/to", Code: customErrors.FieldInvalid, Message: "dealership d3 not exists"}})
+
+	tests := []struct {
+		desc       string
+		body       string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			`{"to":"d2","from":"d3","actor":"someone else"}`,
+			http.StatusCreated,
+			[]byte(`{"from":"d1","to":"d2","actor":"admin:1a2b3c4d","at":"2024-05-01T10:00:00Z"}`),
+		},
+		{
+			"Dealership not exists",
+			`{"to":"d3"}`,
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,
+							"instance":"/car/transfer","code":"validation-failed",
+							"errors":[{"path":"/to","code":"invalid-value","message":"dealership d3 not exists"}]}`),
+		},
+		{
+			"Missing dealership",
+			`{}`,
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,
+							"instance":"/car/transfer","code":"validation-failed",
+							"errors":[{"path":"/to","code":"required","message":"to is required"}]}`),
+		},
+	}
+
+	h := NewDealership(m)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/car/transfer", bytes.NewReader([]byte(tc.body)))
+		r = mux.SetURLVars(r, map[string]string{"id": id1()})
+		w := httptest.NewRecorder()
+
+		withKey(h.Transfer, r, "admin-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"carAPI/carcsv"
+	customErrors "carAPI/custom-errors"
+	"carAPI/dto"
+	"carAPI/middleware"
+	"carAPI/model"
+	"carAPI/problem"
+	"carAPI/xlsx"
+)
+
+// exportFormat writes an export in one format
+type exportFormat struct {
+	contentType string
+
+	// start writes the beginning of the export to w, withMinimum tells whether minimum prices are exported
+	// and v is the version of the API whose bodies cars are written as in formats which write JSON
+	start func(w io.Writer, withMinimum bool, v dto.Version) (exporter, error)
+}
+
+// exporter writes the cars of an export one at a time, end writes whatever is buffered and ends the export
+type exporter struct {
+	write func(car *model.Car) error
+	end   func() error
+}
+
+// exportFormats returns the formats cars can be exported in, by the value of the format query param
+func exportFormats() map[string]exportFormat {
+	return map[string]exportFormat{
+		model.ExportFormatCSV:    {"text/csv; charset=utf-8", startCSV},
+		model.ExportFormatNDJSON: {"application/x-ndjson", startNDJSON},
+		model.ExportFormatXLSX:   {xlsx.ContentType, startXLSX},
+	}
+}
+
+// Export downloads the cars of the dealership of the API key which match the filters of GET /car, with their engines.
+// The format query param is one of csv, ndjson and xlsx, csv by default.
+// Cars are written as they are read from the DB, so once the first rows are sent a failure can only end the download early
+func (h handler) Export(w http.ResponseWriter, r *http.Request) {
+	format, ok := readExportFormat(w, r)
+	if !ok {
+		return
+	}
+
+	filter, ok := readCarFilter(w, r)
+	if !ok {
+		return
+	}
+
+	filter.DealershipID = middleware.Dealership(r.Context())
+
+	f := exportFormats()[format]
+	out := &startedWriter{w: w}
+
+	w.Header().Set("Content-Type", f.contentType)
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="cars-%v.%v"`, time.Now().UTC().Format("2006-01-02"), format))
+
+	exp, err := f.start(out, canSeeMinimumPrice(r), middleware.Version(r.Context()))
+	if err == nil {
+		err = h.svc.Each(filter, exp.write)
+	}
+
+	if err == nil {
+		err = exp.end()
+	}
+
+	if err == nil {
+		return
+	}
+
+	if out.started {
+		// the status has been sent with the first rows, the download is cut short
+		log.Println(err)
+		return
+	}
+
+	w.Header().Del("Content-Disposition")
+	handleServerErr(w, r, err, "")
+}
+
+// readExportFormat reads the format query param, false is returned if an error response has been written
+func readExportFormat(w http.ResponseWriter, r *http.Request) (string, bool) {
+	format := r.URL.Query().Get(model.ParamFormat)
+	if format == "" {
+		return model.ExportFormatCSV, true
+	}
+
+	if _, ok := exportFormats()[format]; !ok {
+		allowed := []string{model.ExportFormatCSV, model.ExportFormatNDJSON, model.ExportFormatXLSX}
+
+		p := problem.New(customErrors.CodeInvalidQuery, fmt.Sprintf("%v must be one of %v", model.ParamFormat, strings.Join(allowed, ", ")))
+		p.Param = model.ParamFormat
+		p.Write(w, r)
+
+		return "", false
+	}
+
+	return format, true
+}
+
+func startCSV(w io.Writer, withMinimum bool, _ dto.Version) (exporter, error) {
+	enc, err := carcsv.NewEncoder(w, withMinimum)
+	if err != nil {
+		return exporter{}, err
+	}
+
+	return exporter{write: enc.Encode, end: enc.Flush}, nil
+}
+
+func startNDJSON(w io.Writer, withMinimum bool, v dto.Version) (exporter, error) {
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+
+	write := func(car *model.Car) error {
+		if !withMinimum && car.Price != nil {
+			car.Price.Minimum = 0
+		}
+
+		// Encode ends every car with a newline
+		return enc.Encode(v.Response(car))
+	}
+
+	return exporter{write: write, end: buf.Flush}, nil
+}
+
+func startXLSX(w io.Writer, withMinimum bool, _ dto.Version) (exporter, error) {
+	xw, err := xlsx.NewWriter(w, "Cars")
+	if err != nil {
+		return exporter{}, err
+	}
+
+	header := carcsv.ExportHeader(withMinimum)
+
+	row := make([]interface{}, len(header))
+	for i := range header {
+		row[i] = header[i]
+	}
+
+	err = xw.WriteRow(row)
+	if err != nil {
+		return exporter{}, err
+	}
+
+	write := func(car *model.Car) error {
+		return xw.WriteRow(carcsv.ExportValues(car, withMinimum))
+	}
+
+	return exporter{write: write, end: xw.Close}, nil
+}
+
+// startedWriter tells whether anything has been written to w, writers in front of it buffer the first rows
+// so that an export failing early is still answered with a problem
+type startedWriter struct {
+	w       io.Writer
+	started bool
+}
+
+func (s *startedWriter) Write(p []byte) (int, error) {
+	s.started = true
+	return s.w.Write(p)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestHandler_Export(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	roadster := car1()
+	roadster.DealershipID = "d1"
+	roadster.Price = &model.Price{Currency: "EUR", List: 5000000, Minimum: 4500000}
+
+	each := func(filter model.CarFilter, fn func(car *model.Car) error) error {
+		car := *roadster
+		price := *roadster.Price
+		car.Price = &price
+
+		return fn(&car)
+	}
+
+	m.EXPECT().Each(model.CarFilter{DealershipID: "d1"}, gomock.Any()).DoAndReturn(each).Times(2)
+	m.EXPECT().Each(model.CarFilter{Brand: "Tesla"}, gomock.Any()).DoAndReturn(each)
+	m.EXPECT().Each(model.CarFilter{DealershipID: "d1"}, gomock.Any()).Return(errors.New("server error"))
+
+	filename := `attachment; filename="cars-` + time.Now().UTC().Format("2006-01-02")
+
+	tests := []struct {
+		desc        string
+		query       string
+		key         string
+		statusCode  int
+		contentType string
+		disposition string
+		body        string
+	}{
+		{
+			"CSV by default",
+			"",
+			"north-key",
+			http.StatusOK,
+			"text/csv; charset=utf-8",
+			filename + `.csv"`,
+			"carId,name,yearOfManufacture,brand,fuelType,trimId,vin,status,displacement,noOfCylinders,range,batteryCapacity," +
+				"electricRange,tankCapacity,noOfMotors,power,torque,transmission,gears,drivetrain,dealershipId,currency,listPrice\n" +
+				id1() + ",Roadster,2000,Tesla,Electric,,,,,,500,,,,,,,,,,d1,EUR,5000000\n",
+		},
+		{
+			"NDJSON without minimum price",
+			"?format=ndjson",
+			"north-key",
+			http.StatusOK,
+			"application/x-ndjson",
+			filename + `.ndjson"`,
+			`{"carId":"` + id1() + `","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric",` +
+				`"engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":500},` +
+				`"price":{"currency":"EUR","list":5000000},"dealershipId":"d1"}` + "\n",
+		},
+		{
+			"CSV with minimum price for admins",
+			"?format=csv&brand=Tesla",
+			"admin-key",
+			http.StatusOK,
+			"text/csv; charset=utf-8",
+			filename + `.csv"`,
+			"carId,name,yearOfManufacture,brand,fuelType,trimId,vin,status,displacement,noOfCylinders,range,batteryCapacity," +
+				"electricRange,tankCapacity,noOfMotors,power,torque,transmission,gears,drivetrain,dealershipId,currency,listPrice," +
+				"minimumPrice\n" +
+				id1() + ",Roadster,2000,Tesla,Electric,,,,,,500,,,,,,,,,,d1,EUR,5000000,4500000\n",
+		},
+		{
+			"Server error",
+			"?format=xlsx",
+			"north-key",
+			http.StatusInternalServerError,
+			"application/problem+json",
+			"",
+			`{"type":"/problems/database-error","title":"Database error","status":500,"instance":"/car/export","code":"database-error"}`,
+		},
+		{
+			"Invalid format",
+			"?format=pdf",
+			"north-key",
+			http.StatusBadRequest,
+			"application/problem+json",
+			"",
+			`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,` +
+				`"detail":"format must be one of csv, ndjson, xlsx","instance":"/car/export","code":"invalid-query-param","param":"format"}`,
+		},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/car/export"+tc.query, nil)
+		w := httptest.NewRecorder()
+
+		withKey(h.Export, r, tc.key).ServeHTTP(w, r)
+
+		result := w.Result()
+		body, _ := io.ReadAll(result.Body)
+
+		result.Body.Close()
+
+		assert.Equalf(t, tc.statusCode, result.StatusCode, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.contentType, result.Header.Get("Content-Type"), "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.disposition, result.Header.Get("Content-Disposition"), "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.body, string(body), "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestHandler_ExportXLSX(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	m.EXPECT().Each(model.CarFilter{DealershipID: "d1"}, gomock.Any()).DoAndReturn(
+		func(filter model.CarFilter, fn func(car *model.Car) error) error {
+			return fn(car1())
+		})
+
+	h := New(m, catalog(mockCtrl))
+
+	r := httptest.NewRequest(http.MethodGet, "/car/export?format=xlsx", nil)
+	w := httptest.NewRecorder()
+
+	withKey(h.Export, r, "north-key").ServeHTTP(w, r)
+
+	result := w.Result()
+	body, _ := io.ReadAll(result.Body)
+
+	result.Body.Close()
+
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", result.Header.Get("Content-Type"))
+
+	// an XLSX file is a zip archive
+	assert.Equal(t, "PK", string(body[:2]))
+}
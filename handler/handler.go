@@ -2,34 +2,50 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
+	"reflect"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/google/uuid"
 
 	"github.com/gorilla/mux"
 
 	customErrors "carAPI/custom-errors"
+	"carAPI/middleware"
 	"carAPI/model"
+	"carAPI/problem"
 	"carAPI/service"
+	"carAPI/validation"
+	"carAPI/vin"
 )
 
 type handler struct {
-	svc service.CarService
+	svc     service.CarService
+	catalog validation.Catalog
 }
 
 //nolint:revive //handler should not be exported
-func New(s service.CarService) handler {
-	return handler{svc: s}
+func New(s service.CarService, c validation.Catalog) handler {
+	return handler{svc: s, catalog: c}
 }
 
+// Get lists the cars of the dealership of the API key
 func (h handler) Get(w http.ResponseWriter, r *http.Request) {
+	h.get(w, r, middleware.Dealership(r.Context()))
+}
+
+// GetAcrossDealerships lists the cars of all dealerships, or of the dealership of the dealershipId query param
+func (h handler) GetAcrossDealerships(w http.ResponseWriter, r *http.Request) {
+	h.get(w, r, r.URL.Query().Get(model.ParamDealershipID))
+}
+
+// get lists the cars of the dealership with given ID, the cars of all dealerships if it is empty
+func (h handler) get(w http.ResponseWriter, r *http.Request, dealershipID string) {
 	q := r.URL.Query()
-	brand := q.Get("brand")
 	withEngine := q.Get("withEngine")
 
 	if withEngine == "" {
@@ -39,24 +55,32 @@ func (h handler) Get(w http.ResponseWriter, r *http.Request) {
 	we, err := strconv.ParseBool(withEngine)
 	if err != nil {
 		log.Println(err)
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprint(w, `{"error":{"code":"invalid value of withEngine","message":"withEngine must be true or false"}}`)
+
+		p := problem.New(customErrors.CodeInvalidQuery, "withEngine must be true or false")
+		p.Param = "withEngine"
+		p.Write(w, r)
 
 		return
 	}
 
-	cars, err := h.svc.GetAll(brand, we)
-	if err != nil {
-		handleServerErr(err, "", w)
+	filter, ok := readCarFilter(w, r)
+	if !ok {
 		return
 	}
 
-	resp, err := json.Marshal(cars)
+	filter.DealershipID = dealershipID
+
+	cars, err := h.svc.GetAll(filter, we)
 	if err != nil {
-		handleMarshalErr(err, w)
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	for i := range cars {
+		hideMinimumPrice(r, &cars[i])
 	}
 
-	_, _ = w.Write(resp)
+	writeResponse(w, r, http.StatusOK, cars)
 }
 
 func (h handler) GetByID(w http.ResponseWriter, r *http.Request) {
@@ -66,138 +90,170 @@ func (h handler) GetByID(w http.ResponseWriter, r *http.Request) {
 	// parse ID
 	err := parseID(id)
 	if err != nil {
-		log.Println(err)
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprint(w, `{"error":{"code":"invalid ID"}}`)
-
+		handleIDErr(w, r, err, id)
 		return
 	}
 
 	car, err := h.svc.GetByID(id)
 	if err != nil {
-		handleServerErr(err, id, w)
+		handleServerErr(w, r, err, id)
 		return
 	}
 
-	resp, err := json.Marshal(car)
-	if err != nil {
-		handleMarshalErr(err, w)
+	if !ownsCar(r, car) {
+		handleServerErr(w, r, customErrors.CarNotExists(), id)
 		return
 	}
 
-	_, _ = w.Write(resp)
+	hideMinimumPrice(r, car)
+
+	writeResponse(w, r, http.StatusOK, car)
 }
 
-func (h handler) Create(w http.ResponseWriter, r *http.Request) {
-	body, err := io.ReadAll(r.Body)
+func (h handler) GetByVIN(w http.ResponseWriter, r *http.Request) {
+	// VINs only consist of capital letters, so lookups are case insensitive
+	v := strings.ToUpper(mux.Vars(r)["vin"])
+
+	err := vin.Validate(v)
 	if err != nil {
-		handleParseErr(err, w)
-		return
-	}
+		log.Println(err)
 
-	var car model.Car
+		p := problem.New(customErrors.CodeInvalidID, err.Error())
+		p.ID = v
+		p.Write(w, r)
 
-	err = json.Unmarshal(body, &car)
-	if err != nil {
-		handleParseErr(err, w)
 		return
 	}
 
-	// validate car
-	err = validateCar(&car)
+	car, err := h.svc.GetByVIN(v)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprintf(w, `{"error":{"code":"invalid body","message":"%v"}}`, err)
+		handleServerErr(w, r, err, v)
+		return
+	}
 
+	if !ownsCar(r, car) {
+		handleServerErr(w, r, customErrors.CarNotExists(), v)
 		return
 	}
 
-	// validate params
-	err = validateParams(&car)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprintf(w, `{"error":{"code":"missing param(s)","requiredParams":"%v"}}`, err)
+	hideMinimumPrice(r, car)
 
+	writeResponse(w, r, http.StatusOK, car)
+}
+
+func (h handler) Create(w http.ResponseWriter, r *http.Request) {
+	var car model.Car
+
+	if !readBody(w, r, &car) {
 		return
 	}
 
-	newCar, err := h.svc.Create(&car)
+	// validate car
+	err := validation.Car(&car, h.catalog)
 	if err != nil {
-		handleServerErr(err, "", w)
+		handleServerErr(w, r, err, "")
 		return
 	}
 
-	resp, err := json.Marshal(newCar)
+	// new cars are stocked at the dealership of the API key
+	car.DealershipID = middleware.Dealership(r.Context())
+
+	newCar, err := h.svc.Create(&car)
 	if err != nil {
-		handleMarshalErr(err, w)
+		handleServerErr(w, r, err, "")
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	_, _ = w.Write(resp)
+	hideMinimumPrice(r, newCar)
+
+	writeResponse(w, r, http.StatusCreated, newCar)
 }
 
 func (h handler) Update(w http.ResponseWriter, r *http.Request) {
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		handleParseErr(err, w)
-		return
-	}
-
 	var car model.Car
 
-	err = json.Unmarshal(body, &car)
-	if err != nil {
-		handleParseErr(err, w)
+	if !readBody(w, r, &car) {
 		return
 	}
 
 	// validate car
-	err = validateCar(&car)
+	err := validation.Car(&car, h.catalog)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprintf(w, `{"error":{"code":"invalid body","message":"%v"}}`, err)
-
+		handleServerErr(w, r, err, "")
 		return
 	}
 
-	// validate params
-	err = validateParams(&car)
+	id := mux.Vars(r)["id"]
+
+	// parse ID
+	err = parseID(id)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprintf(w, `{"error":{"code":"missing param(s)","requiredParams":"%v"}}`, err)
+		handleIDErr(w, r, err, id)
+		return
+	}
 
+	car.ID = id
+
+	updatedCar, err := h.svc.Update(&car)
+	if err != nil {
+		handleServerErr(w, r, err, id)
 		return
 	}
 
+	hideMinimumPrice(r, updatedCar)
+
+	writeResponse(w, r, http.StatusOK, updatedCar)
+}
+
+// Patch applies a JSON merge patch (RFC 7396) to an existing car, members which are left out of the body are kept,
+// null members are removed and the engine is merged member by member
+func (h handler) Patch(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
 
 	// parse ID
-	err = parseID(id)
+	err := parseID(id)
 	if err != nil {
-		log.Println(err)
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprint(w, `{"error":{"code":"invalid ID"}}`)
+		handleIDErr(w, r, err, id)
+		return
+	}
 
+	car, err := h.svc.GetByID(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
 		return
 	}
 
+	if !ownsCar(r, car) {
+		handleServerErr(w, r, customErrors.CarNotExists(), id)
+		return
+	}
+
+	engineID := car.Engine.ID
+
+	if !readMergePatch(w, r, car) {
+		return
+	}
+
+	// IDs cannot be patched
 	car.ID = id
+	car.Engine.ID = engineID
 
-	updatedCar, err := h.svc.Update(&car)
+	// validate car
+	err = validation.Car(car, h.catalog)
 	if err != nil {
-		handleServerErr(err, id, w)
+		handleServerErr(w, r, err, id)
 		return
 	}
 
-	resp, err := json.Marshal(updatedCar)
+	updatedCar, err := h.svc.Update(car)
 	if err != nil {
-		handleMarshalErr(err, w)
+		handleServerErr(w, r, err, id)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write(resp)
+	hideMinimumPrice(r, updatedCar)
+
+	writeResponse(w, r, http.StatusOK, updatedCar)
 }
 
 func (h handler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -206,126 +262,275 @@ func (h handler) Delete(w http.ResponseWriter, r *http.Request) {
 	// parse ID
 	err := parseID(id)
 	if err != nil {
-		log.Println(err)
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprint(w, `{"error":{"code":"invalid ID"}}`)
-
+		handleIDErr(w, r, err, id)
 		return
 	}
 
 	err = h.svc.Delete(id)
 	if err != nil {
-		handleServerErr(err, id, w)
+		handleServerErr(w, r, err, id)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func parseID(id string) error {
-	_, err := uuid.Parse(id)
+// Transition changes the status of a car, the body holds the status to change to
+func (h handler) Transition(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	// parse ID
+	err := parseID(id)
 	if err != nil {
-		return err
+		handleIDErr(w, r, err, id)
+		return
 	}
 
-	return nil
-}
+	var transition struct {
+		Status model.Status `json:"status"`
+	}
 
-func handleServerErr(err error, id string, w http.ResponseWriter) {
-	if err == customErrors.CarNotExists() {
-		log.Println(err)
-		w.WriteHeader(http.StatusNotFound)
-		fmt.Fprint(w, `{"error":{"code":"entity not found","id":"`+id+`"}}`)
-	} else {
-		log.Println(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprint(w, `{"error":{"code":"DB error"}}`)
+	if !readBody(w, r, &transition) {
+		return
 	}
-}
 
-func handleMarshalErr(err error, w http.ResponseWriter) {
-	log.Println(err)
-	w.WriteHeader(http.StatusInternalServerError)
-	fmt.Fprint(w, `{"error":{"code":"Marshal error"}}`)
+	err = validation.Status(transition.Status)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return
+	}
+
+	car, err := h.svc.Transition(id, transition.Status)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	hideMinimumPrice(r, car)
+
+	writeResponse(w, r, http.StatusOK, car)
 }
 
-func handleParseErr(err error, w http.ResponseWriter) {
-	log.Println(err)
-	w.WriteHeader(http.StatusBadRequest)
-	fmt.Fprint(w, `{"error":{"code":"invalid body", "message":"cannot parse given body"}}`)
+func (h handler) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	// parse ID
+	err := parseID(id)
+	if err != nil {
+		handleIDErr(w, r, err, id)
+		return
+	}
+
+	history, err := h.svc.GetStatusHistory(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, history)
 }
 
-func validateParams(car *model.Car) error {
-	// validate necessary car parameters are passed
-	missingParams := make([]string, 0)
+// SetPrice changes the price of a car, the body holds the new price and the reason of the change
+func (h handler) SetPrice(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	var change model.PriceChange
 
-	if car.Name == "" {
-		missingParams = append(missingParams, model.ParamName)
+	if !readBody(w, r, &change) {
+		return
 	}
 
-	if car.Brand == "" {
-		missingParams = append(missingParams, model.ParamBrand)
+	err := validation.PriceChange(&change)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return
 	}
 
-	if car.FuelType == "" {
-		missingParams = append(missingParams, model.ParamFuelType)
+	// the actor is taken from the API key, never from the body
+	change.Actor = middleware.Actor(r.Context())
+
+	car, err := h.svc.SetPrice(id, &change)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
 	}
 
-	if car.YearOfManufacture == 0 {
-		missingParams = append(missingParams, model.ParamYearOfManufacture)
+	hideMinimumPrice(r, car)
+
+	writeResponse(w, r, http.StatusOK, car)
+}
+
+func (h handler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
 	}
 
-	// validate engine params
-	missingParams = append(missingParams, validateEngineParams(car)...)
+	history, err := h.svc.GetPriceHistory(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
 
-	if len(missingParams) != 0 {
-		return customErrors.MissingParams{
-			RequiredParams: missingParams,
+	if !canSeeMinimumPrice(r) {
+		for i := range history {
+			history[i].Minimum = 0
 		}
 	}
 
-	return nil
+	writeResponse(w, r, http.StatusOK, history)
 }
 
-func validateEngineParams(car *model.Car) []string {
-	missingParams := make([]string, 0)
-
-	switch car.FuelType {
-	// for electric cars, range must be present
-	case model.ValueElectric:
-		if car.Engine.Range == 0 {
-			missingParams = append(missingParams, model.ParamRange)
+// Owned only passes requests for a car of the dealership of the API key on to next,
+// the cars of other dealerships are reported as not existing
+func (h handler) Owned(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := readID(w, r)
+		if !ok {
+			return
 		}
 
-	// for non-electric cars, displacement and noOfCylinders must be present
-	case model.ValuePetrol, model.ValueDiesel:
-		if car.Engine.Displacement == 0 {
-			missingParams = append(missingParams, model.ParamDisplacement)
+		car, err := h.svc.GetByID(id)
+		if err != nil {
+			handleServerErr(w, r, err, id)
+			return
 		}
 
-		if car.Engine.NoOfCylinders == 0 {
-			missingParams = append(missingParams, model.ParamNoOfCylinders)
+		if !ownsCar(r, car) {
+			handleServerErr(w, r, customErrors.CarNotExists(), id)
+			return
 		}
+
+		next(w, r)
 	}
+}
 
-	return missingParams
+// ownsCar reports whether car is stocked at the dealership of the API key of r
+func ownsCar(r *http.Request, car *model.Car) bool {
+	return car.DealershipID == middleware.Dealership(r.Context())
 }
 
-func validateCar(car *model.Car) error {
-	// validate year of manufacture
-	err := validateYearOfManufacture(car.YearOfManufacture)
-	if err != nil {
-		return err
+// ownsCarID reports whether the car with given ID, which is fetched from cars, is stocked at the dealership
+// of the API key of r. Cars which do not exist are not owned
+func ownsCarID(cars service.CarService, r *http.Request, carID string) (bool, error) {
+	car, err := cars.GetByID(carID)
+	if errors.Is(err, customErrors.ErrNotFound) {
+		return false, nil
 	}
 
-	// validate brand
-	err = validateBrand(car.Brand)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	return ownsCar(r, car), nil
+}
+
+// canSeeMinimumPrice reports whether the API key of r may see the minimum prices of cars
+func canSeeMinimumPrice(r *http.Request) bool {
+	return middleware.Role(r.Context()) == model.RoleAdmin
+}
+
+// hideMinimumPrice removes the minimum price from car unless the API key of r may see it
+func hideMinimumPrice(r *http.Request, car *model.Car) {
+	if car.Price == nil || canSeeMinimumPrice(r) {
+		return
+	}
+
+	// the price is copied, as it may be shared with the car held by the service
+	price := *car.Price
+	price.Minimum = 0
+	car.Price = &price
+}
+
+// readCarFilter reads the car filter from the query params, false is returned if an error response has been written
+func readCarFilter(w http.ResponseWriter, r *http.Request) (model.CarFilter, bool) {
+	q := r.URL.Query()
+
+	filter := model.CarFilter{
+		Brand:        q.Get(model.ParamBrand),
+		Status:       model.Status(q.Get(model.ParamStatus)),
+		Transmission: q.Get(model.ParamTransmission),
+		Drivetrain:   q.Get(model.ParamDrivetrain),
+		Currency:     q.Get(model.ParamCurrency),
+	}
+
+	enums := []struct {
+		param   string
+		value   string
+		allowed []string
+	}{
+		{model.ParamStatus, string(filter.Status), validation.Statuses()},
+		{model.ParamTransmission, filter.Transmission, validation.Transmissions()},
+		{model.ParamDrivetrain, filter.Drivetrain, validation.Drivetrains()},
+		{model.ParamCurrency, filter.Currency, validation.Currencies()},
 	}
 
-	// validate fuel type
-	err = validateFuelType(car.FuelType)
+	for _, e := range enums {
+		if e.value != "" && !contains(e.allowed, e.value) {
+			p := problem.New(customErrors.CodeInvalidQuery, fmt.Sprintf("%v must be one of %v", e.param, strings.Join(e.allowed, ", ")))
+			p.Param = e.param
+			p.Write(w, r)
+
+			return filter, false
+		}
+	}
+
+	bounds := []struct {
+		param string
+		set   func(n int64)
+	}{
+		{model.ParamMinPower, func(n int64) { filter.MinPower = int(n) }},
+		{model.ParamMaxPower, func(n int64) { filter.MaxPower = int(n) }},
+		{model.ParamMinPrice, func(n int64) { filter.MinPrice = n }},
+		{model.ParamMaxPrice, func(n int64) { filter.MaxPrice = n }},
+	}
+
+	for _, b := range bounds {
+		v := q.Get(b.param)
+		if v == "" {
+			continue
+		}
+
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			p := problem.New(customErrors.CodeInvalidQuery, fmt.Sprintf("%v must be a non-negative integer", b.param))
+			p.Param = b.param
+			p.Write(w, r)
+
+			return filter, false
+		}
+
+		b.set(n)
+	}
+
+	// prices are in the minor unit of their currency, they cannot be compared across currencies
+	if (filter.MinPrice != 0 || filter.MaxPrice != 0) && filter.Currency == "" {
+		p := problem.New(customErrors.CodeInvalidQuery,
+			fmt.Sprintf("%v is required with %v and %v", model.ParamCurrency, model.ParamMinPrice, model.ParamMaxPrice))
+		p.Param = model.ParamCurrency
+		p.Write(w, r)
+
+		return filter, false
+	}
+
+	return filter, true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseID(id string) error {
+	_, err := uuid.Parse(id)
 	if err != nil {
 		return err
 	}
@@ -333,26 +538,120 @@ func validateCar(car *model.Car) error {
 	return nil
 }
 
-func validateYearOfManufacture(year int) error {
-	if (year < model.MinYear && year != 0) || year > time.Now().Year() {
-		return customErrors.InvalidYOM()
+func handleServerErr(w http.ResponseWriter, r *http.Request, err error, id string) {
+	log.Println(err)
+
+	var (
+		notExists customErrors.EntityNotExists
+		conflict  customErrors.Conflict
+	)
+
+	// the detail is taken from the classified error, so that the context added while wrapping is only logged
+	switch {
+	case errors.As(err, &notExists):
+		p := problem.New(customErrors.CodeEntityNotFound, notExists.Error())
+		p.ID = id
+		p.Write(w, r)
+	case errors.As(err, &conflict):
+		p := problem.New(customErrors.CodeConflict, conflict.Error())
+		p.ID = id
+		p.Write(w, r)
+	case errors.Is(err, customErrors.ErrNotFound):
+		p := problem.New(customErrors.CodeEntityNotFound, "")
+		p.ID = id
+		p.Write(w, r)
+	case errors.Is(err, customErrors.ErrConflict):
+		p := problem.New(customErrors.CodeConflict, "")
+		p.ID = id
+		p.Write(w, r)
+	case errors.Is(err, customErrors.ErrValidation):
+		handleValidationErr(w, r, err)
+	case errors.Is(err, customErrors.ErrUnavailable):
+		problem.Write(w, r, customErrors.CodeUnavailable, "")
+	default:
+		problem.Write(w, r, customErrors.CodeDatabase, "")
 	}
+}
 
-	return nil
+func handleMarshalErr(w http.ResponseWriter, r *http.Request, err error) {
+	log.Println(err)
+	problem.Write(w, r, customErrors.CodeEncoding, "")
+}
+
+// handleParseErr writes the error of a body which cannot be read. The errors of the JSON decoder name Go types,
+// they are only logged and described in terms of the body
+func handleParseErr(w http.ResponseWriter, r *http.Request, err error) {
+	log.Println(err)
+
+	var (
+		typeErr        *json.UnmarshalTypeError
+		unsupportedErr *json.UnsupportedTypeError
+	)
+
+	switch {
+	case customErrors.IsTooLarge(err):
+		problem.Write(w, r, customErrors.CodeTooLarge, "the body is too large")
+	case errors.As(err, &typeErr):
+		p := problem.New(customErrors.CodeMalformedBody, "the body has members of the wrong type")
+		p.Errors = customErrors.InvalidFields{typeErrField(typeErr)}
+		p.Write(w, r)
+	case errors.As(err, &unsupportedErr):
+		// YAML mappings with keys other than strings have no JSON equivalent
+		problem.Write(w, r, customErrors.CodeMalformedBody, "the keys of mappings must be strings")
+	default:
+		problem.Write(w, r, customErrors.CodeMalformedBody, err.Error())
+	}
 }
 
-func validateBrand(brand string) error {
-	if brand != "" && brand != model.ValueTesla && brand != model.ValueFerrari && brand != model.ValuePorsche && brand != model.ValueBMW {
-		return customErrors.InvalidBrand()
+// typeErrField returns the invalid field of a member whose JSON type does not match its type in the body
+func typeErrField(err *json.UnmarshalTypeError) customErrors.FieldError {
+	name, path := "the body", ""
+	if err.Field != "" {
+		path = "/" + strings.ReplaceAll(err.Field, ".", "/")
+		name = path[strings.LastIndex(path, "/")+1:]
 	}
 
-	return nil
+	return customErrors.FieldError{Path: path, Code: customErrors.FieldInvalid, Message: name + " must be " + jsonType(err.Type)}
 }
 
-func validateFuelType(fuelType string) error {
-	if fuelType != "" && fuelType != model.ValueElectric && fuelType != model.ValueDiesel && fuelType != model.ValuePetrol {
-		return customErrors.InvalidFuelType()
+// jsonType returns the JSON type values of t are decoded from, with its article
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
 
-	return nil
+	switch t.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+
+	return "an object"
+}
+
+func handleIDErr(w http.ResponseWriter, r *http.Request, err error, id string) {
+	log.Println(err)
+
+	p := problem.New(customErrors.CodeInvalidID, "id must be a valid UUID")
+	p.ID = id
+	p.Write(w, r)
+}
+
+func handleValidationErr(w http.ResponseWriter, r *http.Request, err error) {
+	var fields customErrors.InvalidFields
+
+	if !errors.As(err, &fields) {
+		problem.Write(w, r, customErrors.CodeValidation, err.Error())
+		return
+	}
+
+	p := problem.New(customErrors.CodeValidation, "")
+	p.Errors = fields
+	p.Write(w, r)
 }
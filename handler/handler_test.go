@@ -3,11 +3,14 @@ package handler
 import (
 	"bytes"
 	"errors"
+	"fmt"
 
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
@@ -15,6 +18,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	customErrors "carAPI/custom-errors"
+	"carAPI/dto"
+	"carAPI/middleware"
 	"carAPI/mocks"
 	"carAPI/model"
 )
@@ -73,6 +78,21 @@ func car4() *model.Car {
 	}
 }
 
+// catalog returns a catalog of the brands and fuel types used in the tests
+func catalog(ctrl *gomock.Controller) *mocks.MockCatalog {
+	c := mocks.NewMockCatalog(ctrl)
+
+	c.EXPECT().Brands().Return([]string{"Tesla", "Ferrari", "BMW", "Porsche"}, nil).AnyTimes()
+	c.EXPECT().FuelTypes().Return([]string{"Electric", "Petrol", "Diesel"}, nil).AnyTimes()
+	c.EXPECT().EngineRules().Return(map[string]model.EngineRule{
+		"Electric": {Required: []string{"range"}, Forbidden: []string{"displacement", "noOfCylinders", "electricRange", "tankCapacity"}},
+		"Petrol":   {Required: []string{"displacement", "noOfCylinders"}, Forbidden: []string{"batteryCapacity", "electricRange", "noOfMotors"}},
+		"Diesel":   {Required: []string{"displacement", "noOfCylinders"}, Forbidden: []string{"batteryCapacity", "electricRange", "noOfMotors"}},
+	}, nil).AnyTimes()
+
+	return c
+}
+
 func id1() string {
 	return "86a4cc77-4a2b-4215-8a2c-ff3ecca19627"
 }
@@ -85,17 +105,33 @@ func id3() string {
 	return "568492e8-df97-47ff-a0f2-18b638f767a6"
 }
 
+func id4() string {
+	return "0d3c1f5e-2b8a-4f6e-9a3b-7c5d1e2f3a4b"
+}
+
+func id5() string {
+	return "9b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+}
+
 func TestHandler_Get(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
 	m := mocks.NewMockCarService(mockCtrl)
 
-	m.EXPECT().GetAll("Tesla", true).Return([]model.Car{*car1()}, nil)
-	m.EXPECT().GetAll("", false).Return([]model.Car{*car3(), *car4()}, nil)
-	m.EXPECT().GetAll("Tesla", false).Return([]model.Car{*car3()}, nil)
-	m.EXPECT().GetAll("", true).Return([]model.Car{*car1(), *car2()}, nil)
-	m.EXPECT().GetAll("BMW", false).Return(nil, errors.New("server error"))
+	m.EXPECT().GetAll(model.CarFilter{Brand: "Tesla"}, true).Return([]model.Car{*car1()}, nil)
+	m.EXPECT().GetAll(model.CarFilter{}, false).Return([]model.Car{*car3(), *car4()}, nil)
+	m.EXPECT().GetAll(model.CarFilter{Brand: "Tesla"}, false).Return([]model.Car{*car3()}, nil)
+	m.EXPECT().GetAll(model.CarFilter{}, true).Return([]model.Car{*car1(), *car2()}, nil)
+	m.EXPECT().GetAll(model.CarFilter{Brand: "BMW"}, false).Return(nil, errors.New("server error"))
+	m.EXPECT().GetAll(model.CarFilter{Transmission: "DCT", Drivetrain: "AWD", MinPower: 300, MaxPower: 600}, false).
+		Return([]model.Car{*car3()}, nil)
+
+	priced := car3()
+	priced.Price = &model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000}
+
+	m.EXPECT().GetAll(model.CarFilter{Currency: "EUR", MinPrice: 5000000, MaxPrice: 9000000}, false).
+		Return([]model.Car{*priced}, nil)
 
 	tests := []struct {
 		desc       string
@@ -142,18 +178,60 @@ func TestHandler_Get(t *testing.T) {
 			"Server error",
 			"?brand=BMW",
 			http.StatusInternalServerError,
-			[]byte(`{"error":{"code":"DB error"}}`),
+			[]byte(`{"type":"/problems/database-error","title":"Database error","status":500,"instance":"/car","code":"database-error"}`),
 		},
 		{
 			"Invalid value of withEngine",
 			"?withEngine=abc",
 			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid value of withEngine","message":"withEngine must be true or false"}}`),
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,"detail":"withEngine must be true or false","instance":"/car","code":"invalid-query-param","param":"withEngine"}`),
+		},
+		{
+			"Filter by engine specs",
+			"?transmission=DCT&drivetrain=AWD&minPower=300&maxPower=600",
+			http.StatusOK,
+			[]byte(`[{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,
+							"brand":"Tesla","fuelType":"Electric",
+							"engine":{"engineId":"","displacement":0,"noOfCylinders":0,"range":0}}]`),
+		},
+		{
+			"Invalid transmission",
+			"?transmission=Sequential",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,"detail":"transmission must be one of Manual, Automatic, DCT, CVT","instance":"/car","code":"invalid-query-param","param":"transmission"}`),
+		},
+		{
+			"Invalid minPower",
+			"?minPower=-1",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,"detail":"minPower must be a non-negative integer","instance":"/car","code":"invalid-query-param","param":"minPower"}`),
+		},
+		{
+			"Filter by price, the minimum price is hidden",
+			"?currency=EUR&minPrice=5000000&maxPrice=9000000",
+			http.StatusOK,
+			[]byte(`[{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,
+							"brand":"Tesla","fuelType":"Electric",
+							"engine":{"engineId":"","displacement":0,"noOfCylinders":0,"range":0},
+							"price":{"currency":"EUR","list":8999900}}]`),
+		},
+		{
+			"Invalid maxPrice",
+			"?maxPrice=cheap",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,"detail":"maxPrice must be a non-negative integer","instance":"/car","code":"invalid-query-param","param":"maxPrice"}`),
+		},
+		{
+			"Price bound without currency",
+			"?minPrice=5000000",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,
+							"detail":"currency is required with minPrice and maxPrice","instance":"/car","code":"invalid-query-param","param":"currency"}`),
 		},
 	}
 
 	for i, tc := range tests {
-		h := New(m)
+		h := New(m, catalog(mockCtrl))
 		r := httptest.NewRequest(http.MethodGet, "/car"+tc.params, nil)
 		w := httptest.NewRecorder()
 		h.Get(w, r)
@@ -203,24 +281,24 @@ func TestHandler_GetById(t *testing.T) {
 			"Car not exists",
 			id2(),
 			http.StatusNotFound,
-			[]byte(`{"error":{"code":"entity not found", "id":"4924f6ff-5684-4d3c-8ca3-24486a1fc205"}}`),
+			[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Car not exists","instance":"/car","code":"entity-not-found","id":"4924f6ff-5684-4d3c-8ca3-24486a1fc205"}`),
 		},
 
 		{
 			"Server Error",
 			id3(),
 			http.StatusInternalServerError,
-			[]byte(`{"error":{"code":"DB error"}}`),
+			[]byte(`{"type":"/problems/database-error","title":"Database error","status":500,"instance":"/car","code":"database-error"}`),
 		},
 		{
 			"Invalid ID",
 			"1",
 			http.StatusBadRequest,
-			[]byte(`{"error":{"code": "invalid ID"}}`),
+			[]byte(`{"type":"/problems/invalid-id","title":"Invalid ID","status":400,"detail":"id must be a valid UUID","instance":"/car","code":"invalid-id","id":"1"}`),
 		},
 	}
 
-	h := New(m)
+	h := New(m, catalog(mockCtrl))
 
 	for i, tc := range tests {
 		r := httptest.NewRequest(http.MethodGet, "/car", nil)
@@ -251,6 +329,67 @@ func TestHandler_GetById(t *testing.T) {
 	}
 }
 
+func TestHandler_GetByVIN(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	car := car1()
+	car.VIN = "5YJ3E1EA6LF000001"
+
+	m.EXPECT().GetByVIN("5YJ3E1EA6LF000001").Return(car, nil).Times(2)
+	m.EXPECT().GetByVIN("1M8GDM9AXKP042788").Return(nil, customErrors.CarNotExists())
+
+	tests := []struct {
+		desc       string
+		vin        string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			"5YJ3E1EA6LF000001",
+			http.StatusOK,
+			[]byte(`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric",
+							"engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":500},"vin":"5YJ3E1EA6LF000001"}`),
+		},
+		{
+			"Lower case VIN",
+			"5yj3e1ea6lf000001",
+			http.StatusOK,
+			[]byte(`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric",
+							"engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":500},"vin":"5YJ3E1EA6LF000001"}`),
+		},
+		{
+			"Car not exists",
+			"1M8GDM9AXKP042788",
+			http.StatusNotFound,
+			[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Car not exists",
+							"instance":"/car/vin","code":"entity-not-found","id":"1M8GDM9AXKP042788"}`),
+		},
+		{
+			"Wrong check digit",
+			"5YJ3E1EA7LF000001",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-id","title":"Invalid ID","status":400,"detail":"check digit of vin does not match",
+							"instance":"/car/vin","code":"invalid-id","id":"5YJ3E1EA7LF000001"}`),
+		},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/car/vin", nil)
+		r = mux.SetURLVars(r, map[string]string{"vin": tc.vin})
+		w := httptest.NewRecorder()
+
+		h.GetByVIN(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
 func TestHandler_Create(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -281,44 +420,63 @@ func TestHandler_Create(t *testing.T) {
 											"yearOfManufacture":2020,"brand":"Ferrari","fuelType":"Diesel",
 												"engine":{"engineId":"2","displacement":600,"noOfCylinders":4,"range":0}}`)),
 			http.StatusInternalServerError,
-			[]byte(`{"error":{"code":"DB error"}}`),
+			[]byte(`{"type":"/problems/database-error","title":"Database error","status":500,"instance":"/car","code":"database-error"}`),
 		},
 		{
 			"Unmarshal Error",
 			bytes.NewReader([]byte("Invalid")),
 			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid body", "message":"cannot parse given body"}}`),
+			[]byte(`{"type":"/problems/malformed-body","title":"Cannot parse given body","status":400,"detail":"invalid character 'I' looking for beginning of value","instance":"/car","code":"malformed-body"}`),
+		},
+		{
+			"Member of the wrong type",
+			bytes.NewReader([]byte(`{"name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric",
+							"engine":{"range":"400"}}`)),
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/malformed-body","title":"Cannot parse given body","status":400,"detail":"the body has members of the wrong type",
+							"instance":"/car","code":"malformed-body","errors":[{"path":"/engine/range","code":"invalid-value","message":"range must be a number"}]}`),
+		},
+		{
+			"Body too large",
+			bytes.NewReader(bytes.Repeat([]byte(" "), model.MaxBodySize+1)),
+			http.StatusRequestEntityTooLarge,
+			[]byte(`{"type":"/problems/payload-too-large","title":"Request body is too large","status":413,
+							"detail":"the body is too large","instance":"/car","code":"payload-too-large"}`),
 		},
 		{
 			"Validation Error",
 			bytes.NewReader([]byte("{}")),
-			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"missing param(s)","requiredParams":"[name brand fuelType yearOfManufacture]"}}`),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car","code":"validation-failed",
+							"errors":[{"path":"/name","code":"required","message":"name is required"},{"path":"/yearOfManufacture","code":"required","message":"yearOfManufacture is required"},{"path":"/brand","code":"required","message":"brand is required"},{"path":"/fuelType","code":"required","message":"fuelType is required"}]}`),
 		},
 		{
 			"Invalid Year",
 			bytes.NewReader([]byte(`{"name":"Roadster","yearOfManufacture":2100,"brand":"Tesla","fuelType":"Electric",
 							"engine":{"range":400}}`)),
-			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid body", "message":"Invalid Value of yearOfManufacture"}}`),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car","code":"validation-failed",
+							"errors":[{"path":"/yearOfManufacture","code":"out-of-range","message":"yearOfManufacture must be between 1866 and the current year"}]}`),
 		},
 		{
 			"Invalid Brand",
 			bytes.NewReader([]byte(`{"name":"Roadster","yearOfManufacture":2000,"brand":"Pesla","fuelType":"Electric",
 							"engine":{"range":400}}`)),
-			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid body","message":"Invalid Value of brand"}}`),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car","code":"validation-failed",
+							"errors":[{"path":"/brand","code":"invalid-value","message":"Pesla is not a valid brand","allowed":["Tesla","Ferrari","BMW","Porsche"]}]}`),
 		},
 		{
 			"Invalid FuelType",
 			bytes.NewReader([]byte(`{"name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"CNG",
 							"engine":{"range":400}}`)),
-			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid body","message":"Invalid Value of fuelType"}}`),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car","code":"validation-failed",
+							"errors":[{"path":"/fuelType","code":"invalid-value","message":"CNG is not a valid fuelType","allowed":["Electric","Petrol","Diesel"]}]}`),
 		},
 	}
 
-	h := New(m)
+	h := New(m, catalog(mockCtrl))
 
 	for i, tc := range tests {
 		r := httptest.NewRequest(http.MethodPost, "/car", tc.body)
@@ -343,6 +501,85 @@ func TestHandler_Create(t *testing.T) {
 	}
 }
 
+func TestHandler_Negotiation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	m.EXPECT().Create(car1()).Return(car1(), nil).Times(3)
+	m.EXPECT().GetByID(id1()).Return(car1(), nil)
+
+	xmlCar := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<car><carId>86a4cc77-4a2b-4215-8a2c-ff3ecca19627</carId><name>Roadster</name><yearOfManufacture>2000</yearOfManufacture>` +
+		`<brand>Tesla</brand><fuelType>Electric</fuelType>` +
+		`<engine><engineId>1</engineId><displacement>0</displacement><noOfCylinders>0</noOfCylinders><range>500</range></engine></car>`
+	yamlCar := "carId: 86a4cc77-4a2b-4215-8a2c-ff3ecca19627\nname: Roadster\nyearOfManufacture: 2000\nbrand: Tesla\nfuelType: Electric\n" +
+		"engine:\n    engineId: \"1\"\n    displacement: 0\n    noOfCylinders: 0\n    range: 500\n"
+
+	tests := []struct {
+		desc        string
+		contentType string
+		accept      string
+		body        string
+		statusCode  int
+		respType    string
+		resp        string
+	}{
+		{"XML", "application/xml", "application/xml", xmlCar, http.StatusCreated, "application/xml", xmlCar},
+		{"YAML to JSON", "application/x-yaml", "", yamlCar, http.StatusCreated, "application/json",
+			`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla",` +
+				`"fuelType":"Electric","engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":500}}`},
+		{"JSON to YAML", "application/json", "text/html;q=0.9, application/yaml;q=0.8",
+			`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla",` +
+				`"fuelType":"Electric","engine":{"engineId":"1","range":500}}`,
+			http.StatusCreated, "application/yaml", yamlCar},
+		// YAML bodies are decoded as the equivalent JSON, which has no NaN, so they never reach the validation of the car
+		{"YAML with a float which is not finite", "application/yaml", "",
+			"name: Roadster\nyearOfManufacture: 2000\nbrand: Tesla\nfuelType: Electric\nengine:\n    range: 500\n    batteryCapacity: .nan\n",
+			http.StatusBadRequest, "application/problem+json",
+			`{"type":"/problems/malformed-body","title":"Cannot parse given body","status":400,"detail":"json: unsupported value: NaN",` +
+				`"instance":"/car","code":"malformed-body"}`},
+		{"Unsupported Content-Type", "text/plain", "", "Roadster", http.StatusUnsupportedMediaType, "application/problem+json",
+			`{"type":"/problems/unsupported-media-type","title":"Media type is not supported","status":415,` +
+				`"detail":"the body must be one of application/json, application/xml, application/yaml","instance":"/car",` +
+				`"code":"unsupported-media-type"}`},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/car", strings.NewReader(tc.body))
+		r.Header.Set("Content-Type", tc.contentType)
+		r.Header.Set("Accept", tc.accept)
+
+		w := httptest.NewRecorder()
+		h.Create(w, r)
+		result := w.Result()
+
+		body, _ := io.ReadAll(result.Body)
+
+		result.Body.Close()
+
+		assert.Equalf(t, tc.statusCode, result.StatusCode, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.respType, result.Header.Get("Content-Type"), "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.resp, string(body), "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	// safe requests are negotiated once their response is written
+	r := httptest.NewRequest(http.MethodGet, "/car", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": id1()})
+	r.Header.Set("Accept", "text/html")
+
+	w := httptest.NewRecorder()
+	h.GetByID(w, r)
+
+	assertResponse(t, 0, "Not acceptable", w.Result(), http.StatusNotAcceptable,
+		[]byte(`{"type":"/problems/not-acceptable","title":"None of the accepted media types can be produced","status":406,`+
+			`"detail":"the response can be one of application/json, application/xml, application/yaml","instance":"/car",`+
+			`"code":"not-acceptable"}`))
+}
+
 func TestHandler_Update(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -373,52 +610,56 @@ func TestHandler_Update(t *testing.T) {
 			bytes.NewReader([]byte(`{"carId":"4924f6ff-5684-4d3c-8ca3-24486a1fc205","name":"Abc","yearOfManufacture":2020,"brand":"Ferrari",
 											"fuelType":"Diesel","engine":{"engineId":"2","displacement":600,"noOfCylinders":4,"range":0}}`)),
 			http.StatusInternalServerError,
-			[]byte(`{"error":{"code":"DB error"}}`),
+			[]byte(`{"type":"/problems/database-error","title":"Database error","status":500,"instance":"/car","code":"database-error"}`),
 		},
 		{
 			"Unmarshal Error",
 			"1",
 			bytes.NewReader([]byte("Invalid")),
 			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid body", "message":"cannot parse given body"}}`),
+			[]byte(`{"type":"/problems/malformed-body","title":"Cannot parse given body","status":400,"detail":"invalid character 'I' looking for beginning of value","instance":"/car","code":"malformed-body"}`),
 		},
 		{
 			"Validation Error",
 			"1",
 			bytes.NewReader([]byte("{}")),
-			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"missing param(s)", "requiredParams":"[name brand fuelType yearOfManufacture]"}}`),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car","code":"validation-failed",
+							"errors":[{"path":"/name","code":"required","message":"name is required"},{"path":"/yearOfManufacture","code":"required","message":"yearOfManufacture is required"},{"path":"/brand","code":"required","message":"brand is required"},{"path":"/fuelType","code":"required","message":"fuelType is required"}]}`),
 		},
 		{
 			"Invalid Year",
 			"1",
 			bytes.NewReader([]byte(`{"name":"Roadster","yearOfManufacture":2100,"fuelType":"Electric","engine":{"range":400}}`)),
-			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid body", "message":"Invalid Value of yearOfManufacture"}}`),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car","code":"validation-failed",
+							"errors":[{"path":"/yearOfManufacture","code":"out-of-range","message":"yearOfManufacture must be between 1866 and the current year"},{"path":"/brand","code":"required","message":"brand is required"}]}`),
 		},
 		{
 			"Invalid Brand",
 			"1",
 			bytes.NewReader([]byte(`{"name":"Roadster","yearOfManufacture":2000,"brand":"Pesla","fuelType":"Electric","engine":{"range":400}}`)),
-			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid body", "message":"Invalid Value of brand"}}`),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car","code":"validation-failed",
+							"errors":[{"path":"/brand","code":"invalid-value","message":"Pesla is not a valid brand","allowed":["Tesla","Ferrari","BMW","Porsche"]}]}`),
 		},
 		{
 			"Invalid FuelType",
 			"1",
 			bytes.NewReader([]byte(`{"name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"CNG","engine":{"range":400}}`)),
-			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid body", "message":"Invalid Value of fuelType"}}`),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car","code":"validation-failed",
+							"errors":[{"path":"/fuelType","code":"invalid-value","message":"CNG is not a valid fuelType","allowed":["Electric","Petrol","Diesel"]}]}`),
 		},
 		{
 			"Invalid ID",
 			"1",
 			bytes.NewReader([]byte(`{"name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric","engine":{"range":400}}`)),
 			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid ID"}}`),
+			[]byte(`{"type":"/problems/invalid-id","title":"Invalid ID","status":400,"detail":"id must be a valid UUID","instance":"/car","code":"invalid-id","id":"1"}`),
 		},
 	}
-	h := New(m)
+	h := New(m, catalog(mockCtrl))
 
 	for i, tc := range tests {
 		r := httptest.NewRequest(http.MethodPut, "/car", tc.body)
@@ -453,8 +694,10 @@ func TestHandler_Delete(t *testing.T) {
 	m := mocks.NewMockCarService(mockCtrl)
 
 	m.EXPECT().Delete(id1()).Return(nil)
-	m.EXPECT().Delete(id2()).Return(customErrors.CarNotExists())
+	m.EXPECT().Delete(id2()).Return(fmt.Errorf("deleting car: %w", customErrors.CarNotExists()))
 	m.EXPECT().Delete(id3()).Return(errors.New("server error"))
+	m.EXPECT().Delete(id4()).Return(customErrors.Conflict{Entity: "Car", Reason: "referenced by another entity"})
+	m.EXPECT().Delete(id5()).Return(customErrors.Unavailable{Err: errors.New("connection refused")})
 
 	tests := []struct {
 		desc       string
@@ -472,23 +715,38 @@ func TestHandler_Delete(t *testing.T) {
 			"Car not exists",
 			id2(),
 			http.StatusNotFound,
-			[]byte(`{"error":{"code":"entity not found","id":"4924f6ff-5684-4d3c-8ca3-24486a1fc205"}}`),
+			[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Car not exists","instance":"/car","code":"entity-not-found","id":"4924f6ff-5684-4d3c-8ca3-24486a1fc205"}`),
 		},
 		{
 			"Server Error",
 			id3(),
 			http.StatusInternalServerError,
-			[]byte(`{"error":{"code":"DB error"}}`),
+			[]byte(`{"type":"/problems/database-error","title":"Database error","status":500,"instance":"/car","code":"database-error"}`),
+		},
+		{
+			"Car is referenced",
+			id4(),
+			http.StatusConflict,
+			[]byte(`{"type":"/problems/conflict","title":"Entity conflicts with existing data","status":409,` +
+				`"detail":"Car conflicts: referenced by another entity","instance":"/car","code":"conflict",` +
+				`"id":"0d3c1f5e-2b8a-4f6e-9a3b-7c5d1e2f3a4b"}`),
+		},
+		{
+			"DB unavailable",
+			id5(),
+			http.StatusServiceUnavailable,
+			[]byte(`{"type":"/problems/service-unavailable","title":"Service temporarily unavailable","status":503,` +
+				`"instance":"/car","code":"service-unavailable"}`),
 		},
 		{
 			"Invalid ID",
 			"1",
 			http.StatusBadRequest,
-			[]byte(`{"error":{"code":"invalid ID"}}`),
+			[]byte(`{"type":"/problems/invalid-id","title":"Invalid ID","status":400,"detail":"id must be a valid UUID","instance":"/car","code":"invalid-id","id":"1"}`),
 		},
 	}
 
-	h := New(m)
+	h := New(m, catalog(mockCtrl))
 
 	for i, tc := range tests {
 		r := httptest.NewRequest(http.MethodDelete, "/car", nil)
@@ -510,3 +768,451 @@ func TestHandler_Delete(t *testing.T) {
 		assert.Equalf(t, tc.resp, body, "Testcase[%v] (%v)", i, tc.desc)
 	}
 }
+
+func TestHandler_Patch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	patched := car1()
+	patched.Name = "Model S"
+	patched.Engine.Range = 600
+
+	withVIN := car1()
+	withVIN.VIN = "5YJ3E1EA6LF000001"
+	withVIN.Engine.Power = 200
+
+	// null removes the VIN, the engine is merged member by member
+	cleared := car1()
+	cleared.Engine.Power = 300
+	cleared.Engine.Torque = 400
+
+	m.EXPECT().GetByID(id1()).Return(car1(), nil)
+	m.EXPECT().Update(patched).Return(patched, nil)
+	m.EXPECT().GetByID(id1()).Return(car1(), nil)
+	m.EXPECT().GetByID(id1()).Return(withVIN, nil)
+	m.EXPECT().Update(cleared).Return(cleared, nil)
+	m.EXPECT().GetByID(id2()).Return(nil, customErrors.CarNotExists())
+
+	tests := []struct {
+		desc       string
+		id         string
+		body       io.Reader
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			id1(),
+			bytes.NewReader([]byte(`{"carId":"1","name":"Model S","engine":{"engineId":"5","range":600}}`)),
+			http.StatusOK,
+			[]byte(`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Model S","yearOfManufacture":2000,"brand":"Tesla",
+							"fuelType":"Electric","engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":600}}`),
+		},
+		{
+			"Patched car is invalid",
+			id1(),
+			bytes.NewReader([]byte(`{"name":"","engine":{"range":0}}`)),
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,"instance":"/car",
+							"code":"validation-failed","errors":[{"path":"/name","code":"required","message":"name is required"},
+							{"path":"/engine/range","code":"required","message":"range is required"}]}`),
+		},
+		{
+			"Null members are removed",
+			id1(),
+			bytes.NewReader([]byte(`{"vin":null,"engine":{"power":300,"torque":400}}`)),
+			http.StatusOK,
+			[]byte(`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla",
+							"fuelType":"Electric","engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":500,
+							"power":300,"torque":400}}`),
+		},
+		{
+			"Car not exists",
+			id2(),
+			bytes.NewReader([]byte(`{"name":"Model S"}`)),
+			http.StatusNotFound,
+			[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Car not exists",
+							"instance":"/car","code":"entity-not-found","id":"4924f6ff-5684-4d3c-8ca3-24486a1fc205"}`),
+		},
+		{
+			"Invalid ID",
+			"1",
+			bytes.NewReader([]byte(`{"name":"Model S"}`)),
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-id","title":"Invalid ID","status":400,"detail":"id must be a valid UUID","instance":"/car",
+							"code":"invalid-id","id":"1"}`),
+		},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPatch, "/car", tc.body)
+		w := httptest.NewRecorder()
+		m := make(map[string]string)
+
+		m["id"] = tc.id
+		r = mux.SetURLVars(r, m)
+		h.Patch(w, r)
+
+		result := w.Result()
+		body, _ := io.ReadAll(result.Body)
+		result.Body.Close()
+
+		if result.StatusCode != tc.statusCode {
+			t.Errorf("Testcase[%v] failed (%v)\nExpected status %v\tGot %v", i, tc.desc, tc.statusCode, result.StatusCode)
+		}
+
+		options := jsondiff.DefaultConsoleOptions()
+		diff, _ := jsondiff.Compare(tc.resp, body, &options)
+
+		if diff != jsondiff.FullMatch {
+			t.Errorf("Testcase[%v] failed (%v)\nExpected:\n%v\nGot:\n%v", i, tc.desc, string(tc.resp), string(body))
+		}
+	}
+}
+
+func TestHandler_Transition(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	reserved := car1()
+	reserved.Status = model.StatusReserved
+
+	m.EXPECT().Transition(id1(), model.StatusReserved).Return(reserved, nil)
+	m.EXPECT().Transition(id2(), model.StatusReserved).
+		Return(nil, customErrors.Conflict{Entity: "Car", Reason: "status cannot change from sold to reserved"})
+
+	tests := []struct {
+		desc       string
+		id         string
+		body       string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			id1(),
+			`{"status":"reserved"}`,
+			http.StatusOK,
+			[]byte(`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric",
+							"engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":500},"status":"reserved"}`),
+		},
+		{
+			"Transition not allowed",
+			id2(),
+			`{"status":"reserved"}`,
+			http.StatusConflict,
+			[]byte(`{"type":"/problems/conflict","title":"Entity conflicts with existing data","status":409,
+							"detail":"Car conflicts: status cannot change from sold to reserved","instance":"/car/transition",
+							"code":"conflict","id":"4924f6ff-5684-4d3c-8ca3-24486a1fc205"}`),
+		},
+		{
+			"Unknown status",
+			id1(),
+			`{"status":"stolen"}`,
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,
+							"instance":"/car/transition","code":"validation-failed",
+							"errors":[{"path":"/status","code":"invalid-value","message":"stolen is not a valid status",
+							"allowed":["in-transit","on-lot","reserved","sold","returned"]}]}`),
+		},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/car/transition", bytes.NewReader([]byte(tc.body)))
+		r = mux.SetURLVars(r, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		h.Transition(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+// withKey authenticates requests to next with the given key, admin-key has the admin role and viewer-key the viewer role.
+// north-key and south-key have the viewer role at the dealerships d1 and d2.
+func TestHandler_Versions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	stocked := car1()
+	stocked.VIN = "XP7YGCEL0YB000001"
+	stocked.Status = model.StatusOnLot
+	stocked.Price = &model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000}
+
+	m.EXPECT().GetByID(id1()).Return(stocked, nil).Times(2)
+
+	body := &model.Car{Name: "Roadster", YearOfManufacture: 2000, Brand: "Tesla", FuelType: "Electric",
+		Engine: model.Engine{Range: 500}, VIN: "XP7YGCEL0YB000001"}
+	created := car1()
+	created.VIN = "XP7YGCEL0YB000001"
+
+	m.EXPECT().Create(body).Return(created, nil)
+
+	h := New(m, catalog(mockCtrl))
+
+	tests := []struct {
+		desc       string
+		version    dto.Version
+		method     string
+		handler    http.HandlerFunc
+		body       string
+		statusCode int
+		resp       []byte
+	}{
+		{"Version 1", dto.V1, http.MethodGet, h.GetByID, "", http.StatusOK,
+			[]byte(`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla",` +
+				`"fuelType":"Electric","engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":500},` +
+				`"vin":"XP7YGCEL0YB000001","status":"on-lot","price":{"currency":"EUR","list":8999900,"minimum":8500000}}`)},
+		{"Version 2", dto.V2, http.MethodGet, h.GetByID, "", http.StatusOK,
+			[]byte(`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla",` +
+				`"fuelType":"Electric","engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":500},` +
+				`"vin":{"number":"XP7YGCEL0YB000001","wmi":"XP7","manufacturer":"Tesla","modelYears":[2000]},"status":"on-lot",` +
+				`"price":{"list":{"amount":8999900,"currency":"EUR"},"minimum":{"amount":8500000,"currency":"EUR"}}}`)},
+		{"Version 2 body", dto.V2, http.MethodPost, h.Create,
+			`{"name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric","engine":{"range":500},` +
+				`"vin":{"number":"XP7YGCEL0YB000001"}}`,
+			http.StatusCreated,
+			[]byte(`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla",` +
+				`"fuelType":"Electric","engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":500},` +
+				`"vin":{"number":"XP7YGCEL0YB000001","wmi":"XP7","manufacturer":"Tesla","modelYears":[2000]},"status":""}`)},
+	}
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(tc.method, "/car", strings.NewReader(tc.body))
+		r = mux.SetURLVars(r, map[string]string{"id": id1()})
+
+		w := httptest.NewRecorder()
+		withKey(middleware.Versioned(tc.version)(tc.handler).ServeHTTP, r, "admin-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func withKey(next http.HandlerFunc, r *http.Request, key string) http.Handler {
+	r.Header.Set("x-api-key", key)
+
+	return middleware.Auth(map[string]model.APIKey{
+		"admin-key":  {Role: model.RoleAdmin},
+		"viewer-key": {Role: model.RoleViewer},
+		"north-key":  {Role: model.RoleViewer, DealershipID: "d1"},
+		"south-key":  {Role: model.RoleViewer, DealershipID: "d2"},
+	})(next)
+}
+
+func TestHandler_SetPrice(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	priced := car1()
+	priced.Price = &model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000}
+
+	m.EXPECT().SetPrice(id1(), gomock.Any()).DoAndReturn(func(id string, change *model.PriceChange) (*model.Car, error) {
+		assert.Equal(t, model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000}, change.Price)
+		assert.Equal(t, "spring sale", change.Reason)
+		assert.Regexp(t, "^admin:[0-9a-f]{8}$", change.Actor)
+
+		return priced, nil
+	})
+	m.EXPECT().SetPrice(id2(), gomock.Any()).Return(nil, customErrors.CarNotExists())
+
+	tests := []struct {
+		desc       string
+		id         string
+		body       string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			id1(),
+			`{"currency":"EUR","list":8999900,"minimum":8500000,"reason":"spring sale","actor":"someone else"}`,
+			http.StatusOK,
+			[]byte(`{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla","fuelType":"Electric",
+							"engine":{"engineId":"1","displacement":0,"noOfCylinders":0,"range":500},
+							"price":{"currency":"EUR","list":8999900,"minimum":8500000}}`),
+		},
+		{
+			"Car not exists",
+			id2(),
+			`{"currency":"EUR","list":8999900,"reason":"spring sale"}`,
+			http.StatusNotFound,
+			[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Car not exists",
+							"instance":"/car/price","code":"entity-not-found","id":"4924f6ff-5684-4d3c-8ca3-24486a1fc205"}`),
+		},
+		{
+			"Minimum above list price",
+			id1(),
+			`{"currency":"EUR","list":100,"minimum":200,"reason":"typo"}`,
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,
+							"instance":"/car/price","code":"validation-failed",
+							"errors":[{"path":"/minimum","code":"out-of-range","message":"minimum must be between 0 and the list price"}]}`),
+		},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPut, "/car/price", bytes.NewReader([]byte(tc.body)))
+		r = mux.SetURLVars(r, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		withKey(h.SetPrice, r, "admin-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestHandler_GetPriceHistory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	history := func() []model.PriceChange {
+		return []model.PriceChange{{
+			Price:  model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000},
+			Actor:  "admin:1a2b3c4d",
+			Reason: "spring sale",
+			At:     at,
+		}}
+	}
+
+	m.EXPECT().GetPriceHistory(id1()).Return(history(), nil)
+	m.EXPECT().GetPriceHistory(id1()).Return(history(), nil)
+
+	tests := []struct {
+		desc       string
+		key        string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Admin key sees the minimum price",
+			"admin-key",
+			http.StatusOK,
+			[]byte(`[{"currency":"EUR","list":8999900,"minimum":8500000,"actor":"admin:1a2b3c4d","reason":"spring sale",
+							"at":"2024-05-01T10:00:00Z"}]`),
+		},
+		{
+			"Viewer key does not see the minimum price",
+			"viewer-key",
+			http.StatusOK,
+			[]byte(`[{"currency":"EUR","list":8999900,"actor":"admin:1a2b3c4d","reason":"spring sale","at":"2024-05-01T10:00:00Z"}]`),
+		},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/car/prices", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": id1()})
+		w := httptest.NewRecorder()
+
+		withKey(h.GetPriceHistory, r, tc.key).ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestHandler_GetByDealership(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	north := car3()
+	north.DealershipID = "d1"
+	south := car4()
+	south.DealershipID = "d2"
+
+	m.EXPECT().GetAll(model.CarFilter{DealershipID: "d1"}, false).Return([]model.Car{*north}, nil)
+	m.EXPECT().GetAll(model.CarFilter{DealershipID: "d2"}, false).Return([]model.Car{*south}, nil)
+	m.EXPECT().GetAll(model.CarFilter{}, false).Return([]model.Car{*north, *south}, nil)
+	m.EXPECT().GetAll(model.CarFilter{DealershipID: "d2"}, false).Return([]model.Car{*south}, nil)
+
+	northResp := `{"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","name":"Roadster","yearOfManufacture":2000,"brand":"Tesla",
+					"fuelType":"Electric","engine":{"engineId":"","displacement":0,"noOfCylinders":0,"range":0},"dealershipId":"d1"}`
+	southResp := `{"carId":"4924f6ff-5684-4d3c-8ca3-24486a1fc205","name":"Abc","yearOfManufacture":2020,"brand":"Ferrari",
+					"fuelType":"Diesel","engine":{"engineId":"","displacement":0,"noOfCylinders":0,"range":0},"dealershipId":"d2"}`
+
+	h := New(m, catalog(mockCtrl))
+
+	tests := []struct {
+		desc    string
+		target  string
+		handler http.HandlerFunc
+		key     string
+		resp    []byte
+	}{
+		{"Stock of the dealership of the key", "/car", h.Get, "north-key", []byte("[" + northResp + "]")},
+		{"Stock of another dealership", "/car?dealershipId=d1", h.Get, "south-key", []byte("[" + southResp + "]")},
+		{"Stock of all dealerships", "/dealerships/cars", h.GetAcrossDealerships, "admin-key", []byte("[" + northResp + "," + southResp + "]")},
+		{"Stock of one dealership", "/dealerships/cars?dealershipId=d2", h.GetAcrossDealerships, "admin-key", []byte("[" + southResp + "]")},
+	}
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		w := httptest.NewRecorder()
+
+		withKey(tc.handler, r, tc.key).ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), http.StatusOK, tc.resp)
+	}
+}
+
+func TestHandler_Owned(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	north := car1()
+	north.DealershipID = "d1"
+
+	m.EXPECT().GetByID(id1()).Return(north, nil).Times(2)
+	m.EXPECT().GetByID(id2()).Return(nil, customErrors.CarNotExists())
+	m.EXPECT().Delete(id1()).Return(nil)
+
+	notExists := func(id string) []byte {
+		return []byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Car not exists",
+							"instance":"/car","code":"entity-not-found","id":"` + id + `"}`)
+	}
+
+	tests := []struct {
+		desc       string
+		id         string
+		key        string
+		statusCode int
+		resp       []byte
+	}{
+		{"Car of the dealership of the key", id1(), "north-key", http.StatusNoContent, nil},
+		{"Car of another dealership", id1(), "south-key", http.StatusNotFound, notExists(id1())},
+		{"Car not exists", id2(), "north-key", http.StatusNotFound, notExists(id2())},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodDelete, "/car", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		withKey(h.Owned(h.Delete), r, tc.key).ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
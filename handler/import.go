@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"carAPI/carcsv"
+	customErrors "carAPI/custom-errors"
+	"carAPI/middleware"
+	"carAPI/model"
+	"carAPI/problem"
+	"carAPI/validation"
+)
+
+// csvContentType is the media type of CSV bodies
+const csvContentType = "text/csv"
+
+// importReport is the outcome of a CSV import, the rows which are not imported are listed by their line
+type importReport struct {
+	DryRun  bool          `json:"dryRun"`
+	Rows    int           `json:"rows"`
+	Valid   int           `json:"valid"`
+	Created int           `json:"created"`
+	Errors  []importError `json:"errors"`
+}
+
+// importError lists the invalid fields of the row on Line
+type importError struct {
+	Line   int                        `json:"line"`
+	Errors customErrors.InvalidFields `json:"errors"`
+}
+
+// importBatch collects the valid rows of an import, so that they are created through the bulk create
+// of the service without holding the whole file in memory
+type importBatch struct {
+	cars  []model.Car
+	lines []int
+}
+
+// Import creates the cars of a CSV body at the dealership of the API key, every row is a car and the header
+// names the param of every column. With dryRun=true the rows are only validated.
+// Rows are imported best-effort, the response reports every row which is not imported by its line
+func (h handler) Import(w http.ResponseWriter, r *http.Request) {
+	dec, dryRun, ok := readCSV(w, r)
+	if !ok {
+		return
+	}
+
+	report := importReport{DryRun: dryRun, Errors: []importError{}}
+	batch := importBatch{}
+
+	for {
+		car, fields, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			// the rows before the malformed line may have been created already, so the report is still written
+			log.Println(err)
+
+			report.Errors = append(report.Errors, importError{Line: dec.Line(), Errors: customErrors.InvalidFields{{
+				Code:    customErrors.FieldInvalid,
+				Message: "the import stopped at a malformed line: " + err.Error(),
+			}}})
+
+			break
+		}
+
+		report.Rows++
+
+		if !h.importRow(w, r, car, fields, dec.Line(), &report, &batch) {
+			return
+		}
+
+		if len(batch.cars) == model.MaxBulkItems && !h.flushImport(w, r, &report, &batch) {
+			return
+		}
+	}
+
+	if !h.flushImport(w, r, &report, &batch) {
+		return
+	}
+
+	// rows rejected by the service are reported after the rows of their batch which failed validation
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Line < report.Errors[j].Line
+	})
+
+	writeResponse(w, r, http.StatusOK, report)
+}
+
+// importRow validates the row on line and adds it to batch, false is returned if an error response has been written
+func (h handler) importRow(w http.ResponseWriter, r *http.Request, car *model.Car, fields customErrors.InvalidFields, line int,
+	report *importReport, batch *importBatch) bool {
+	// cells which cannot be parsed leave their field empty, so they are only reported once
+	if fields == nil {
+		var invalid customErrors.InvalidFields
+
+		err := validation.Car(car, h.catalog)
+		if err != nil && !errors.As(err, &invalid) {
+			handleServerErr(w, r, err, "")
+			return false
+		}
+
+		fields = invalid
+	}
+
+	if fields != nil {
+		report.Errors = append(report.Errors, importError{Line: line, Errors: fields})
+		return true
+	}
+
+	report.Valid++
+
+	// new cars are stocked at the dealership of the API key
+	car.DealershipID = middleware.Dealership(r.Context())
+
+	batch.cars = append(batch.cars, *car)
+	batch.lines = append(batch.lines, line)
+
+	return true
+}
+
+// flushImport creates the cars of batch and empties it, false is returned if an error response has been written.
+// A dry run checks the cars like their creation does, so that it reports the same rows as the import
+func (h handler) flushImport(w http.ResponseWriter, r *http.Request, report *importReport, batch *importBatch) bool {
+	if len(batch.cars) == 0 {
+		return true
+	}
+
+	indexes := make([]int, len(batch.cars))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	create := func(cars []*model.Car) (map[int]customErrors.InvalidFields, error) {
+		return h.svc.CreateMany(cars, false)
+	}
+
+	if report.DryRun {
+		create = h.svc.CheckMany
+	}
+
+	errs, err := create(carsAt(batch.cars, indexes))
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return false
+	}
+
+	for i, line := range batch.lines {
+		fields, ok := errs[i]
+		if !ok {
+			if !report.DryRun {
+				report.Created++
+			}
+
+			continue
+		}
+
+		report.Valid--
+		report.Errors = append(report.Errors, importError{Line: line, Errors: fields})
+	}
+
+	batch.cars = batch.cars[:0]
+	batch.lines = batch.lines[:0]
+
+	return true
+}
+
+// readCSV checks the media type of the body, reads the dryRun query param and the header of the CSV body,
+// false is returned if an error response has been written
+func readCSV(w http.ResponseWriter, r *http.Request) (dec *carcsv.Decoder, dryRun, ok bool) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != csvContentType {
+		problem.Write(w, r, customErrors.CodeUnsupported, "the body must be "+csvContentType)
+		return nil, false, false
+	}
+
+	dryRun, ok = readDryRun(w, r)
+	if !ok {
+		return nil, false, false
+	}
+
+	// the body is parsed as it is read, rows are decoded one at a time
+	dec, err = carcsv.NewDecoder(r.Body)
+	if err != nil {
+		handleParseErr(w, r, err)
+		return nil, false, false
+	}
+
+	return dec, dryRun, true
+}
+
+// readDryRun reads the dryRun query param, false is returned if an error response has been written
+func readDryRun(w http.ResponseWriter, r *http.Request) (dryRun, ok bool) {
+	v := r.URL.Query().Get(model.ParamDryRun)
+	if v == "" {
+		return false, true
+	}
+
+	dryRun, err := strconv.ParseBool(v)
+	if err != nil {
+		p := problem.New(customErrors.CodeInvalidQuery, fmt.Sprintf("%v must be true or false", model.ParamDryRun))
+		p.Param = model.ParamDryRun
+		p.Write(w, r)
+
+		return false, false
+	}
+
+	return dryRun, true
+}
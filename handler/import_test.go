@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestHandler_Import(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+
+	roadster := model.Car{Name: "Roadster", YearOfManufacture: 2008, Brand: "Tesla", FuelType: "Electric",
+		Engine: model.Engine{Range: 393}, DealershipID: "d1"}
+	abc := model.Car{Name: "Abc", YearOfManufacture: 2020, Brand: "Ferrari", FuelType: "Diesel",
+		Engine: model.Engine{Displacement: 600, NoOfCylinders: 4}, DealershipID: "d1"}
+
+	// a dry run reports the rows rejected by the service too
+	m.EXPECT().CheckMany([]*model.Car{&roadster, &abc}).Return(map[int]customErrors.InvalidFields{
+		1: {{Path: "/trimId", Code: customErrors.FieldInvalid, Message: "trim t1 not exists"}},
+	}, nil)
+	m.EXPECT().CreateMany([]*model.Car{&roadster, &abc}, false).Return(map[int]customErrors.InvalidFields{
+		1: {{Path: "/trimId", Code: customErrors.FieldInvalid, Message: "trim t1 not exists"}},
+	}, nil)
+	m.EXPECT().CreateMany([]*model.Car{&roadster}, false).Return(nil, errors.New("server error"))
+
+	csv := "name,yearOfManufacture,brand,fuelType,range,displacement,noOfCylinders\n" +
+		"Roadster,2008,Tesla,Electric,393,,\n" +
+		"Model X,2015,Pesla,Electric,500,,\n" +
+		"Abc,2020,Ferrari,Diesel,,600,4\n"
+
+	tests := []struct {
+		desc        string
+		query       string
+		contentType string
+		body        string
+		statusCode  int
+		resp        []byte
+	}{
+		{
+			"Dry run",
+			"?dryRun=true",
+			"text/csv",
+			csv,
+			http.StatusOK,
+			[]byte(`{"dryRun":true,"rows":3,"valid":1,"created":0,"errors":[{"line":3,"errors":[{"path":"/brand","code":"invalid-value",
+						"message":"Pesla is not a valid brand","allowed":["Tesla","Ferrari","BMW","Porsche"]}]},
+					{"line":4,"errors":[{"path":"/trimId","code":"invalid-value","message":"trim t1 not exists"}]}]}`),
+		},
+		{
+			"Import",
+			"",
+			"text/csv; charset=utf-8",
+			csv,
+			http.StatusOK,
+			[]byte(`{"dryRun":false,"rows":3,"valid":1,"created":1,"errors":[{"line":3,"errors":[{"path":"/brand","code":"invalid-value",
+						"message":"Pesla is not a valid brand","allowed":["Tesla","Ferrari","BMW","Porsche"]}]},
+					{"line":4,"errors":[{"path":"/trimId","code":"invalid-value","message":"trim t1 not exists"}]}]}`),
+		},
+		{
+			"Server error",
+			"",
+			"text/csv",
+			"name,yearOfManufacture,brand,fuelType,range\nRoadster,2008,Tesla,Electric,393\n",
+			http.StatusInternalServerError,
+			[]byte(`{"type":"/problems/database-error","title":"Database error","status":500,"instance":"/car/import","code":"database-error"}`),
+		},
+		{
+			"Numbers which are not finite",
+			"",
+			"text/csv",
+			"name,yearOfManufacture,brand,fuelType,range,batteryCapacity\nModel S,2012,Tesla,Electric,500,NaN\n",
+			http.StatusOK,
+			[]byte(`{"dryRun":false,"rows":1,"valid":0,"created":0,"errors":[{"line":2,"errors":[{"path":"/engine/batteryCapacity",
+						"code":"invalid-value","message":"batteryCapacity must be a finite number"}]}]}`),
+		},
+		{
+			"Not a CSV",
+			"",
+			"application/json",
+			"[]",
+			http.StatusUnsupportedMediaType,
+			[]byte(`{"type":"/problems/unsupported-media-type","title":"Media type is not supported","status":415,
+						"detail":"the body must be text/csv","instance":"/car/import","code":"unsupported-media-type"}`),
+		},
+		{
+			"Invalid dryRun",
+			"?dryRun=maybe",
+			"text/csv",
+			csv,
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,
+						"detail":"dryRun must be true or false","instance":"/car/import","code":"invalid-query-param","param":"dryRun"}`),
+		},
+		{
+			"Unknown column",
+			"",
+			"text/csv",
+			"name,colour\nRoadster,red\n",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/malformed-body","title":"Cannot parse given body","status":400,
+						"detail":"unknown column \"colour\", columns must be one of name, yearOfManufacture, brand, fuelType, trimId, vin, status, displacement, noOfCylinders, range, batteryCapacity, electricRange, tankCapacity, noOfMotors, power, torque, transmission, gears, drivetrain",
+						"instance":"/car/import","code":"malformed-body"}`),
+		},
+	}
+
+	h := New(m, catalog(mockCtrl))
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/car/import"+tc.query, strings.NewReader(tc.body))
+		r.Header.Set("Content-Type", tc.contentType)
+
+		w := httptest.NewRecorder()
+
+		withKey(h.Import, r, "north-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"carAPI/codec"
+	customErrors "carAPI/custom-errors"
+	"carAPI/middleware"
+	"carAPI/model"
+	"carAPI/problem"
+)
+
+// readID parses the id path variable, false is returned if an error response has been written
+func readID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := mux.Vars(r)["id"]
+
+	err := parseID(id)
+	if err != nil {
+		handleIDErr(w, r, err, id)
+		return "", false
+	}
+
+	return id, true
+}
+
+// readBody decodes the body into v in the format of its Content-Type, JSON if it has none.
+// False is returned if an error response has been written
+func readBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	return readLimitedBody(w, r, v, model.MaxBodySize)
+}
+
+// readLimitedBody is readBody for bodies of at most maxBody bytes, larger bodies are refused with 413
+func readLimitedBody(w http.ResponseWriter, r *http.Request, v interface{}, maxBody int64) bool {
+	c, ok := codec.ForContentType(r.Header.Get("Content-Type"))
+	if !ok {
+		problem.Write(w, r, customErrors.CodeUnsupported, "the body must be one of "+strings.Join(codec.MediaTypes(), ", "))
+		return false
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		handleParseErr(w, r, err)
+		return false
+	}
+
+	// cars are read from their body in the version of the API of r
+	dst, done := middleware.Version(r.Context()).Request(v)
+
+	err = c.Unmarshal(body, dst)
+	if err != nil {
+		handleParseErr(w, r, err)
+		return false
+	}
+
+	done()
+
+	return true
+}
+
+// writeResponse writes v with status in the format of the Accept header of r, JSON if it has none.
+// Cars are written as their body in the version of the API of r
+func writeResponse(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	c, ok := codec.ForAccept(r.Header.Get("Accept"))
+	if !ok {
+		problem.Write(w, r, customErrors.CodeNotAcceptable, "the response can be one of "+strings.Join(codec.MediaTypes(), ", "))
+		return
+	}
+
+	resp, err := c.Marshal(middleware.Version(r.Context()).Response(v))
+	if err != nil {
+		handleMarshalErr(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", c.MediaType)
+	w.WriteHeader(status)
+	_, _ = w.Write(resp)
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/middleware"
+	"carAPI/model"
+	"carAPI/problem"
+	"carAPI/service"
+	"carAPI/validation"
+)
+
+type orderHandler struct {
+	svc  service.OrderService
+	cars service.CarService
+}
+
+// NewOrder returns the handler of orders, orders are scoped to the dealership of the API key through their cars,
+// which are fetched from c
+//
+//nolint:revive //orderHandler should not be exported
+func NewOrder(s service.OrderService, c service.CarService) orderHandler {
+	return orderHandler{svc: s, cars: c}
+}
+
+func (h orderHandler) Get(w http.ResponseWriter, r *http.Request) {
+	filter, ok := readOrderFilter(w, r)
+	if !ok {
+		return
+	}
+
+	filter.DealershipID = middleware.Dealership(r.Context())
+
+	orders, err := h.svc.GetAll(filter)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, orders)
+}
+
+func (h orderHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	order, ok := h.owned(w, r, id)
+	if !ok {
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, order)
+}
+
+// owned fetches the order with given ID, orders of cars of other dealerships than the one of the API key of r
+// are not found. false is returned if an error response has been written
+func (h orderHandler) owned(w http.ResponseWriter, r *http.Request, id string) (*model.Order, bool) {
+	order, err := h.svc.GetByID(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return nil, false
+	}
+
+	owns, err := ownsCarID(h.cars, r, order.CarID)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return nil, false
+	}
+
+	if !owns {
+		handleServerErr(w, r, customErrors.OrderNotExists(), id)
+		return nil, false
+	}
+
+	return order, true
+}
+
+func (h orderHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var order model.Order
+
+	if !readBody(w, r, &order) {
+		return
+	}
+
+	err := validation.Order(&order)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return
+	}
+
+	// cars of other dealerships cannot be ordered, completing the order would sell them
+	owns, err := ownsCarID(h.cars, r, order.CarID)
+	if err != nil {
+		handleServerErr(w, r, err, order.CarID)
+		return
+	}
+
+	if !owns {
+		handleServerErr(w, r, customErrors.CarNotExists(), order.CarID)
+		return
+	}
+
+	newOrder, err := h.svc.Create(&order)
+	if err != nil {
+		handleServerErr(w, r, err, "")
+		return
+	}
+
+	writeResponse(w, r, http.StatusCreated, newOrder)
+}
+
+func (h orderHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	var order model.Order
+
+	if !readBody(w, r, &order) {
+		return
+	}
+
+	err := validation.Order(&order)
+	if err != nil {
+		handleValidationErr(w, r, err)
+		return
+	}
+
+	_, ok = h.owned(w, r, id)
+	if !ok {
+		return
+	}
+
+	order.ID = id
+
+	updatedOrder, err := h.svc.Update(&order)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, updatedOrder)
+}
+
+// Complete completes a paid order, its car is sold
+func (h orderHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	h.finish(w, r, h.svc.Complete)
+}
+
+// Cancel cancels an open order
+func (h orderHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.finish(w, r, h.svc.Cancel)
+}
+
+// finish completes or cancels the order with the id of the path
+func (h orderHandler) finish(w http.ResponseWriter, r *http.Request, action func(id string) (*model.Order, error)) {
+	id, ok := readID(w, r)
+	if !ok {
+		return
+	}
+
+	_, ok = h.owned(w, r, id)
+	if !ok {
+		return
+	}
+
+	order, err := action(id)
+	if err != nil {
+		handleServerErr(w, r, err, id)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, order)
+}
+
+// readOrderFilter reads the order filter from the query params, false is returned if an error response has been written
+func readOrderFilter(w http.ResponseWriter, r *http.Request) (model.OrderFilter, bool) {
+	q := r.URL.Query()
+
+	filter := model.OrderFilter{
+		CustomerID: q.Get(model.ParamCustomerID),
+		CarID:      q.Get(model.ParamCarID),
+		Status:     model.OrderStatus(q.Get(model.ParamStatus)),
+	}
+
+	for _, param := range []string{model.ParamCustomerID, model.ParamCarID} {
+		if v := q.Get(param); v != "" && parseID(v) != nil {
+			p := problem.New(customErrors.CodeInvalidQuery, fmt.Sprintf("%v must be a valid UUID", param))
+			p.Param = param
+			p.Write(w, r)
+
+			return filter, false
+		}
+	}
+
+	if filter.Status != "" && !contains(validation.OrderStatuses(), string(filter.Status)) {
+		p := problem.New(customErrors.CodeInvalidQuery,
+			fmt.Sprintf("%v must be one of %v", model.ParamStatus, strings.Join(validation.OrderStatuses(), ", ")))
+		p.Param = model.ParamStatus
+		p.Write(w, r)
+
+		return filter, false
+	}
+
+	return filter, true
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func order1() *model.Order {
+	return &model.Order{
+		ID:            id3(),
+		CustomerID:    id2(),
+		CarID:         id1(),
+		Currency:      "EUR",
+		AgreedPrice:   8999900,
+		Discount:      99900,
+		Tax:           1691000,
+		Total:         10591000,
+		PaymentStatus: model.PaymentPending,
+		Status:        model.OrderOpen,
+		CreatedAt:     time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestOrderHandler_Get(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockOrderService(mockCtrl)
+
+	m.EXPECT().GetAll(model.OrderFilter{CustomerID: id2(), Status: model.OrderOpen, DealershipID: "d1"}).Return([]model.Order{*order1()}, nil)
+
+	tests := []struct {
+		desc       string
+		params     string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Open orders of a customer",
+			"?customerId=4924f6ff-5684-4d3c-8ca3-24486a1fc205&status=open",
+			http.StatusOK,
+			[]byte(`[{"orderId":"` + id3() + `","customerId":"4924f6ff-5684-4d3c-8ca3-24486a1fc205",
+							"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","currency":"EUR","agreedPrice":8999900,"discount":99900,
+							"tax":1691000,"total":10591000,"paymentStatus":"pending","status":"open","createdAt":"2024-05-01T10:00:00Z"}]`),
+		},
+		{
+			"Invalid customerId",
+			"?customerId=c1",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,
+							"detail":"customerId must be a valid UUID","instance":"/orders","code":"invalid-query-param","param":"customerId"}`),
+		},
+		{
+			"Invalid status",
+			"?status=pending",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-query-param","title":"Invalid query parameter","status":400,
+							"detail":"status must be one of open, completed, cancelled","instance":"/orders","code":"invalid-query-param",
+							"param":"status"}`),
+		},
+	}
+
+	h := NewOrder(m, nil)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/orders"+tc.params, nil)
+		w := httptest.NewRecorder()
+
+		withKey(h.Get, r, "north-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestOrderHandler_Create(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockOrderService(mockCtrl)
+	cars := mocks.NewMockCarService(mockCtrl)
+
+	cars.EXPECT().GetByID(id1()).Return(&model.Car{ID: id1(), DealershipID: "d1"}, nil)
+	cars.EXPECT().GetByID(id4()).Return(&model.Car{ID: id4(), DealershipID: "d1"}, nil)
+
+	m.EXPECT().Create(&model.Order{
+		CustomerID: id2(), CarID: id1(), Currency: "EUR", AgreedPrice: 8999900, Discount: 99900, Tax: 1691000,
+	}).Return(order1(), nil)
+	m.EXPECT().Create(&model.Order{CustomerID: id2(), CarID: id4(), Currency: "EUR", AgreedPrice: 100}).
+		Return(nil, customErrors.Conflict{Entity: "Order", Reason: "car is sold"})
+
+	tests := []struct {
+		desc       string
+		body       string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			`{"customerId":"` + id2() + `","carId":"` + id1() + `","currency":"EUR","agreedPrice":8999900,"discount":99900,"tax":1691000}`,
+			http.StatusCreated,
+			[]byte(`{"orderId":"` + id3() + `","customerId":"4924f6ff-5684-4d3c-8ca3-24486a1fc205",
+							"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","currency":"EUR","agreedPrice":8999900,"discount":99900,
+							"tax":1691000,"total":10591000,"paymentStatus":"pending","status":"open","createdAt":"2024-05-01T10:00:00Z"}`),
+		},
+		{
+			"Car sold",
+			`{"customerId":"` + id2() + `","carId":"` + id4() + `","currency":"EUR","agreedPrice":100}`,
+			http.StatusConflict,
+			[]byte(`{"type":"/problems/conflict","title":"Entity conflicts with existing data","status":409,
+							"detail":"Order conflicts: car is sold","instance":"/orders","code":"conflict"}`),
+		},
+		{
+			"Discount above agreed price",
+			`{"customerId":"` + id2() + `","carId":"` + id1() + `","currency":"EUR","agreedPrice":100,"discount":200}`,
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,
+							"instance":"/orders","code":"validation-failed",
+							"errors":[{"path":"/discount","code":"out-of-range","message":"discount must be between 0 and the agreedPrice"}]}`),
+		},
+	}
+
+	h := NewOrder(m, cars)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(tc.body)))
+		w := httptest.NewRecorder()
+
+		withKey(h.Create, r, "north-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestOrderHandler_Complete(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockOrderService(mockCtrl)
+
+	completed := order1()
+	completedAt := completed.CreatedAt.Add(time.Hour)
+	completed.PaymentStatus = model.PaymentPaid
+	completed.Status = model.OrderCompleted
+	completed.CompletedAt = &completedAt
+
+	cars := mocks.NewMockCarService(mockCtrl)
+
+	cars.EXPECT().GetByID(id1()).Return(&model.Car{ID: id1(), DealershipID: "d1"}, nil).Times(2)
+	m.EXPECT().GetByID(id3()).Return(order1(), nil)
+	m.EXPECT().GetByID(id5()).Return(order1(), nil)
+	m.EXPECT().Complete(id3()).Return(completed, nil)
+	m.EXPECT().Complete(id5()).
+		Return(nil, customErrors.Conflict{Entity: "Order", Reason: "payment is pending, only paid orders can be completed"})
+
+	tests := []struct {
+		desc       string
+		id         string
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Success",
+			id3(),
+			http.StatusOK,
+			[]byte(`{"orderId":"` + id3() + `","customerId":"4924f6ff-5684-4d3c-8ca3-24486a1fc205",
+							"carId":"86a4cc77-4a2b-4215-8a2c-ff3ecca19627","currency":"EUR","agreedPrice":8999900,"discount":99900,
+							"tax":1691000,"total":10591000,"paymentStatus":"paid","status":"completed","createdAt":"2024-05-01T10:00:00Z",
+							"completedAt":"2024-05-01T11:00:00Z"}`),
+		},
+		{
+			"Order not paid",
+			id5(),
+			http.StatusConflict,
+			[]byte(`{"type":"/problems/conflict","title":"Entity conflicts with existing data","status":409,
+							"detail":"Order conflicts: payment is pending, only paid orders can be completed","instance":"/orders/complete",
+							"code":"conflict","id":"` + id5() + `"}`),
+		},
+		{
+			"Invalid id",
+			"o1",
+			http.StatusBadRequest,
+			[]byte(`{"type":"/problems/invalid-id","title":"Invalid ID","status":400,"detail":"id must be a valid UUID",
+							"instance":"/orders/complete","code":"invalid-id","id":"o1"}`),
+		},
+	}
+
+	h := NewOrder(m, cars)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/orders/complete", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		withKey(h.Complete, r, "north-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
+
+func TestOrderHandler_Dealership(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockOrderService(mockCtrl)
+	cars := mocks.NewMockCarService(mockCtrl)
+
+	// the car of the order is stocked at the dealership of north-key, south-key belongs to another dealership
+	m.EXPECT().GetByID(id3()).Return(order1(), nil).Times(4)
+	cars.EXPECT().GetByID(id1()).Return(&model.Car{ID: id1(), DealershipID: "d1"}, nil).Times(5)
+
+	notExists := func(entity, id string) []byte {
+		return []byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"` + entity +
+			` not exists","instance":"/orders","code":"entity-not-found","id":"` + id + `"}`)
+	}
+
+	body := `{"customerId":"` + id2() + `","carId":"` + id1() + `","currency":"EUR","agreedPrice":100}`
+
+	h := NewOrder(m, cars)
+
+	tests := []struct {
+		desc       string
+		handler    http.HandlerFunc
+		body       string
+		statusCode int
+		resp       []byte
+	}{
+		{"Order of another dealership", h.GetByID, "", http.StatusNotFound, notExists("Order", id3())},
+		{"Update an order of another dealership", h.Update, body, http.StatusNotFound, notExists("Order", id3())},
+		{"Complete an order of another dealership", h.Complete, "", http.StatusNotFound, notExists("Order", id3())},
+		{"Cancel an order of another dealership", h.Cancel, "", http.StatusNotFound, notExists("Order", id3())},
+		{"Order a car of another dealership", h.Create, body, http.StatusNotFound, notExists("Car", id1())},
+	}
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tc.body))
+		r = mux.SetURLVars(r, map[string]string{"id": id3()})
+		w := httptest.NewRecorder()
+
+		withKey(tc.handler, r, "south-key").ServeHTTP(w, r)
+
+		assertResponse(t, i, tc.desc, w.Result(), tc.statusCode, tc.resp)
+	}
+}
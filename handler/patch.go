@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"carAPI/codec"
+	customErrors "carAPI/custom-errors"
+	"carAPI/middleware"
+	"carAPI/model"
+	"carAPI/problem"
+)
+
+// readMergePatch applies the body of r as a JSON merge patch (RFC 7396) to the body of car in the version of the API of r,
+// and decodes the patched body into car. Members which are left out are kept, null members are removed and objects
+// are merged member by member. False is returned if an error response has been written
+func readMergePatch(w http.ResponseWriter, r *http.Request, car *model.Car) bool {
+	c, ok := codec.ForContentType(r.Header.Get("Content-Type"))
+	if !ok {
+		problem.Write(w, r, customErrors.CodeUnsupported, "the body must be one of "+strings.Join(codec.MediaTypes(), ", "))
+		return false
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, model.MaxBodySize))
+	if err != nil {
+		handleParseErr(w, r, err)
+		return false
+	}
+
+	version := middleware.Version(r.Context())
+
+	target, err := jsonValue(version.Response(car))
+	if err != nil {
+		handleServerErr(w, r, err, car.ID)
+		return false
+	}
+
+	var patched model.Car
+
+	dst, done := version.Request(&patched)
+
+	patch, err := c.UnmarshalTree(body, dst)
+	if err != nil {
+		handleParseErr(w, r, err)
+		return false
+	}
+
+	data, err := json.Marshal(mergePatch(target, patch))
+	if err != nil {
+		handleParseErr(w, r, err)
+		return false
+	}
+
+	err = json.Unmarshal(data, dst)
+	if err != nil {
+		handleParseErr(w, r, err)
+		return false
+	}
+
+	done()
+
+	*car = patched
+
+	return true
+}
+
+// mergePatch applies patch to target as defined by RFC 7396, both are decoded JSON values. Target may be changed
+func mergePatch(target, patch interface{}) interface{} {
+	members, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	obj, ok := target.(map[string]interface{})
+	if !ok {
+		obj = make(map[string]interface{}, len(members))
+	}
+
+	for name, value := range members {
+		if value == nil {
+			delete(obj, name)
+			continue
+		}
+
+		obj[name] = mergePatch(obj[name], value)
+	}
+
+	return obj
+}
+
+// jsonValue returns the decoded JSON encoding of v
+func jsonValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+
+	err = json.Unmarshal(data, &value)
+
+	return value, err
+}
@@ -1,23 +1,46 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/gorilla/mux"
 
+	"carAPI/blob"
+	"carAPI/dto"
+	"carAPI/graph"
 	"carAPI/handler"
 	"carAPI/middleware"
+	"carAPI/model"
+	"carAPI/openapi"
+	"carAPI/rpc"
 	"carAPI/service"
+	"carAPI/store"
+	"carAPI/store/attachment"
+	"carAPI/store/booking"
+	"carAPI/store/brand"
 	"carAPI/store/car"
+	"carAPI/store/carmodel"
+	"carAPI/store/customer"
+	"carAPI/store/dealership"
 	"carAPI/store/engine"
+	"carAPI/store/fueltype"
+	"carAPI/store/order"
+	"carAPI/store/trim"
+	"carAPI/validation"
 )
 
 func main() {
-	// connecting to db
-	db, err := sql.Open("mysql", "test:test@tcp(127.0.0.1:3306)/test")
+	// connecting to db, affected rows count the matched rows, so that updates which change nothing are not reported as missing rows
+	db, err := sql.Open("mysql", "test:test@tcp(127.0.0.1:3306)/test?parseTime=true&clientFoundRows=true")
 	if err != nil {
 		log.Println(err)
 	}
@@ -27,24 +50,331 @@ func main() {
 	// initialize dependencies
 	carStore := car.New(db)
 	engineStore := engine.NewEngineStore(db)
-	svc := service.New(carStore, engineStore)
-	h := handler.New(svc)
+	trimStore := trim.New(db)
+	customerStore := customer.New(db)
+	attachmentSvc := service.NewAttachment(attachment.New(db), carStore, blob.NewLocal(getEnv("ATTACHMENT_DIR", "attachments")))
+	catalogSvc := service.NewCatalog(brand.New(db), fueltype.New(db), carmodel.New(db), trimStore, engineStore)
+	bookingSvc := service.NewBooking(booking.New(db), carStore, customerStore, getEnvDuration("BOOKING_HOLD_DURATION", 48*time.Hour))
+
+	s := services{
+		car:        service.New(carStore, engineStore, trimStore, attachmentSvc),
+		engine:     engineStore,
+		catalog:    catalogSvc,
+		customer:   service.NewCustomer(customerStore),
+		order:      service.NewOrder(order.New(db), customerStore, carStore),
+		booking:    bookingSvc,
+		dealership: service.NewDealership(dealership.New(db), carStore),
+		attachment: attachmentSvc,
+	}
+
+	// warm the catalog cache, it is loaded on first use if the DB is not reachable yet
+	err = catalogSvc.Refresh()
+	if err != nil {
+		log.Println(err)
+	}
+
+	// release expired holds in the background
+	go bookingSvc.Sweep(context.Background(), getEnvDuration("BOOKING_SWEEP_INTERVAL", time.Minute))
+
+	// version 1 is deprecated since version 2 was introduced
+	v1 := deprecation{at: getEnvDate("V1_DEPRECATED_AT", "2026-10-19"), sunset: getEnvDate("V1_SUNSET", "2027-10-19")}
+
+	keys := getAPIKeys("API_KEYS", "nitesh-zs:admin")
+
+	r, err := newRouter(s, keys, v1)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	corsCfg := middleware.CORSConfig{
+		AllowedOrigins:   getEnvList("CORS_ALLOWED_ORIGINS", "*"),
+		AllowedMethods:   getEnvList("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE"),
+		AllowedHeaders:   getEnvList("CORS_ALLOWED_HEADERS", "Content-Type,x-api-key"),
+		ExposedHeaders:   getEnvList("CORS_EXPOSED_HEADERS", "Location,Content-Disposition,Deprecation,Sunset"),
+		AllowCredentials: getEnv("CORS_ALLOW_CREDENTIALS", "false") == "true",
+		MaxAge:           getEnvInt("CORS_MAX_AGE", 600),
+	}
+
+	// credentials require CORS_ALLOWED_ORIGINS to list the origins, the default wildcard is refused
+	err = corsCfg.Validate()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// CORS wraps the router so that preflight requests are answered before routing and auth
+	cors := middleware.CORS(corsCfg)
+
+	// the car service is also served over gRPC, on a port of its own
+	lis, err := net.Listen("tcp", ":"+getEnv("GRPC_PORT", "4001"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	go func() {
+		log.Println(rpc.NewServer(s.car, catalogSvc, keys).Serve(lis))
+	}()
+
+	// start server, requests without a version are served by version 1
+	log.Println(http.ListenAndServe(":4000", cors(middleware.DefaultVersion("/v1", r))))
+}
+
+// catalogService is the catalog the catalog routes are served by and cars are validated against
+type catalogService interface {
+	service.CatalogService
+	validation.Catalog
+}
+
+// services are the services the routes are served by
+type services struct {
+	car        service.CarService
+	engine     store.EngineStore
+	catalog    catalogService
+	customer   service.CustomerService
+	order      service.OrderService
+	booking    service.BookingService
+	dealership service.DealershipService
+	attachment service.AttachmentService
+}
+
+// deprecation tells when a version of the API was deprecated and when it is removed
+type deprecation struct {
+	at, sunset time.Time
+}
+
+// newRouter registers the routes of the API, every route is documented in openapi/openapi.json.
+// The OpenAPI document and its Swagger UI page are public, the routes of the versions of the API and the GraphQL
+// endpoint require one of the API keys keys. Version 1 is deprecated as told by v1
+func newRouter(s services, keys map[string]model.APIKey, v1 deprecation) (*mux.Router, error) {
+	// the bodies of the car routes are validated against the OpenAPI document before they reach the handlers
+	validator, err := openapi.NewValidator(s.catalog, "/v1/car", "/v2/car")
+	if err != nil {
+		return nil, err
+	}
 
-	// register handlers
 	r := mux.NewRouter()
 
 	r.StrictSlash(true)
 
+	r.HandleFunc("/openapi.json", openapi.Spec).Methods(http.MethodGet)
+	r.HandleFunc("/docs", openapi.UI).Methods(http.MethodGet)
+	r.Handle("/docs/swagger-ui/{file}", openapi.Assets).Methods(http.MethodGet)
+
+	// the GraphQL schema is not versioned, it only grows
+	gh, err := graph.New(s.car, s.engine, s.catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Handle("/graphql", middleware.Auth(keys)(gh)).Methods(http.MethodPost)
+
+	// the versions only differ in the bodies of cars, which the handlers write in the version of the request
+	v1Router := r.PathPrefix("/v1").Subrouter()
+	v1Router.Use(middleware.Deprecated(v1.at, v1.sunset))
+	versionRoutes(v1Router, s, keys, validator, dto.V1)
+
+	versionRoutes(r.PathPrefix("/v2").Subrouter(), s, keys, validator, dto.V2)
+
+	return r, nil
+}
+
+// versionRoutes registers the routes of version v of the API
+func versionRoutes(r *mux.Router, s services, keys map[string]model.APIKey, validator *openapi.Validator, v dto.Version) {
+	carRoutes(r, s)
+	catalogRoutes(r, s)
+	dealershipRoutes(r, s)
+	salesRoutes(r, s)
+
+	// set middlewares
+	r.Use(middleware.Auth(keys))
+	r.Use(middleware.Negotiate)
+	r.Use(middleware.Versioned(v))
+	r.Use(middleware.ValidateBody(validator))
+}
+
+// carRoutes registers the routes of cars and of their bookings and attachments
+func carRoutes(r *mux.Router, s services) {
+	h := handler.New(s.car, s.catalog)
+	bh := handler.NewBooking(s.booking, s.car)
+	ah := handler.NewAttachment(s.attachment)
+	dh := handler.NewDealership(s.dealership)
+
 	r.HandleFunc("/car", h.Get).Methods(http.MethodGet)
+	// /car/export is registered before /car/{id} so that it is matched first
+	r.HandleFunc("/car/export", h.Export).Methods(http.MethodGet)
 	r.HandleFunc("/car/{id}", h.GetByID).Methods(http.MethodGet)
+	r.HandleFunc("/car/vin/{vin}", h.GetByVIN).Methods(http.MethodGet)
 	r.HandleFunc("/car", h.Create).Methods(http.MethodPost)
-	r.HandleFunc("/car/{id}", h.Update).Methods(http.MethodPut)
-	r.HandleFunc("/car/{id}", h.Delete).Methods(http.MethodDelete)
+	r.HandleFunc("/car/{id}", h.Patch).Methods(http.MethodPatch)
 
-	// set middlewares
-	r.Use(middleware.AuthMiddleware)
-	r.Use(middleware.RespHeaderMiddleware)
+	// /car/bulk is registered before /car/{id} so that it is matched first
+	r.HandleFunc("/car/bulk", h.CreateMany).Methods(http.MethodPost)
+	r.HandleFunc("/car/bulk", h.UpdateMany).Methods(http.MethodPut)
+	r.HandleFunc("/car/bulk", h.DeleteMany).Methods(http.MethodDelete)
+	r.HandleFunc("/car/import", h.Import).Methods(http.MethodPost)
+
+	// cars are scoped to the dealership of the API key, the cars of other dealerships are not found
+	r.HandleFunc("/car/{id}", h.Owned(h.Update)).Methods(http.MethodPut)
+	r.HandleFunc("/car/{id}", h.Owned(h.Delete)).Methods(http.MethodDelete)
+	r.HandleFunc("/car/{id}/transition", h.Owned(h.Transition)).Methods(http.MethodPost)
+	r.HandleFunc("/car/{id}/history", h.Owned(h.GetStatusHistory)).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/prices", h.Owned(h.GetPriceHistory)).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/transfers", h.Owned(dh.GetTransfers)).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/bookings", h.Owned(bh.GetByCar)).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/availability", h.Owned(bh.Availability)).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/attachments", h.Owned(ah.GetByCar)).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/attachments", h.Owned(ah.Upload)).Methods(http.MethodPost)
+	r.HandleFunc("/car/{id}/attachments/{attachmentId}", h.Owned(ah.Download)).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/attachments/{attachmentId}/thumbnail", h.Owned(ah.Thumbnail)).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/attachments/{attachmentId}", h.Owned(ah.Delete)).Methods(http.MethodDelete)
+
+	// price changes are restricted to admin keys
+	r.Handle("/car/{id}/price", admin(h.Owned(h.SetPrice))).Methods(http.MethodPut)
+
+	// bookings are scoped to the dealership of the API key through their car by the handler
+	r.HandleFunc("/bookings", bh.Create).Methods(http.MethodPost)
+	r.HandleFunc("/bookings/{id}", bh.GetByID).Methods(http.MethodGet)
+	r.HandleFunc("/bookings/{id}/cancel", bh.Cancel).Methods(http.MethodPost)
+}
+
+// catalogRoutes registers the routes of the catalog, changes to the catalog are restricted to admin keys
+func catalogRoutes(r *mux.Router, s services) {
+	ch := handler.NewCatalog(s.catalog)
+
+	r.HandleFunc("/brands", ch.GetBrands).Methods(http.MethodGet)
+	r.HandleFunc("/fuel-types", ch.GetFuelTypes).Methods(http.MethodGet)
+	r.HandleFunc("/brands/{name}/models", ch.GetModels).Methods(http.MethodGet)
+	r.HandleFunc("/models/{id}/trims", ch.GetTrims).Methods(http.MethodGet)
+	r.HandleFunc("/trims/{id}", ch.GetTrim).Methods(http.MethodGet)
+
+	r.Handle("/brands", admin(http.HandlerFunc(ch.CreateBrand))).Methods(http.MethodPost)
+	r.Handle("/brands/{name}", admin(http.HandlerFunc(ch.UpdateBrand))).Methods(http.MethodPut)
+	r.Handle("/brands/{name}", admin(http.HandlerFunc(ch.DeleteBrand))).Methods(http.MethodDelete)
+	r.Handle("/fuel-types", admin(http.HandlerFunc(ch.CreateFuelType))).Methods(http.MethodPost)
+	r.Handle("/fuel-types/{name}", admin(http.HandlerFunc(ch.UpdateFuelType))).Methods(http.MethodPut)
+	r.Handle("/fuel-types/{name}", admin(http.HandlerFunc(ch.DeleteFuelType))).Methods(http.MethodDelete)
+	r.Handle("/models", admin(http.HandlerFunc(ch.CreateModel))).Methods(http.MethodPost)
+	r.Handle("/models/{id}", admin(http.HandlerFunc(ch.UpdateModel))).Methods(http.MethodPut)
+	r.Handle("/models/{id}", admin(http.HandlerFunc(ch.DeleteModel))).Methods(http.MethodDelete)
+	r.Handle("/trims", admin(http.HandlerFunc(ch.CreateTrim))).Methods(http.MethodPost)
+	r.Handle("/trims/{id}", admin(http.HandlerFunc(ch.UpdateTrim))).Methods(http.MethodPut)
+	r.Handle("/trims/{id}", admin(http.HandlerFunc(ch.DeleteTrim))).Methods(http.MethodDelete)
+}
+
+// dealershipRoutes registers the routes of dealerships, only admin keys see and move the cars of all dealerships
+func dealershipRoutes(r *mux.Router, s services) {
+	h := handler.New(s.car, s.catalog)
+	dh := handler.NewDealership(s.dealership)
+
+	// /dealerships/cars is registered before /dealerships/{id} so that it is matched first
+	r.Handle("/dealerships/cars", admin(http.HandlerFunc(h.GetAcrossDealerships))).Methods(http.MethodGet)
+	r.HandleFunc("/dealerships", dh.Get).Methods(http.MethodGet)
+	r.HandleFunc("/dealerships/{id}", dh.GetByID).Methods(http.MethodGet)
+	r.Handle("/dealerships", admin(http.HandlerFunc(dh.Create))).Methods(http.MethodPost)
+	r.Handle("/dealerships/{id}", admin(http.HandlerFunc(dh.Update))).Methods(http.MethodPut)
+	r.Handle("/car/{id}/transfer", admin(http.HandlerFunc(dh.Transfer))).Methods(http.MethodPost)
+}
+
+// salesRoutes registers the routes of customers and orders, they hold personal data and deal terms,
+// so they are restricted to admin keys. Orders are scoped to the dealership of the key through their cars
+func salesRoutes(r *mux.Router, s services) {
+	cuh := handler.NewCustomer(s.customer)
+	oh := handler.NewOrder(s.order, s.car)
+
+	r.Handle("/customers", admin(http.HandlerFunc(cuh.Get))).Methods(http.MethodGet)
+	r.Handle("/customers/{id}", admin(http.HandlerFunc(cuh.GetByID))).Methods(http.MethodGet)
+	r.Handle("/customers", admin(http.HandlerFunc(cuh.Create))).Methods(http.MethodPost)
+	r.Handle("/customers/{id}", admin(http.HandlerFunc(cuh.Update))).Methods(http.MethodPut)
+	r.Handle("/customers/{id}", admin(http.HandlerFunc(cuh.Delete))).Methods(http.MethodDelete)
+	r.Handle("/orders", admin(http.HandlerFunc(oh.Get))).Methods(http.MethodGet)
+	r.Handle("/orders/{id}", admin(http.HandlerFunc(oh.GetByID))).Methods(http.MethodGet)
+	r.Handle("/orders", admin(http.HandlerFunc(oh.Create))).Methods(http.MethodPost)
+	r.Handle("/orders/{id}", admin(http.HandlerFunc(oh.Update))).Methods(http.MethodPut)
+	r.Handle("/orders/{id}/complete", admin(http.HandlerFunc(oh.Complete))).Methods(http.MethodPost)
+	r.Handle("/orders/{id}/cancel", admin(http.HandlerFunc(oh.Cancel))).Methods(http.MethodPost)
+}
+
+// admin restricts a handler to admin keys
+func admin(next http.Handler) http.Handler {
+	return middleware.RequireRole(model.RoleAdmin)(next)
+}
+
+// getEnv returns the value of the environment variable key, or def if it is not set
+func getEnv(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+
+	return def
+}
+
+// getEnvList splits a comma separated environment variable into its values
+func getEnvList(key, def string) []string {
+	values := make([]string, 0)
+
+	for _, v := range strings.Split(getEnv(key, def), ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// getAPIKeys parses a comma separated list of key:role or key:role@dealershipId entries,
+// keys without a dealership belong to the default dealership
+func getAPIKeys(key, def string) map[string]model.APIKey {
+	keys := make(map[string]model.APIKey)
+
+	for _, v := range getEnvList(key, def) {
+		i := strings.LastIndex(v, ":")
+		if i < 1 {
+			log.Printf("invalid API key entry in %v, expected key:role or key:role@dealershipId", key)
+			continue
+		}
+
+		apiKey := model.APIKey{Role: model.Role(v[i+1:]), DealershipID: model.DefaultDealershipID}
+
+		if j := strings.Index(v[i+1:], "@"); j >= 0 {
+			apiKey.Role = model.Role(v[i+1 : i+1+j])
+			apiKey.DealershipID = v[i+2+j:]
+		}
+
+		keys[v[:i]] = apiKey
+	}
+
+	return keys
+}
+
+// getEnvDuration parses an environment variable formatted like 48h or 90m
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(getEnv(key, def.String()))
+	if err != nil || d <= 0 {
+		log.Printf("invalid value of %v, using %v", key, def)
+		return def
+	}
+
+	return d
+}
+
+// getEnvDate parses an environment variable formatted like 2006-01-02
+func getEnvDate(key, def string) time.Time {
+	t, err := time.Parse(model.DateLayout, getEnv(key, def))
+	if err != nil {
+		log.Printf("invalid value of %v, using %v", key, def)
+
+		t, _ = time.Parse(model.DateLayout, def)
+	}
+
+	return t
+}
+
+func getEnvInt(key string, def int) int {
+	v, err := strconv.Atoi(getEnv(key, strconv.Itoa(def)))
+	if err != nil {
+		log.Printf("invalid value of %v, using %v", key, def)
+		return def
+	}
 
-	// start server
-	log.Println(http.ListenAndServe(":4000", r))
+	return v
 }
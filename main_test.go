@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"carAPI/openapi"
+)
+
+// TestRoutesDocumented fails if a route is not documented in the OpenAPI document, or if the document describes a route
+// which is not registered
+func TestRoutesDocumented(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+
+	err := json.Unmarshal(openapi.Document(), &spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	documented := make(map[string]bool)
+
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	r, err := newRouter(services{}, nil, deprecation{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registered := make(map[string]bool)
+
+	err = r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		methods, err := route.GetMethods()
+		if err != nil {
+			// the subrouters of the versions have no methods
+			return nil
+		}
+
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+
+		for _, method := range methods {
+			registered[method+" "+path] = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for route := range registered {
+		assert.Truef(t, documented[route], "route %v is not documented in openapi/openapi.json", route)
+	}
+
+	for route := range documented {
+		assert.Truef(t, registered[route], "route %v is documented in openapi/openapi.json but not registered", route)
+	}
+}
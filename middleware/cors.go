@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/problem"
+)
+
+// CORSConfig holds the cross-origin policy applied by CORS
+type CORSConfig struct {
+	// AllowedOrigins lists the origins allowed to call the API, "*" allows any origin unless credentials are allowed
+	AllowedOrigins []string
+
+	// AllowedMethods lists the methods a browser may use in the actual request
+	AllowedMethods []string
+
+	// AllowedHeaders lists the request headers a browser may send in the actual request
+	AllowedHeaders []string
+
+	// ExposedHeaders lists the response headers, besides the CORS-safelisted ones, that browser scripts may read
+	ExposedHeaders []string
+
+	// AllowCredentials allows browsers to send cookies and authorization headers
+	AllowCredentials bool
+
+	// MaxAge is the number of seconds a preflight response may be cached, 0 omits the header
+	MaxAge int
+}
+
+// Validate returns an error if cfg allows any origin together with credentials, any site could then make
+// credentialed requests to the API
+func (c CORSConfig) Validate() error {
+	if c.AllowCredentials && contains(c.AllowedOrigins, "*") {
+		return errors.New("cors: the wildcard origin cannot be allowed together with credentials")
+	}
+
+	return nil
+}
+
+// CORS returns a middleware which applies the given cross-origin policy.
+// Preflight requests are answered by the middleware itself, so it must wrap
+// the router rather than being registered with Use: mux does not run route
+// middlewares for OPTIONS requests, and preflights carry no x-api-key.
+func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+
+			// not a cross-origin request
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if !cfg.allowsOrigin(origin) {
+				if preflight {
+					problem.Write(w, r, customErrors.CodeCORSRejected, "origin is not allowed")
+					return
+				}
+
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			cfg.setOriginHeaders(w, origin)
+
+			if !preflight {
+				if len(cfg.ExposedHeaders) != 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(cfg.ExposedHeaders, ", "))
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cfg.handlePreflight(w, r)
+		})
+	}
+}
+
+func (c CORSConfig) handlePreflight(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+	method := r.Header.Get("Access-Control-Request-Method")
+	if !contains(c.AllowedMethods, method) {
+		problem.Write(w, r, customErrors.CodeCORSRejected, "method is not allowed")
+		return
+	}
+
+	headers := parseHeaderList(r.Header.Get("Access-Control-Request-Headers"))
+	for _, h := range headers {
+		if !contains(c.AllowedHeaders, h) {
+			problem.Write(w, r, customErrors.CodeCORSRejected, "header is not allowed")
+			return
+		}
+	}
+
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(c.AllowedMethods, ", "))
+
+	if len(headers) != 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+	}
+
+	if c.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(c.MaxAge))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c CORSConfig) setOriginHeaders(w http.ResponseWriter, origin string) {
+	// origins are only echoed back if they are listed, as allowsOrigin ignores the wildcard if credentials are allowed
+	if contains(c.AllowedOrigins, "*") && !c.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+
+	if c.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// allowsOrigin reports whether origin is allowed, the wildcard never allows credentialed requests
+func (c CORSConfig) allowsOrigin(origin string) bool {
+	for _, o := range c.AllowedOrigins {
+		if (o == "*" && !c.AllowCredentials) || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains reports whether s is in list, ignoring case as methods and header names are case-insensitive
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseHeaderList(value string) []string {
+	headers := make([]string, 0)
+
+	for _, h := range strings.Split(value, ",") {
+		h = strings.TrimSpace(h)
+		if h != "" {
+			headers = append(headers, h)
+		}
+	}
+
+	return headers
+}
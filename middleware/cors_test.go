@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func corsConfig() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders:   []string{"Content-Type", "x-api-key"},
+		ExposedHeaders:   []string{"Location", "Deprecation"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}
+}
+
+func TestCORS(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	tests := []struct {
+		desc        string
+		method      string
+		headers     map[string]string
+		statusCode  int
+		allowOrigin string
+		allowHeader string
+		maxAge      string
+		exposed     string
+	}{
+		{
+			"Same origin request",
+			http.MethodGet,
+			map[string]string{},
+			http.StatusTeapot, "", "", "", "",
+		},
+		{
+			"Allowed origin",
+			http.MethodGet,
+			map[string]string{"Origin": "https://app.example.com"},
+			http.StatusTeapot, "https://app.example.com", "", "", "Location, Deprecation",
+		},
+		{
+			"Disallowed origin",
+			http.MethodGet,
+			map[string]string{"Origin": "https://evil.example.com"},
+			http.StatusTeapot, "", "", "", "",
+		},
+		{
+			"Preflight",
+			http.MethodOptions,
+			map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  http.MethodPost,
+				"Access-Control-Request-Headers": "content-type, x-api-key",
+			},
+			http.StatusNoContent, "https://app.example.com", "content-type, x-api-key", "600", "",
+		},
+		{
+			"Preflight from disallowed origin",
+			http.MethodOptions,
+			map[string]string{"Origin": "https://evil.example.com", "Access-Control-Request-Method": http.MethodGet},
+			http.StatusForbidden, "", "", "", "",
+		},
+		{
+			"Preflight with disallowed method",
+			http.MethodOptions,
+			map[string]string{"Origin": "https://app.example.com", "Access-Control-Request-Method": http.MethodDelete},
+			http.StatusForbidden, "https://app.example.com", "", "", "",
+		},
+		{
+			"Preflight with disallowed header",
+			http.MethodOptions,
+			map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  http.MethodGet,
+				"Access-Control-Request-Headers": "x-secret",
+			},
+			http.StatusForbidden, "https://app.example.com", "", "", "",
+		},
+		{
+			"Plain OPTIONS request is passed on",
+			http.MethodOptions,
+			map[string]string{"Origin": "https://app.example.com"},
+			http.StatusTeapot, "https://app.example.com", "", "", "Location, Deprecation",
+		},
+	}
+
+	h := CORS(corsConfig())(next)
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(tc.method, "/car", nil)
+
+		for k, v := range tc.headers {
+			r.Header.Set(k, v)
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		result := w.Result()
+		result.Body.Close()
+
+		assert.Equalf(t, tc.statusCode, result.StatusCode, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.allowOrigin, result.Header.Get("Access-Control-Allow-Origin"), "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.allowHeader, result.Header.Get("Access-Control-Allow-Headers"), "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.maxAge, result.Header.Get("Access-Control-Max-Age"), "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.exposed, result.Header.Get("Access-Control-Expose-Headers"), "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestCORS_Wildcard(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		desc        string
+		credentials bool
+		allowOrigin string
+	}{
+		{"Wildcard without credentials", false, "*"},
+		{"Wildcard with credentials allows no origin", true, ""},
+	}
+
+	for i, tc := range tests {
+		h := CORS(CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: tc.credentials})(next)
+
+		r := httptest.NewRequest(http.MethodGet, "/car", nil)
+		r.Header.Set("Origin", "https://any.example.com")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		assert.Equalf(t, tc.allowOrigin, w.Header().Get("Access-Control-Allow-Origin"), "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestCORSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		desc        string
+		origins     []string
+		credentials bool
+		wantErr     bool
+	}{
+		{"Wildcard without credentials", []string{"*"}, false, false},
+		{"Listed origins with credentials", []string{"https://app.example.com"}, true, false},
+		{"Wildcard with credentials", []string{"https://app.example.com", "*"}, true, true},
+	}
+
+	for i, tc := range tests {
+		err := CORSConfig{AllowedOrigins: tc.origins, AllowCredentials: tc.credentials}.Validate()
+
+		assert.Equalf(t, tc.wantErr, err != nil, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
@@ -1,28 +1,238 @@
 package middleware
 
 import (
-	"fmt"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"carAPI/codec"
+	customErrors "carAPI/custom-errors"
+	"carAPI/dto"
+	"carAPI/model"
+	"carAPI/openapi"
+	"carAPI/problem"
 )
 
-func AuthMiddleware(next http.Handler) http.Handler {
+type contextKey int
+
+const (
+	roleKey contextKey = iota
+	actorKey
+	dealershipKey
+	versionKey
+)
+
+// Auth returns a middleware which authenticates requests by their x-api-key header,
+// keys maps every valid API key to the role and dealership it grants
+func Auth(keys map[string]model.APIKey) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// check x-api-key in request header
+			ctx, ok := Authenticate(r.Context(), keys, r.Header.Get("x-api-key"))
+			if !ok {
+				problem.Write(w, r, customErrors.CodeUnauthorized, "")
+				return
+			}
+
+			// Call the next handler
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Authenticate returns ctx with the role, actor and dealership of the API key key, false is returned if keys
+// does not hold key. Other transports than HTTP authenticate their requests with it
+func Authenticate(ctx context.Context, keys map[string]model.APIKey, key string) (context.Context, bool) {
+	apiKey, ok := keys[key]
+	if !ok {
+		return ctx, false
+	}
+
+	ctx = context.WithValue(ctx, roleKey, apiKey.Role)
+	ctx = context.WithValue(ctx, actorKey, string(apiKey.Role)+":"+fingerprint(key))
+	ctx = context.WithValue(ctx, dealershipKey, apiKey.DealershipID)
+
+	return ctx, true
+}
+
+// RequireRole returns a middleware which only lets requests authenticated with one of roles through,
+// it must be applied after Auth
+func RequireRole(roles ...model.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role := Role(r.Context())
+
+			for _, allowed := range roles {
+				if role == allowed {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			problem.Write(w, r, customErrors.CodeForbidden, "")
+		})
+	}
+}
+
+// Role returns the role of the API key the request in ctx was authenticated with
+func Role(ctx context.Context) model.Role {
+	role, _ := ctx.Value(roleKey).(model.Role)
+	return role
+}
+
+// Actor identifies the API key the request in ctx was authenticated with, by its role and a fingerprint of the key,
+// so that changes can be attributed without storing the key
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey).(string)
+	return actor
+}
+
+// Dealership returns the dealership of the API key the request in ctx was authenticated with,
+// the cars of the request are scoped to it
+func Dealership(ctx context.Context) string {
+	dealershipID, _ := ctx.Value(dealershipKey).(string)
+	return dealershipID
+}
+
+// fingerprint returns the first 8 hex digits of the SHA-256 hash of key
+func fingerprint(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:4])
+}
+
+// Negotiate refuses requests with unsafe methods whose Accept header names none of the media types responses are encoded in,
+// before they take effect. Safe requests are negotiated once their response is written, so that downloads in other formats
+// are served
+func Negotiate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// check x-api-key in request header
-		if r.Header.Get("x-api-key") != "nitesh-zs" {
-			w.WriteHeader(http.StatusUnauthorized)
-			fmt.Fprint(w, `{"error":{"code":"Authorization error","message":"A valid 'x-api-key' must be set in request headers"}}`)
+		safe := r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+
+		if _, ok := codec.ForAccept(r.Header.Get("Accept")); !ok && !safe {
+			problem.Write(w, r, customErrors.CodeNotAcceptable, "the response can be one of "+strings.Join(codec.MediaTypes(), ", "))
 			return
 		}
-		// Call the next handler
+
 		next.ServeHTTP(w, r)
 	})
 }
 
-func RespHeaderMiddleware(next http.Handler) http.Handler {
+// ValidateBody returns a middleware which validates the JSON and YAML bodies of requests against the schema of their route
+// in the OpenAPI document, before they reach the handler. XML bodies have no types to validate and are left to the handler,
+// as are bodies which cannot be parsed
+func ValidateBody(v *openapi.Validator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			errs, err := violations(v, w, r)
+			if customErrors.IsTooLarge(err) {
+				problem.Write(w, r, customErrors.CodeTooLarge, "the body is too large")
+				return
+			}
+
+			if err != nil {
+				problem.Write(w, r, customErrors.CodeMalformedBody, err.Error())
+				return
+			}
+
+			if len(errs) != 0 {
+				d := problem.New(customErrors.CodeSchema, "errors lists every member of the body which does not match the schema")
+				d.Errors = errs
+				d.Write(w, r)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// violations validates the body of r against the schema of its route, the body of r can be read again afterwards.
+// The body is read before the handler bounds it, so it is bounded by the size of the largest bodies, those of bulk requests
+func violations(v *openapi.Validator, w http.ResponseWriter, r *http.Request) (customErrors.InvalidFields, error) {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return nil, nil
+	}
+
+	path, err := route.GetPathTemplate()
+	if err != nil || !v.Validates(r.Method, path) {
+		return nil, nil
+	}
+
+	c, ok := codec.ForContentType(r.Header.Get("Content-Type"))
+	if !ok || c.MediaType == codec.MediaTypeXML {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, model.MaxBulkBodySize))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var doc interface{}
+
+	// the handler reports bodies which cannot be parsed
+	if c.Unmarshal(body, &doc) != nil {
+		return nil, nil
+	}
+
+	return v.Validate(r.Method, path, doc), nil
+}
+
+// Versioned returns a middleware which serves requests in version v of the API
+func Versioned(v dto.Version) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), versionKey, v)))
+		})
+	}
+}
+
+// Version returns the version of the API the request in ctx is served in, version 1 if it is not versioned
+func Version(ctx context.Context) dto.Version {
+	v, ok := ctx.Value(versionKey).(dto.Version)
+	if !ok {
+		return dto.V1
+	}
+
+	return v
+}
+
+// Deprecated returns a middleware which marks the responses of a deprecated version of the API with the Deprecation header
+// of RFC 9745, telling when the version was deprecated, and the Sunset header of RFC 8594, telling when it is removed
+func Deprecated(deprecatedAt, sunset time.Time) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "@"+strconv.FormatInt(deprecatedAt.Unix(), 10))
+			w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// DefaultVersion serves requests which no route of router matches as requests to the version with given path prefix,
+// so that clients which predate versioning keep working
+func DefaultVersion(prefix string, router *mux.Router) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// set Content-Type in response header
-		w.Header().Set("Content-Type", "application/json")
-		// Call the next handler
-		next.ServeHTTP(w, r)
+		var match mux.RouteMatch
+
+		if !router.Match(r, &match) && !errors.Is(match.MatchErr, mux.ErrMethodMismatch) {
+			r.URL.Path = prefix + r.URL.Path
+			r.URL.RawPath = ""
+		}
+
+		router.ServeHTTP(w, r)
 	})
 }
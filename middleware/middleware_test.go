@@ -0,0 +1,266 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"carAPI/mocks"
+	"carAPI/model"
+	"carAPI/openapi"
+)
+
+func TestAuth(t *testing.T) {
+	keys := map[string]model.APIKey{"admin-key": {Role: model.RoleAdmin}, "viewer-key": {Role: model.RoleViewer}}
+
+	var role model.Role
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role = Role(r.Context())
+	})
+
+	adminOnly := Auth(keys)(RequireRole(model.RoleAdmin)(next))
+
+	tests := []struct {
+		desc       string
+		key        string
+		statusCode int
+		role       model.Role
+	}{
+		{"Admin key", "admin-key", http.StatusOK, model.RoleAdmin},
+		{"Viewer key", "viewer-key", http.StatusForbidden, ""},
+		{"Unknown key", "unknown", http.StatusUnauthorized, ""},
+		{"Missing key", "", http.StatusUnauthorized, ""},
+	}
+
+	for i, tc := range tests {
+		role = ""
+
+		r := httptest.NewRequest(http.MethodPost, "/brands", nil)
+		r.Header.Set("x-api-key", tc.key)
+
+		w := httptest.NewRecorder()
+		adminOnly.ServeHTTP(w, r)
+
+		result := w.Result()
+		result.Body.Close()
+
+		assert.Equalf(t, tc.statusCode, result.StatusCode, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.role, role, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestActor(t *testing.T) {
+	var actor string
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = Actor(r.Context())
+	})
+
+	r := httptest.NewRequest(http.MethodPut, "/car/1/price", nil)
+	r.Header.Set("x-api-key", "admin-key")
+
+	Auth(map[string]model.APIKey{"admin-key": {Role: model.RoleAdmin}})(next).ServeHTTP(httptest.NewRecorder(), r)
+
+	// the actor carries a fingerprint of the key, never the key itself
+	assert.Equal(t, "admin:"+fingerprint("admin-key"), actor)
+	assert.Len(t, actor, len("admin:")+8)
+	assert.NotContains(t, actor, "admin-key")
+}
+
+func TestDealership(t *testing.T) {
+	keys := map[string]model.APIKey{
+		"north-key": {Role: model.RoleViewer, DealershipID: "d1"},
+		"south-key": {Role: model.RoleAdmin, DealershipID: "d2"},
+	}
+
+	var dealershipID string
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dealershipID = Dealership(r.Context())
+	})
+
+	tests := []struct {
+		desc         string
+		key          string
+		dealershipID string
+	}{
+		{"Viewer key", "north-key", "d1"},
+		{"Admin key", "south-key", "d2"},
+		{"Unknown key", "unknown", ""},
+	}
+
+	for i, tc := range tests {
+		dealershipID = ""
+
+		r := httptest.NewRequest(http.MethodGet, "/car", nil)
+		r.Header.Set("x-api-key", tc.key)
+
+		Auth(keys)(next).ServeHTTP(httptest.NewRecorder(), r)
+
+		assert.Equalf(t, tc.dealershipID, dealershipID, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	var called bool
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	tests := []struct {
+		desc       string
+		method     string
+		accept     string
+		statusCode int
+		called     bool
+	}{
+		{"No Accept header", http.MethodPost, "", http.StatusOK, true},
+		{"Supported media type", http.MethodPut, "application/xml", http.StatusOK, true},
+		{"Unsupported media type", http.MethodPost, "text/html", http.StatusNotAcceptable, false},
+		{"Safe request", http.MethodGet, "text/csv", http.StatusOK, true},
+	}
+
+	for i, tc := range tests {
+		called = false
+
+		r := httptest.NewRequest(tc.method, "/car", nil)
+		r.Header.Set("Accept", tc.accept)
+
+		w := httptest.NewRecorder()
+		Negotiate(next).ServeHTTP(w, r)
+
+		result := w.Result()
+		result.Body.Close()
+
+		assert.Equalf(t, tc.statusCode, result.StatusCode, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.called, called, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestValidateBody(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCatalog(mockCtrl)
+
+	c.EXPECT().Brands().Return([]string{"Tesla"}, nil).AnyTimes()
+	c.EXPECT().FuelTypes().Return([]string{"Electric"}, nil).AnyTimes()
+	c.EXPECT().EngineRules().Return(map[string]model.EngineRule{
+		"Electric": {Required: []string{"range"}, Forbidden: []string{"displacement", "noOfCylinders", "electricRange", "tankCapacity"}},
+	}, nil).AnyTimes()
+
+	v, err := openapi.NewValidator(c, "/v1/car")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body string
+
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/car", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}).Methods(http.MethodPost)
+	router.Use(ValidateBody(v))
+
+	valid := `{"name":"Model 3","yearOfManufacture":2020,"brand":"Tesla","fuelType":"Electric"}`
+
+	tests := []struct {
+		desc        string
+		contentType string
+		body        string
+		statusCode  int
+		handlerBody string
+	}{
+		{"Valid body", "", valid, http.StatusOK, valid},
+		{"Type of a member", "application/json", `{"yearOfManufacture":"2000"}`, http.StatusBadRequest, ""},
+		{"YAML", "application/yaml", "name: Model 3\nyearOfManufacture: 2020\nbrand: Fiat\nfuelType: Electric\n", http.StatusBadRequest, ""},
+		{"XML is left to the handler", "application/xml", `<car><yearOfManufacture>x</yearOfManufacture></car>`,
+			http.StatusOK, `<car><yearOfManufacture>x</yearOfManufacture></car>`},
+		{"Malformed body is left to the handler", "application/json", `{"name":`, http.StatusOK, `{"name":`},
+		{"Body too large", "application/json", strings.Repeat(" ", model.MaxBulkBodySize) + valid, http.StatusRequestEntityTooLarge, ""},
+	}
+
+	for i, tc := range tests {
+		body = ""
+
+		r := httptest.NewRequest(http.MethodPost, "/v1/car", strings.NewReader(tc.body))
+		r.Header.Set("Content-Type", tc.contentType)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		result := w.Result()
+		result.Body.Close()
+
+		assert.Equalf(t, tc.statusCode, result.StatusCode, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.handlerBody, body, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestDeprecated(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	w := httptest.NewRecorder()
+	Deprecated(deprecatedAt, sunset)(http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/car", nil))
+
+	result := w.Result()
+	result.Body.Close()
+
+	assert.Equal(t, "@1792368000", result.Header.Get("Deprecation"))
+	assert.Equal(t, "Tue, 19 Oct 2027 00:00:00 GMT", result.Header.Get("Sunset"))
+}
+
+func TestDefaultVersion(t *testing.T) {
+	var path string
+
+	next := func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/docs", next).Methods(http.MethodGet)
+	router.HandleFunc("/v1/car", next).Methods(http.MethodGet)
+	router.HandleFunc("/v2/car", next).Methods(http.MethodGet)
+
+	tests := []struct {
+		desc       string
+		method     string
+		target     string
+		statusCode int
+		path       string
+	}{
+		{"Unversioned path", http.MethodGet, "/car", http.StatusOK, "/v1/car"},
+		{"Version 2", http.MethodGet, "/v2/car", http.StatusOK, "/v2/car"},
+		{"Unversioned route", http.MethodGet, "/docs", http.StatusOK, "/docs"},
+		{"Method of a route", http.MethodPost, "/v2/car", http.StatusMethodNotAllowed, ""},
+		{"Unknown path", http.MethodGet, "/cars", http.StatusNotFound, ""},
+	}
+
+	for i, tc := range tests {
+		path = ""
+
+		w := httptest.NewRecorder()
+		DefaultVersion("/v1", router).ServeHTTP(w, httptest.NewRequest(tc.method, tc.target, nil))
+
+		result := w.Result()
+		result.Body.Close()
+
+		assert.Equalf(t, tc.statusCode, result.StatusCode, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.path, path, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
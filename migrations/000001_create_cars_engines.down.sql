@@ -0,0 +1,2 @@
+drop table if exists cars;
+drop table if exists engines;
@@ -0,0 +1,16 @@
+create table if not exists engines (
+    engineId     varchar(36) not null primary key,
+    displacement int         not null default 0,
+    noOfCylinder int         not null default 0,
+    `range`      int         not null default 0
+);
+
+create table if not exists cars (
+    carId             varchar(36)  not null primary key,
+    name              varchar(255) not null,
+    yearOfManufacture int          not null,
+    brand             varchar(50)  not null,
+    fuelType          varchar(50)  not null,
+    engineId          varchar(36)  not null,
+    constraint fk_cars_engine foreign key (engineId) references engines (engineId)
+);
@@ -0,0 +1,6 @@
+alter table cars
+    drop foreign key fk_cars_brand,
+    drop foreign key fk_cars_fuel_type;
+
+drop table if exists fuel_types;
+drop table if exists brands;
@@ -0,0 +1,15 @@
+create table brands (
+    name varchar(50) not null primary key
+);
+
+create table fuel_types (
+    name varchar(50) not null primary key
+);
+
+insert into brands (name) values ('Tesla'), ('Ferrari'), ('BMW'), ('Porsche');
+insert into fuel_types (name) values ('Electric'), ('Petrol'), ('Diesel');
+
+-- cars may only reference catalog entries, renaming an entry renames it on all cars
+alter table cars
+    add constraint fk_cars_brand foreign key (brand) references brands (name) on update cascade,
+    add constraint fk_cars_fuel_type foreign key (fuelType) references fuel_types (name) on update cascade;
@@ -0,0 +1,6 @@
+alter table cars
+    drop foreign key fk_cars_trim,
+    drop column trimId;
+
+drop table if exists trims;
+drop table if exists models;
@@ -0,0 +1,22 @@
+create table models (
+    modelId varchar(36) not null primary key,
+    brand   varchar(50) not null,
+    name    varchar(50) not null,
+    constraint uq_models_brand_name unique (brand, name),
+    constraint fk_models_brand foreign key (brand) references brands (name) on update cascade
+);
+
+-- the engine of a trim is the default engine of its cars
+create table trims (
+    trimId   varchar(36) not null primary key,
+    modelId  varchar(36) not null,
+    name     varchar(50) not null,
+    engineId varchar(36) not null,
+    constraint uq_trims_model_name unique (modelId, name),
+    constraint fk_trims_model foreign key (modelId) references models (modelId),
+    constraint fk_trims_engine foreign key (engineId) references engines (engineId)
+);
+
+alter table cars
+    add column trimId varchar(36) null,
+    add constraint fk_cars_trim foreign key (trimId) references trims (trimId);
@@ -0,0 +1,7 @@
+delete from fuel_types where name in ('Hybrid', 'Plug-in Hybrid', 'CNG', 'Hydrogen');
+
+alter table engines
+    drop column batteryCapacity,
+    drop column electricRange,
+    drop column tankCapacity,
+    drop column noOfMotors;
@@ -0,0 +1,7 @@
+alter table engines
+    add column batteryCapacity decimal(6, 2) not null default 0,
+    add column electricRange   int           not null default 0,
+    add column tankCapacity    decimal(6, 2) not null default 0,
+    add column noOfMotors      int           not null default 0;
+
+insert ignore into fuel_types (name) values ('Hybrid'), ('Plug-in Hybrid'), ('CNG'), ('Hydrogen');
@@ -0,0 +1,8 @@
+drop index idx_engines_power on engines;
+
+alter table engines
+    drop column power,
+    drop column torque,
+    drop column transmission,
+    drop column gears,
+    drop column drivetrain;
@@ -0,0 +1,9 @@
+-- the specs are optional, zero and empty values mean the spec is not known
+alter table engines
+    add column power        int         not null default 0,
+    add column torque       int         not null default 0,
+    add column transmission varchar(20) not null default '',
+    add column gears        int         not null default 0,
+    add column drivetrain   varchar(20) not null default '';
+
+create index idx_engines_power on engines (power);
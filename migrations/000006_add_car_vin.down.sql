@@ -0,0 +1,3 @@
+alter table cars
+    drop index uq_cars_vin,
+    drop column vin;
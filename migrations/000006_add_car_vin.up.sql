@@ -0,0 +1,4 @@
+-- the vin is optional, unique constraints ignore null values
+alter table cars
+    add column vin varchar(17) null,
+    add constraint uq_cars_vin unique (vin);
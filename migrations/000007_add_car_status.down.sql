@@ -0,0 +1,6 @@
+drop table if exists car_status_history;
+
+drop index idx_cars_status on cars;
+
+alter table cars
+    drop column status;
@@ -0,0 +1,15 @@
+-- cars created before the status lifecycle are on the lot
+alter table cars
+    add column status varchar(20) not null default 'on-lot';
+
+create index idx_cars_status on cars (status);
+
+create table car_status_history (
+    id         bigint      not null auto_increment primary key,
+    carId      varchar(36) not null,
+    fromStatus varchar(20) null,
+    toStatus   varchar(20) not null,
+    changedAt  datetime(3) not null,
+    index idx_car_status_history_car (carId, changedAt),
+    constraint fk_car_status_history_car foreign key (carId) references cars (carId) on delete cascade
+);
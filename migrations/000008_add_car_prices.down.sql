@@ -0,0 +1,8 @@
+drop table if exists car_prices;
+
+drop index idx_cars_list_price on cars;
+
+alter table cars
+    drop column minPrice,
+    drop column listPrice,
+    drop column currency;
@@ -0,0 +1,20 @@
+-- prices are in the minor unit of the currency, cars without a price have no currency either
+alter table cars
+    add column currency  char(3) null,
+    add column listPrice bigint  null,
+    add column minPrice  bigint  null;
+
+create index idx_cars_list_price on cars (listPrice);
+
+create table car_prices (
+    id        bigint       not null auto_increment primary key,
+    carId     varchar(36)  not null,
+    currency  char(3)      not null,
+    listPrice bigint       not null,
+    minPrice  bigint       null,
+    actor     varchar(100) not null,
+    reason    varchar(255) not null,
+    changedAt datetime(3)  not null,
+    index idx_car_prices_car (carId, changedAt),
+    constraint fk_car_prices_car foreign key (carId) references cars (carId) on delete cascade
+);
@@ -0,0 +1,27 @@
+create table customers (
+    customerId varchar(36)  not null primary key,
+    name       varchar(100) not null,
+    email      varchar(254) not null,
+    phone      varchar(20)  null,
+    constraint uq_customers_email unique (email)
+);
+
+-- amounts are in the minor unit of the currency, orders are kept when their car is sold, so cars of orders cannot be deleted
+create table orders (
+    orderId       varchar(36) not null primary key,
+    customerId    varchar(36) not null,
+    carId         varchar(36) not null,
+    currency      char(3)     not null,
+    agreedPrice   bigint      not null,
+    discount      bigint      not null default 0,
+    tax           bigint      not null default 0,
+    total         bigint      not null,
+    paymentStatus varchar(20) not null default 'pending',
+    status        varchar(20) not null default 'open',
+    createdAt     datetime(3) not null,
+    completedAt   datetime(3) null,
+    index idx_orders_customer (customerId),
+    index idx_orders_car (carId),
+    constraint fk_orders_customer foreign key (customerId) references customers (customerId),
+    constraint fk_orders_car foreign key (carId) references cars (carId)
+);
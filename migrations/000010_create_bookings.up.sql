@@ -0,0 +1,17 @@
+-- overlapping active bookings of a car are prevented by locking the car row while a booking is created
+create table bookings (
+    bookingId  varchar(36) not null primary key,
+    carId      varchar(36) not null,
+    customerId varchar(36) null,
+    kind       varchar(20) not null,
+    startsAt   datetime(3) not null,
+    endsAt     datetime(3) not null,
+    status     varchar(20) not null default 'active',
+    expiresAt  datetime(3) null,
+    createdAt  datetime(3) not null,
+    index idx_bookings_car (carId, status, startsAt),
+    index idx_bookings_expiry (status, expiresAt),
+    constraint chk_bookings_slot check (startsAt < endsAt),
+    constraint fk_bookings_car foreign key (carId) references cars (carId) on delete cascade,
+    constraint fk_bookings_customer foreign key (customerId) references customers (customerId)
+);
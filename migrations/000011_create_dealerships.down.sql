@@ -0,0 +1,9 @@
+drop table if exists car_transfers;
+
+drop index idx_cars_dealership on cars;
+
+alter table cars
+    drop foreign key fk_cars_dealership,
+    drop column dealershipId;
+
+drop table if exists dealerships;
@@ -0,0 +1,28 @@
+create table dealerships (
+    dealershipId varchar(36) not null primary key,
+    name         varchar(50) not null,
+    city         varchar(50) null,
+    constraint uq_dealerships_name unique (name)
+);
+
+-- existing cars and API keys without a dealership belong to the default dealership
+insert into dealerships (dealershipId, name) values ('00000000-0000-0000-0000-000000000001', 'Main showroom');
+
+alter table cars
+    add column dealershipId varchar(36) not null default '00000000-0000-0000-0000-000000000001',
+    add constraint fk_cars_dealership foreign key (dealershipId) references dealerships (dealershipId);
+
+create index idx_cars_dealership on cars (dealershipId, status);
+
+create table car_transfers (
+    id               bigint       not null auto_increment primary key,
+    carId            varchar(36)  not null,
+    fromDealershipId varchar(36)  not null,
+    toDealershipId   varchar(36)  not null,
+    actor            varchar(100) not null,
+    transferredAt    datetime(3)  not null,
+    index idx_car_transfers_car (carId, transferredAt),
+    constraint fk_car_transfers_car foreign key (carId) references cars (carId) on delete cascade,
+    constraint fk_car_transfers_from foreign key (fromDealershipId) references dealerships (dealershipId),
+    constraint fk_car_transfers_to foreign key (toDealershipId) references dealerships (dealershipId)
+);
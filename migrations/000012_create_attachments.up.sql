@@ -0,0 +1,13 @@
+-- the content of attachments is kept in the blob storage, it is deleted by the service when a car is deleted
+create table attachments (
+    attachmentId varchar(36)  not null primary key,
+    carId        varchar(36)  not null,
+    kind         varchar(20)  not null,
+    fileName     varchar(255) not null,
+    contentType  varchar(100) not null,
+    size         bigint       not null,
+    hasThumbnail boolean      not null default false,
+    createdAt    datetime(3)  not null,
+    index idx_attachments_car (carId, createdAt),
+    constraint fk_attachments_car foreign key (carId) references cars (carId) on delete cascade
+);
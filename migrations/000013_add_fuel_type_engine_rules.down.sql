@@ -0,0 +1,3 @@
+alter table fuel_types
+    drop column requiredEngineParams,
+    drop column forbiddenEngineParams;
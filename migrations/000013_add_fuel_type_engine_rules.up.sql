@@ -0,0 +1,36 @@
+-- the engine rules of fuel types are comma separated engine params, cars of a fuel type must have the required params
+-- and must not have the forbidden ones
+alter table fuel_types
+    add column requiredEngineParams  varchar(255) not null default '',
+    add column forbiddenEngineParams varchar(255) not null default '';
+
+update fuel_types
+set requiredEngineParams  = 'range',
+    forbiddenEngineParams = 'displacement,noOfCylinders,electricRange,tankCapacity'
+where name = 'Electric';
+
+update fuel_types
+set requiredEngineParams  = 'displacement,noOfCylinders',
+    forbiddenEngineParams = 'batteryCapacity,electricRange,noOfMotors'
+where name in ('Petrol', 'Diesel');
+
+-- a hybrid cannot be charged from the grid, so it has no electric range worth declaring
+update fuel_types
+set requiredEngineParams  = 'displacement,noOfCylinders,batteryCapacity,noOfMotors',
+    forbiddenEngineParams = 'electricRange'
+where name = 'Hybrid';
+
+update fuel_types
+set requiredEngineParams = 'displacement,noOfCylinders,batteryCapacity,electricRange,noOfMotors'
+where name = 'Plug-in Hybrid';
+
+update fuel_types
+set requiredEngineParams  = 'displacement,noOfCylinders,tankCapacity',
+    forbiddenEngineParams = 'batteryCapacity,electricRange,noOfMotors'
+where name = 'CNG';
+
+-- hydrogen cars are fuel cell electric cars
+update fuel_types
+set requiredEngineParams  = 'range,tankCapacity,noOfMotors',
+    forbiddenEngineParams = 'displacement,noOfCylinders,electricRange'
+where name = 'Hydrogen';
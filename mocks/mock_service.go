@@ -5,7 +5,9 @@
 package mocks
 
 import (
+	customerrors "carAPI/custom-errors"
 	model "carAPI/model"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,6 +36,21 @@ func (m *MockCarService) EXPECT() *MockCarServiceMockRecorder {
 	return m.recorder
 }
 
+// CheckMany mocks base method.
+func (m *MockCarService) CheckMany(cars []*model.Car) (map[int]customerrors.InvalidFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMany", cars)
+	ret0, _ := ret[0].(map[int]customerrors.InvalidFields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckMany indicates an expected call of CheckMany.
+func (mr *MockCarServiceMockRecorder) CheckMany(cars interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMany", reflect.TypeOf((*MockCarService)(nil).CheckMany), cars)
+}
+
 // Create mocks base method.
 func (m *MockCarService) Create(car *model.Car) (*model.Car, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +66,21 @@ func (mr *MockCarServiceMockRecorder) Create(car interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCarService)(nil).Create), car)
 }
 
+// CreateMany mocks base method.
+func (m *MockCarService) CreateMany(cars []*model.Car, atomic bool) (map[int]customerrors.InvalidFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", cars, atomic)
+	ret0, _ := ret[0].(map[int]customerrors.InvalidFields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockCarServiceMockRecorder) CreateMany(cars, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockCarService)(nil).CreateMany), cars, atomic)
+}
+
 // Delete mocks base method.
 func (m *MockCarService) Delete(id string) error {
 	m.ctrl.T.Helper()
@@ -63,19 +95,48 @@ func (mr *MockCarServiceMockRecorder) Delete(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCarService)(nil).Delete), id)
 }
 
+// DeleteMany mocks base method.
+func (m *MockCarService) DeleteMany(dealershipID string, ids []string, atomic bool) (map[int]customerrors.InvalidFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", dealershipID, ids, atomic)
+	ret0, _ := ret[0].(map[int]customerrors.InvalidFields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockCarServiceMockRecorder) DeleteMany(dealershipID, ids, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockCarService)(nil).DeleteMany), dealershipID, ids, atomic)
+}
+
+// Each mocks base method.
+func (m *MockCarService) Each(filter model.CarFilter, fn func(*model.Car) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockCarServiceMockRecorder) Each(filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockCarService)(nil).Each), filter, fn)
+}
+
 // GetAll mocks base method.
-func (m *MockCarService) GetAll(brand string, withEngine bool) ([]model.Car, error) {
+func (m *MockCarService) GetAll(filter model.CarFilter, withEngine bool) ([]model.Car, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", brand, withEngine)
+	ret := m.ctrl.Call(m, "GetAll", filter, withEngine)
 	ret0, _ := ret[0].([]model.Car)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockCarServiceMockRecorder) GetAll(brand, withEngine interface{}) *gomock.Call {
+func (mr *MockCarServiceMockRecorder) GetAll(filter, withEngine interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCarService)(nil).GetAll), brand, withEngine)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCarService)(nil).GetAll), filter, withEngine)
 }
 
 // GetByID mocks base method.
@@ -93,6 +154,97 @@ func (mr *MockCarServiceMockRecorder) GetByID(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCarService)(nil).GetByID), id)
 }
 
+// GetByVIN mocks base method.
+func (m *MockCarService) GetByVIN(vin string) (*model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVIN", vin)
+	ret0, _ := ret[0].(*model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVIN indicates an expected call of GetByVIN.
+func (mr *MockCarServiceMockRecorder) GetByVIN(vin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVIN", reflect.TypeOf((*MockCarService)(nil).GetByVIN), vin)
+}
+
+// GetPriceHistory mocks base method.
+func (m *MockCarService) GetPriceHistory(id string) ([]model.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceHistory", id)
+	ret0, _ := ret[0].([]model.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockCarServiceMockRecorder) GetPriceHistory(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*MockCarService)(nil).GetPriceHistory), id)
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockCarService) GetStatusHistory(id string) ([]model.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", id)
+	ret0, _ := ret[0].([]model.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockCarServiceMockRecorder) GetStatusHistory(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockCarService)(nil).GetStatusHistory), id)
+}
+
+// Page mocks base method.
+func (m *MockCarService) Page(filter model.CarFilter, limit, offset int) ([]model.Car, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Page", filter, limit, offset)
+	ret0, _ := ret[0].([]model.Car)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Page indicates an expected call of Page.
+func (mr *MockCarServiceMockRecorder) Page(filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Page", reflect.TypeOf((*MockCarService)(nil).Page), filter, limit, offset)
+}
+
+// SetPrice mocks base method.
+func (m *MockCarService) SetPrice(id string, change *model.PriceChange) (*model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrice", id, change)
+	ret0, _ := ret[0].(*model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPrice indicates an expected call of SetPrice.
+func (mr *MockCarServiceMockRecorder) SetPrice(id, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrice", reflect.TypeOf((*MockCarService)(nil).SetPrice), id, change)
+}
+
+// Transition mocks base method.
+func (m *MockCarService) Transition(id string, to model.Status) (*model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", id, to)
+	ret0, _ := ret[0].(*model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockCarServiceMockRecorder) Transition(id, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockCarService)(nil).Transition), id, to)
+}
+
 // Update mocks base method.
 func (m *MockCarService) Update(car *model.Car) (*model.Car, error) {
 	m.ctrl.T.Helper()
@@ -107,3 +259,885 @@ func (mr *MockCarServiceMockRecorder) Update(car interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCarService)(nil).Update), car)
 }
+
+// UpdateMany mocks base method.
+func (m *MockCarService) UpdateMany(dealershipID string, cars []*model.Car, atomic bool) (map[int]customerrors.InvalidFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMany", dealershipID, cars, atomic)
+	ret0, _ := ret[0].(map[int]customerrors.InvalidFields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMany indicates an expected call of UpdateMany.
+func (mr *MockCarServiceMockRecorder) UpdateMany(dealershipID, cars, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMany", reflect.TypeOf((*MockCarService)(nil).UpdateMany), dealershipID, cars, atomic)
+}
+
+// MockCatalogService is a mock of CatalogService interface.
+type MockCatalogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogServiceMockRecorder
+}
+
+// MockCatalogServiceMockRecorder is the mock recorder for MockCatalogService.
+type MockCatalogServiceMockRecorder struct {
+	mock *MockCatalogService
+}
+
+// NewMockCatalogService creates a new mock instance.
+func NewMockCatalogService(ctrl *gomock.Controller) *MockCatalogService {
+	mock := &MockCatalogService{ctrl: ctrl}
+	mock.recorder = &MockCatalogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogService) EXPECT() *MockCatalogServiceMockRecorder {
+	return m.recorder
+}
+
+// Brands mocks base method.
+func (m *MockCatalogService) Brands() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Brands")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Brands indicates an expected call of Brands.
+func (mr *MockCatalogServiceMockRecorder) Brands() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Brands", reflect.TypeOf((*MockCatalogService)(nil).Brands))
+}
+
+// CreateBrand mocks base method.
+func (m *MockCatalogService) CreateBrand(brand *model.Brand) (*model.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBrand", brand)
+	ret0, _ := ret[0].(*model.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBrand indicates an expected call of CreateBrand.
+func (mr *MockCatalogServiceMockRecorder) CreateBrand(brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBrand", reflect.TypeOf((*MockCatalogService)(nil).CreateBrand), brand)
+}
+
+// CreateFuelType mocks base method.
+func (m *MockCatalogService) CreateFuelType(fuelType *model.FuelType) (*model.FuelType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFuelType", fuelType)
+	ret0, _ := ret[0].(*model.FuelType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFuelType indicates an expected call of CreateFuelType.
+func (mr *MockCatalogServiceMockRecorder) CreateFuelType(fuelType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFuelType", reflect.TypeOf((*MockCatalogService)(nil).CreateFuelType), fuelType)
+}
+
+// CreateModel mocks base method.
+func (m *MockCatalogService) CreateModel(carModel *model.CarModel) (*model.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModel", carModel)
+	ret0, _ := ret[0].(*model.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateModel indicates an expected call of CreateModel.
+func (mr *MockCatalogServiceMockRecorder) CreateModel(carModel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModel", reflect.TypeOf((*MockCatalogService)(nil).CreateModel), carModel)
+}
+
+// CreateTrim mocks base method.
+func (m *MockCatalogService) CreateTrim(trim *model.Trim) (*model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrim", trim)
+	ret0, _ := ret[0].(*model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrim indicates an expected call of CreateTrim.
+func (mr *MockCatalogServiceMockRecorder) CreateTrim(trim interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrim", reflect.TypeOf((*MockCatalogService)(nil).CreateTrim), trim)
+}
+
+// DeleteBrand mocks base method.
+func (m *MockCatalogService) DeleteBrand(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBrand", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBrand indicates an expected call of DeleteBrand.
+func (mr *MockCatalogServiceMockRecorder) DeleteBrand(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBrand", reflect.TypeOf((*MockCatalogService)(nil).DeleteBrand), name)
+}
+
+// DeleteFuelType mocks base method.
+func (m *MockCatalogService) DeleteFuelType(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFuelType", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFuelType indicates an expected call of DeleteFuelType.
+func (mr *MockCatalogServiceMockRecorder) DeleteFuelType(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFuelType", reflect.TypeOf((*MockCatalogService)(nil).DeleteFuelType), name)
+}
+
+// DeleteModel mocks base method.
+func (m *MockCatalogService) DeleteModel(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModel", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteModel indicates an expected call of DeleteModel.
+func (mr *MockCatalogServiceMockRecorder) DeleteModel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModel", reflect.TypeOf((*MockCatalogService)(nil).DeleteModel), id)
+}
+
+// DeleteTrim mocks base method.
+func (m *MockCatalogService) DeleteTrim(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrim", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrim indicates an expected call of DeleteTrim.
+func (mr *MockCatalogServiceMockRecorder) DeleteTrim(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrim", reflect.TypeOf((*MockCatalogService)(nil).DeleteTrim), id)
+}
+
+// EngineRules mocks base method.
+func (m *MockCatalogService) EngineRules() (map[string]model.EngineRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EngineRules")
+	ret0, _ := ret[0].(map[string]model.EngineRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EngineRules indicates an expected call of EngineRules.
+func (mr *MockCatalogServiceMockRecorder) EngineRules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EngineRules", reflect.TypeOf((*MockCatalogService)(nil).EngineRules))
+}
+
+// FuelTypes mocks base method.
+func (m *MockCatalogService) FuelTypes() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FuelTypes")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FuelTypes indicates an expected call of FuelTypes.
+func (mr *MockCatalogServiceMockRecorder) FuelTypes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FuelTypes", reflect.TypeOf((*MockCatalogService)(nil).FuelTypes))
+}
+
+// GetBrands mocks base method.
+func (m *MockCatalogService) GetBrands() ([]model.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrands")
+	ret0, _ := ret[0].([]model.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrands indicates an expected call of GetBrands.
+func (mr *MockCatalogServiceMockRecorder) GetBrands() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrands", reflect.TypeOf((*MockCatalogService)(nil).GetBrands))
+}
+
+// GetFuelTypes mocks base method.
+func (m *MockCatalogService) GetFuelTypes() ([]model.FuelType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFuelTypes")
+	ret0, _ := ret[0].([]model.FuelType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFuelTypes indicates an expected call of GetFuelTypes.
+func (mr *MockCatalogServiceMockRecorder) GetFuelTypes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFuelTypes", reflect.TypeOf((*MockCatalogService)(nil).GetFuelTypes))
+}
+
+// GetModels mocks base method.
+func (m *MockCatalogService) GetModels(brand string) ([]model.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModels", brand)
+	ret0, _ := ret[0].([]model.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModels indicates an expected call of GetModels.
+func (mr *MockCatalogServiceMockRecorder) GetModels(brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModels", reflect.TypeOf((*MockCatalogService)(nil).GetModels), brand)
+}
+
+// GetTrim mocks base method.
+func (m *MockCatalogService) GetTrim(id string) (*model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrim", id)
+	ret0, _ := ret[0].(*model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrim indicates an expected call of GetTrim.
+func (mr *MockCatalogServiceMockRecorder) GetTrim(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrim", reflect.TypeOf((*MockCatalogService)(nil).GetTrim), id)
+}
+
+// GetTrims mocks base method.
+func (m *MockCatalogService) GetTrims(modelID string) ([]model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrims", modelID)
+	ret0, _ := ret[0].([]model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrims indicates an expected call of GetTrims.
+func (mr *MockCatalogServiceMockRecorder) GetTrims(modelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrims", reflect.TypeOf((*MockCatalogService)(nil).GetTrims), modelID)
+}
+
+// Refresh mocks base method.
+func (m *MockCatalogService) Refresh() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockCatalogServiceMockRecorder) Refresh() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockCatalogService)(nil).Refresh))
+}
+
+// UpdateBrand mocks base method.
+func (m *MockCatalogService) UpdateBrand(name string, brand *model.Brand) (*model.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBrand", name, brand)
+	ret0, _ := ret[0].(*model.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBrand indicates an expected call of UpdateBrand.
+func (mr *MockCatalogServiceMockRecorder) UpdateBrand(name, brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBrand", reflect.TypeOf((*MockCatalogService)(nil).UpdateBrand), name, brand)
+}
+
+// UpdateFuelType mocks base method.
+func (m *MockCatalogService) UpdateFuelType(name string, fuelType *model.FuelType) (*model.FuelType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFuelType", name, fuelType)
+	ret0, _ := ret[0].(*model.FuelType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFuelType indicates an expected call of UpdateFuelType.
+func (mr *MockCatalogServiceMockRecorder) UpdateFuelType(name, fuelType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFuelType", reflect.TypeOf((*MockCatalogService)(nil).UpdateFuelType), name, fuelType)
+}
+
+// UpdateModel mocks base method.
+func (m *MockCatalogService) UpdateModel(carModel *model.CarModel) (*model.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateModel", carModel)
+	ret0, _ := ret[0].(*model.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateModel indicates an expected call of UpdateModel.
+func (mr *MockCatalogServiceMockRecorder) UpdateModel(carModel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateModel", reflect.TypeOf((*MockCatalogService)(nil).UpdateModel), carModel)
+}
+
+// UpdateTrim mocks base method.
+func (m *MockCatalogService) UpdateTrim(trim *model.Trim) (*model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrim", trim)
+	ret0, _ := ret[0].(*model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTrim indicates an expected call of UpdateTrim.
+func (mr *MockCatalogServiceMockRecorder) UpdateTrim(trim interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrim", reflect.TypeOf((*MockCatalogService)(nil).UpdateTrim), trim)
+}
+
+// MockCustomerService is a mock of CustomerService interface.
+type MockCustomerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerServiceMockRecorder
+}
+
+// MockCustomerServiceMockRecorder is the mock recorder for MockCustomerService.
+type MockCustomerServiceMockRecorder struct {
+	mock *MockCustomerService
+}
+
+// NewMockCustomerService creates a new mock instance.
+func NewMockCustomerService(ctrl *gomock.Controller) *MockCustomerService {
+	mock := &MockCustomerService{ctrl: ctrl}
+	mock.recorder = &MockCustomerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerService) EXPECT() *MockCustomerServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomerService) Create(customer *model.Customer) (*model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", customer)
+	ret0, _ := ret[0].(*model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomerServiceMockRecorder) Create(customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerService)(nil).Create), customer)
+}
+
+// Delete mocks base method.
+func (m *MockCustomerService) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomerServiceMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomerService)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockCustomerService) GetAll() ([]model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCustomerServiceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCustomerService)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockCustomerService) GetByID(id string) (*model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCustomerServiceMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCustomerService)(nil).GetByID), id)
+}
+
+// Update mocks base method.
+func (m *MockCustomerService) Update(customer *model.Customer) (*model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", customer)
+	ret0, _ := ret[0].(*model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomerServiceMockRecorder) Update(customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomerService)(nil).Update), customer)
+}
+
+// MockOrderService is a mock of OrderService interface.
+type MockOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceMockRecorder
+}
+
+// MockOrderServiceMockRecorder is the mock recorder for MockOrderService.
+type MockOrderServiceMockRecorder struct {
+	mock *MockOrderService
+}
+
+// NewMockOrderService creates a new mock instance.
+func NewMockOrderService(ctrl *gomock.Controller) *MockOrderService {
+	mock := &MockOrderService{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockOrderService) Cancel(id string) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", id)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockOrderServiceMockRecorder) Cancel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockOrderService)(nil).Cancel), id)
+}
+
+// Complete mocks base method.
+func (m *MockOrderService) Complete(id string) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", id)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockOrderServiceMockRecorder) Complete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockOrderService)(nil).Complete), id)
+}
+
+// Create mocks base method.
+func (m *MockOrderService) Create(order *model.Order) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", order)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderServiceMockRecorder) Create(order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderService)(nil).Create), order)
+}
+
+// GetAll mocks base method.
+func (m *MockOrderService) GetAll(filter model.OrderFilter) ([]model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", filter)
+	ret0, _ := ret[0].([]model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockOrderServiceMockRecorder) GetAll(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockOrderService)(nil).GetAll), filter)
+}
+
+// GetByID mocks base method.
+func (m *MockOrderService) GetByID(id string) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOrderServiceMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrderService)(nil).GetByID), id)
+}
+
+// Update mocks base method.
+func (m *MockOrderService) Update(order *model.Order) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", order)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOrderServiceMockRecorder) Update(order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderService)(nil).Update), order)
+}
+
+// MockBookingService is a mock of BookingService interface.
+type MockBookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingServiceMockRecorder
+}
+
+// MockBookingServiceMockRecorder is the mock recorder for MockBookingService.
+type MockBookingServiceMockRecorder struct {
+	mock *MockBookingService
+}
+
+// NewMockBookingService creates a new mock instance.
+func NewMockBookingService(ctrl *gomock.Controller) *MockBookingService {
+	mock := &MockBookingService{ctrl: ctrl}
+	mock.recorder = &MockBookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingService) EXPECT() *MockBookingServiceMockRecorder {
+	return m.recorder
+}
+
+// Availability mocks base method.
+func (m *MockBookingService) Availability(carID string, slot model.Slot) ([]model.Slot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Availability", carID, slot)
+	ret0, _ := ret[0].([]model.Slot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Availability indicates an expected call of Availability.
+func (mr *MockBookingServiceMockRecorder) Availability(carID, slot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Availability", reflect.TypeOf((*MockBookingService)(nil).Availability), carID, slot)
+}
+
+// Cancel mocks base method.
+func (m *MockBookingService) Cancel(id string) (*model.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", id)
+	ret0, _ := ret[0].(*model.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockBookingServiceMockRecorder) Cancel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockBookingService)(nil).Cancel), id)
+}
+
+// Create mocks base method.
+func (m *MockBookingService) Create(booking *model.Booking) (*model.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", booking)
+	ret0, _ := ret[0].(*model.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookingServiceMockRecorder) Create(booking interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookingService)(nil).Create), booking)
+}
+
+// GetByCar mocks base method.
+func (m *MockBookingService) GetByCar(carID string) ([]model.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCar", carID)
+	ret0, _ := ret[0].([]model.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCar indicates an expected call of GetByCar.
+func (mr *MockBookingServiceMockRecorder) GetByCar(carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCar", reflect.TypeOf((*MockBookingService)(nil).GetByCar), carID)
+}
+
+// GetByID mocks base method.
+func (m *MockBookingService) GetByID(id string) (*model.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBookingServiceMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBookingService)(nil).GetByID), id)
+}
+
+// MockDealershipService is a mock of DealershipService interface.
+type MockDealershipService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDealershipServiceMockRecorder
+}
+
+// MockDealershipServiceMockRecorder is the mock recorder for MockDealershipService.
+type MockDealershipServiceMockRecorder struct {
+	mock *MockDealershipService
+}
+
+// NewMockDealershipService creates a new mock instance.
+func NewMockDealershipService(ctrl *gomock.Controller) *MockDealershipService {
+	mock := &MockDealershipService{ctrl: ctrl}
+	mock.recorder = &MockDealershipServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDealershipService) EXPECT() *MockDealershipServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDealershipService) Create(dealership *model.Dealership) (*model.Dealership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", dealership)
+	ret0, _ := ret[0].(*model.Dealership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDealershipServiceMockRecorder) Create(dealership interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDealershipService)(nil).Create), dealership)
+}
+
+// GetAll mocks base method.
+func (m *MockDealershipService) GetAll() ([]model.Dealership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.Dealership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDealershipServiceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDealershipService)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockDealershipService) GetByID(id string) (*model.Dealership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Dealership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDealershipServiceMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDealershipService)(nil).GetByID), id)
+}
+
+// GetTransfers mocks base method.
+func (m *MockDealershipService) GetTransfers(carID string) ([]model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfers", carID)
+	ret0, _ := ret[0].([]model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfers indicates an expected call of GetTransfers.
+func (mr *MockDealershipServiceMockRecorder) GetTransfers(carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfers", reflect.TypeOf((*MockDealershipService)(nil).GetTransfers), carID)
+}
+
+// Transfer mocks base method.
+func (m *MockDealershipService) Transfer(carID string, transfer *model.Transfer) (*model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", carID, transfer)
+	ret0, _ := ret[0].(*model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockDealershipServiceMockRecorder) Transfer(carID, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockDealershipService)(nil).Transfer), carID, transfer)
+}
+
+// Update mocks base method.
+func (m *MockDealershipService) Update(dealership *model.Dealership) (*model.Dealership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", dealership)
+	ret0, _ := ret[0].(*model.Dealership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDealershipServiceMockRecorder) Update(dealership interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDealershipService)(nil).Update), dealership)
+}
+
+// MockAttachmentService is a mock of AttachmentService interface.
+type MockAttachmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentServiceMockRecorder
+}
+
+// MockAttachmentServiceMockRecorder is the mock recorder for MockAttachmentService.
+type MockAttachmentServiceMockRecorder struct {
+	mock *MockAttachmentService
+}
+
+// NewMockAttachmentService creates a new mock instance.
+func NewMockAttachmentService(ctrl *gomock.Controller) *MockAttachmentService {
+	mock := &MockAttachmentService{ctrl: ctrl}
+	mock.recorder = &MockAttachmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentService) EXPECT() *MockAttachmentServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAttachmentService) Delete(carID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", carID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentServiceMockRecorder) Delete(carID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachmentService)(nil).Delete), carID, id)
+}
+
+// DeleteByCar mocks base method.
+func (m *MockAttachmentService) DeleteByCar(carID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByCar", carID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByCar indicates an expected call of DeleteByCar.
+func (mr *MockAttachmentServiceMockRecorder) DeleteByCar(carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByCar", reflect.TypeOf((*MockAttachmentService)(nil).DeleteByCar), carID)
+}
+
+// GetByCar mocks base method.
+func (m *MockAttachmentService) GetByCar(carID string) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCar", carID)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCar indicates an expected call of GetByCar.
+func (mr *MockAttachmentServiceMockRecorder) GetByCar(carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCar", reflect.TypeOf((*MockAttachmentService)(nil).GetByCar), carID)
+}
+
+// Open mocks base method.
+func (m *MockAttachmentService) Open(carID, id string) (*model.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", carID, id)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockAttachmentServiceMockRecorder) Open(carID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockAttachmentService)(nil).Open), carID, id)
+}
+
+// OpenThumbnail mocks base method.
+func (m *MockAttachmentService) OpenThumbnail(carID, id string) (*model.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenThumbnail", carID, id)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenThumbnail indicates an expected call of OpenThumbnail.
+func (mr *MockAttachmentServiceMockRecorder) OpenThumbnail(carID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenThumbnail", reflect.TypeOf((*MockAttachmentService)(nil).OpenThumbnail), carID, id)
+}
+
+// Upload mocks base method.
+func (m *MockAttachmentService) Upload(attachment *model.Attachment, data []byte) (*model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", attachment, data)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockAttachmentServiceMockRecorder) Upload(attachment, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockAttachmentService)(nil).Upload), attachment, data)
+}
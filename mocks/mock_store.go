@@ -7,6 +7,7 @@ package mocks
 import (
 	model "carAPI/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,18 +36,32 @@ func (m *MockCarStore) EXPECT() *MockCarStoreMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockCarStore) Create(car *model.Car) (*model.Car, error) {
+func (m *MockCarStore) Create(car *model.Car, at time.Time) (*model.Car, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", car)
+	ret := m.ctrl.Call(m, "Create", car, at)
 	ret0, _ := ret[0].(*model.Car)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockCarStoreMockRecorder) Create(car interface{}) *gomock.Call {
+func (mr *MockCarStoreMockRecorder) Create(car, at interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCarStore)(nil).Create), car)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCarStore)(nil).Create), car, at)
+}
+
+// CreateMany mocks base method.
+func (m *MockCarStore) CreateMany(cars []*model.Car, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", cars, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockCarStoreMockRecorder) CreateMany(cars, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockCarStore)(nil).CreateMany), cars, at)
 }
 
 // Delete mocks base method.
@@ -63,19 +78,47 @@ func (mr *MockCarStoreMockRecorder) Delete(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCarStore)(nil).Delete), id)
 }
 
-// GetByBrand mocks base method.
-func (m *MockCarStore) GetByBrand(brand string) ([]model.Car, error) {
+// DeleteMany mocks base method.
+func (m *MockCarStore) DeleteMany(cars []model.Car) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByBrand", brand)
+	ret := m.ctrl.Call(m, "DeleteMany", cars)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockCarStoreMockRecorder) DeleteMany(cars interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockCarStore)(nil).DeleteMany), cars)
+}
+
+// Each mocks base method.
+func (m *MockCarStore) Each(filter model.CarFilter, fn func(*model.Car) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockCarStoreMockRecorder) Each(filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockCarStore)(nil).Each), filter, fn)
+}
+
+// Get mocks base method.
+func (m *MockCarStore) Get(filter model.CarFilter) ([]model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", filter)
 	ret0, _ := ret[0].([]model.Car)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByBrand indicates an expected call of GetByBrand.
-func (mr *MockCarStoreMockRecorder) GetByBrand(brand interface{}) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockCarStoreMockRecorder) Get(filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBrand", reflect.TypeOf((*MockCarStore)(nil).GetByBrand), brand)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCarStore)(nil).Get), filter)
 }
 
 // GetByID mocks base method.
@@ -93,6 +136,169 @@ func (mr *MockCarStoreMockRecorder) GetByID(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCarStore)(nil).GetByID), id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockCarStore) GetByIDs(ids []string) ([]model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].([]model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockCarStoreMockRecorder) GetByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockCarStore)(nil).GetByIDs), ids)
+}
+
+// GetByVIN mocks base method.
+func (m *MockCarStore) GetByVIN(vin string) (*model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVIN", vin)
+	ret0, _ := ret[0].(*model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVIN indicates an expected call of GetByVIN.
+func (mr *MockCarStoreMockRecorder) GetByVIN(vin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVIN", reflect.TypeOf((*MockCarStore)(nil).GetByVIN), vin)
+}
+
+// GetByVINs mocks base method.
+func (m *MockCarStore) GetByVINs(vins []string) ([]model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVINs", vins)
+	ret0, _ := ret[0].([]model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVINs indicates an expected call of GetByVINs.
+func (mr *MockCarStoreMockRecorder) GetByVINs(vins interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVINs", reflect.TypeOf((*MockCarStore)(nil).GetByVINs), vins)
+}
+
+// GetOrderedIDs mocks base method.
+func (m *MockCarStore) GetOrderedIDs(ids []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderedIDs", ids)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderedIDs indicates an expected call of GetOrderedIDs.
+func (mr *MockCarStoreMockRecorder) GetOrderedIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderedIDs", reflect.TypeOf((*MockCarStore)(nil).GetOrderedIDs), ids)
+}
+
+// GetPriceHistory mocks base method.
+func (m *MockCarStore) GetPriceHistory(id string) ([]model.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceHistory", id)
+	ret0, _ := ret[0].([]model.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockCarStoreMockRecorder) GetPriceHistory(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*MockCarStore)(nil).GetPriceHistory), id)
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockCarStore) GetStatusHistory(id string) ([]model.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", id)
+	ret0, _ := ret[0].([]model.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockCarStoreMockRecorder) GetStatusHistory(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockCarStore)(nil).GetStatusHistory), id)
+}
+
+// GetTransfers mocks base method.
+func (m *MockCarStore) GetTransfers(id string) ([]model.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfers", id)
+	ret0, _ := ret[0].([]model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfers indicates an expected call of GetTransfers.
+func (mr *MockCarStoreMockRecorder) GetTransfers(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfers", reflect.TypeOf((*MockCarStore)(nil).GetTransfers), id)
+}
+
+// Page mocks base method.
+func (m *MockCarStore) Page(filter model.CarFilter, limit, offset int) ([]model.Car, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Page", filter, limit, offset)
+	ret0, _ := ret[0].([]model.Car)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Page indicates an expected call of Page.
+func (mr *MockCarStoreMockRecorder) Page(filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Page", reflect.TypeOf((*MockCarStore)(nil).Page), filter, limit, offset)
+}
+
+// SetPrice mocks base method.
+func (m *MockCarStore) SetPrice(id string, change *model.PriceChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrice", id, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrice indicates an expected call of SetPrice.
+func (mr *MockCarStoreMockRecorder) SetPrice(id, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrice", reflect.TypeOf((*MockCarStore)(nil).SetPrice), id, change)
+}
+
+// Transfer mocks base method.
+func (m *MockCarStore) Transfer(id string, transfer *model.Transfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", id, transfer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockCarStoreMockRecorder) Transfer(id, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockCarStore)(nil).Transfer), id, transfer)
+}
+
+// Transition mocks base method.
+func (m *MockCarStore) Transition(id string, change *model.StatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", id, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockCarStoreMockRecorder) Transition(id, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockCarStore)(nil).Transition), id, change)
+}
+
 // Update mocks base method.
 func (m *MockCarStore) Update(car *model.Car) (*model.Car, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +314,20 @@ func (mr *MockCarStoreMockRecorder) Update(car interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCarStore)(nil).Update), car)
 }
 
+// UpdateMany mocks base method.
+func (m *MockCarStore) UpdateMany(cars []*model.Car) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMany", cars)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMany indicates an expected call of UpdateMany.
+func (mr *MockCarStoreMockRecorder) UpdateMany(cars interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMany", reflect.TypeOf((*MockCarStore)(nil).UpdateMany), cars)
+}
+
 // MockEngineStore is a mock of EngineStore interface.
 type MockEngineStore struct {
 	ctrl     *gomock.Controller
@@ -190,6 +410,21 @@ func (mr *MockEngineStoreMockRecorder) GetByID(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockEngineStore)(nil).GetByID), id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockEngineStore) GetByIDs(ids []string) (map[string]model.Engine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].(map[string]model.Engine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockEngineStoreMockRecorder) GetByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockEngineStore)(nil).GetByIDs), ids)
+}
+
 // Update mocks base method.
 func (m *MockEngineStore) Update(engine *model.Engine) (*model.Engine, error) {
 	m.ctrl.T.Helper()
@@ -204,3 +439,846 @@ func (mr *MockEngineStoreMockRecorder) Update(engine interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEngineStore)(nil).Update), engine)
 }
+
+// MockBrandStore is a mock of BrandStore interface.
+type MockBrandStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrandStoreMockRecorder
+}
+
+// MockBrandStoreMockRecorder is the mock recorder for MockBrandStore.
+type MockBrandStoreMockRecorder struct {
+	mock *MockBrandStore
+}
+
+// NewMockBrandStore creates a new mock instance.
+func NewMockBrandStore(ctrl *gomock.Controller) *MockBrandStore {
+	mock := &MockBrandStore{ctrl: ctrl}
+	mock.recorder = &MockBrandStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrandStore) EXPECT() *MockBrandStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBrandStore) Create(brand *model.Brand) (*model.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", brand)
+	ret0, _ := ret[0].(*model.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBrandStoreMockRecorder) Create(brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBrandStore)(nil).Create), brand)
+}
+
+// Delete mocks base method.
+func (m *MockBrandStore) Delete(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBrandStoreMockRecorder) Delete(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBrandStore)(nil).Delete), name)
+}
+
+// GetAll mocks base method.
+func (m *MockBrandStore) GetAll() ([]model.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockBrandStoreMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockBrandStore)(nil).GetAll))
+}
+
+// Update mocks base method.
+func (m *MockBrandStore) Update(name string, brand *model.Brand) (*model.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", name, brand)
+	ret0, _ := ret[0].(*model.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBrandStoreMockRecorder) Update(name, brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBrandStore)(nil).Update), name, brand)
+}
+
+// MockFuelTypeStore is a mock of FuelTypeStore interface.
+type MockFuelTypeStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockFuelTypeStoreMockRecorder
+}
+
+// MockFuelTypeStoreMockRecorder is the mock recorder for MockFuelTypeStore.
+type MockFuelTypeStoreMockRecorder struct {
+	mock *MockFuelTypeStore
+}
+
+// NewMockFuelTypeStore creates a new mock instance.
+func NewMockFuelTypeStore(ctrl *gomock.Controller) *MockFuelTypeStore {
+	mock := &MockFuelTypeStore{ctrl: ctrl}
+	mock.recorder = &MockFuelTypeStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFuelTypeStore) EXPECT() *MockFuelTypeStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFuelTypeStore) Create(fuelType *model.FuelType) (*model.FuelType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", fuelType)
+	ret0, _ := ret[0].(*model.FuelType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFuelTypeStoreMockRecorder) Create(fuelType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFuelTypeStore)(nil).Create), fuelType)
+}
+
+// Delete mocks base method.
+func (m *MockFuelTypeStore) Delete(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFuelTypeStoreMockRecorder) Delete(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFuelTypeStore)(nil).Delete), name)
+}
+
+// GetAll mocks base method.
+func (m *MockFuelTypeStore) GetAll() ([]model.FuelType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.FuelType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockFuelTypeStoreMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockFuelTypeStore)(nil).GetAll))
+}
+
+// Update mocks base method.
+func (m *MockFuelTypeStore) Update(name string, fuelType *model.FuelType) (*model.FuelType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", name, fuelType)
+	ret0, _ := ret[0].(*model.FuelType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFuelTypeStoreMockRecorder) Update(name, fuelType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFuelTypeStore)(nil).Update), name, fuelType)
+}
+
+// MockCarModelStore is a mock of CarModelStore interface.
+type MockCarModelStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarModelStoreMockRecorder
+}
+
+// MockCarModelStoreMockRecorder is the mock recorder for MockCarModelStore.
+type MockCarModelStoreMockRecorder struct {
+	mock *MockCarModelStore
+}
+
+// NewMockCarModelStore creates a new mock instance.
+func NewMockCarModelStore(ctrl *gomock.Controller) *MockCarModelStore {
+	mock := &MockCarModelStore{ctrl: ctrl}
+	mock.recorder = &MockCarModelStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarModelStore) EXPECT() *MockCarModelStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCarModelStore) Create(carModel *model.CarModel) (*model.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", carModel)
+	ret0, _ := ret[0].(*model.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCarModelStoreMockRecorder) Create(carModel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCarModelStore)(nil).Create), carModel)
+}
+
+// Delete mocks base method.
+func (m *MockCarModelStore) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCarModelStoreMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCarModelStore)(nil).Delete), id)
+}
+
+// GetByBrand mocks base method.
+func (m *MockCarModelStore) GetByBrand(brand string) ([]model.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBrand", brand)
+	ret0, _ := ret[0].([]model.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBrand indicates an expected call of GetByBrand.
+func (mr *MockCarModelStoreMockRecorder) GetByBrand(brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBrand", reflect.TypeOf((*MockCarModelStore)(nil).GetByBrand), brand)
+}
+
+// GetByID mocks base method.
+func (m *MockCarModelStore) GetByID(id string) (*model.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCarModelStoreMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCarModelStore)(nil).GetByID), id)
+}
+
+// Update mocks base method.
+func (m *MockCarModelStore) Update(carModel *model.CarModel) (*model.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", carModel)
+	ret0, _ := ret[0].(*model.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCarModelStoreMockRecorder) Update(carModel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCarModelStore)(nil).Update), carModel)
+}
+
+// MockTrimStore is a mock of TrimStore interface.
+type MockTrimStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrimStoreMockRecorder
+}
+
+// MockTrimStoreMockRecorder is the mock recorder for MockTrimStore.
+type MockTrimStoreMockRecorder struct {
+	mock *MockTrimStore
+}
+
+// NewMockTrimStore creates a new mock instance.
+func NewMockTrimStore(ctrl *gomock.Controller) *MockTrimStore {
+	mock := &MockTrimStore{ctrl: ctrl}
+	mock.recorder = &MockTrimStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrimStore) EXPECT() *MockTrimStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTrimStore) Create(trim *model.Trim) (*model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", trim)
+	ret0, _ := ret[0].(*model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTrimStoreMockRecorder) Create(trim interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTrimStore)(nil).Create), trim)
+}
+
+// Delete mocks base method.
+func (m *MockTrimStore) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTrimStoreMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTrimStore)(nil).Delete), id)
+}
+
+// GetByID mocks base method.
+func (m *MockTrimStore) GetByID(id string) (*model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTrimStoreMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTrimStore)(nil).GetByID), id)
+}
+
+// GetByModel mocks base method.
+func (m *MockTrimStore) GetByModel(modelID string) ([]model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByModel", modelID)
+	ret0, _ := ret[0].([]model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByModel indicates an expected call of GetByModel.
+func (mr *MockTrimStoreMockRecorder) GetByModel(modelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByModel", reflect.TypeOf((*MockTrimStore)(nil).GetByModel), modelID)
+}
+
+// Update mocks base method.
+func (m *MockTrimStore) Update(trim *model.Trim) (*model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", trim)
+	ret0, _ := ret[0].(*model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTrimStoreMockRecorder) Update(trim interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTrimStore)(nil).Update), trim)
+}
+
+// MockCustomerStore is a mock of CustomerStore interface.
+type MockCustomerStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerStoreMockRecorder
+}
+
+// MockCustomerStoreMockRecorder is the mock recorder for MockCustomerStore.
+type MockCustomerStoreMockRecorder struct {
+	mock *MockCustomerStore
+}
+
+// NewMockCustomerStore creates a new mock instance.
+func NewMockCustomerStore(ctrl *gomock.Controller) *MockCustomerStore {
+	mock := &MockCustomerStore{ctrl: ctrl}
+	mock.recorder = &MockCustomerStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerStore) EXPECT() *MockCustomerStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomerStore) Create(customer *model.Customer) (*model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", customer)
+	ret0, _ := ret[0].(*model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomerStoreMockRecorder) Create(customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerStore)(nil).Create), customer)
+}
+
+// Delete mocks base method.
+func (m *MockCustomerStore) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomerStoreMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomerStore)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockCustomerStore) GetAll() ([]model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCustomerStoreMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCustomerStore)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockCustomerStore) GetByID(id string) (*model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCustomerStoreMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCustomerStore)(nil).GetByID), id)
+}
+
+// Update mocks base method.
+func (m *MockCustomerStore) Update(customer *model.Customer) (*model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", customer)
+	ret0, _ := ret[0].(*model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomerStoreMockRecorder) Update(customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomerStore)(nil).Update), customer)
+}
+
+// MockOrderStore is a mock of OrderStore interface.
+type MockOrderStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderStoreMockRecorder
+}
+
+// MockOrderStoreMockRecorder is the mock recorder for MockOrderStore.
+type MockOrderStoreMockRecorder struct {
+	mock *MockOrderStore
+}
+
+// NewMockOrderStore creates a new mock instance.
+func NewMockOrderStore(ctrl *gomock.Controller) *MockOrderStore {
+	mock := &MockOrderStore{ctrl: ctrl}
+	mock.recorder = &MockOrderStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderStore) EXPECT() *MockOrderStoreMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockOrderStore) Cancel(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockOrderStoreMockRecorder) Cancel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockOrderStore)(nil).Cancel), id)
+}
+
+// Complete mocks base method.
+func (m *MockOrderStore) Complete(order *model.Order, carStatus model.Status, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", order, carStatus, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockOrderStoreMockRecorder) Complete(order, carStatus, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockOrderStore)(nil).Complete), order, carStatus, at)
+}
+
+// Create mocks base method.
+func (m *MockOrderStore) Create(order *model.Order) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", order)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderStoreMockRecorder) Create(order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderStore)(nil).Create), order)
+}
+
+// Get mocks base method.
+func (m *MockOrderStore) Get(filter model.OrderFilter) ([]model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", filter)
+	ret0, _ := ret[0].([]model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOrderStoreMockRecorder) Get(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrderStore)(nil).Get), filter)
+}
+
+// GetByID mocks base method.
+func (m *MockOrderStore) GetByID(id string) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOrderStoreMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrderStore)(nil).GetByID), id)
+}
+
+// Update mocks base method.
+func (m *MockOrderStore) Update(order *model.Order) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", order)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOrderStoreMockRecorder) Update(order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderStore)(nil).Update), order)
+}
+
+// MockBookingStore is a mock of BookingStore interface.
+type MockBookingStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingStoreMockRecorder
+}
+
+// MockBookingStoreMockRecorder is the mock recorder for MockBookingStore.
+type MockBookingStoreMockRecorder struct {
+	mock *MockBookingStore
+}
+
+// NewMockBookingStore creates a new mock instance.
+func NewMockBookingStore(ctrl *gomock.Controller) *MockBookingStore {
+	mock := &MockBookingStore{ctrl: ctrl}
+	mock.recorder = &MockBookingStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingStore) EXPECT() *MockBookingStoreMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockBookingStore) Cancel(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockBookingStoreMockRecorder) Cancel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockBookingStore)(nil).Cancel), id)
+}
+
+// Create mocks base method.
+func (m *MockBookingStore) Create(booking *model.Booking) (*model.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", booking)
+	ret0, _ := ret[0].(*model.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookingStoreMockRecorder) Create(booking interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookingStore)(nil).Create), booking)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockBookingStore) ExpireHolds(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockBookingStoreMockRecorder) ExpireHolds(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockBookingStore)(nil).ExpireHolds), now)
+}
+
+// GetByCar mocks base method.
+func (m *MockBookingStore) GetByCar(carID string) ([]model.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCar", carID)
+	ret0, _ := ret[0].([]model.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCar indicates an expected call of GetByCar.
+func (mr *MockBookingStoreMockRecorder) GetByCar(carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCar", reflect.TypeOf((*MockBookingStore)(nil).GetByCar), carID)
+}
+
+// GetByID mocks base method.
+func (m *MockBookingStore) GetByID(id string) (*model.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBookingStoreMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBookingStore)(nil).GetByID), id)
+}
+
+// GetOverlapping mocks base method.
+func (m *MockBookingStore) GetOverlapping(carID string, slot model.Slot, now time.Time) ([]model.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverlapping", carID, slot, now)
+	ret0, _ := ret[0].([]model.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverlapping indicates an expected call of GetOverlapping.
+func (mr *MockBookingStoreMockRecorder) GetOverlapping(carID, slot, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverlapping", reflect.TypeOf((*MockBookingStore)(nil).GetOverlapping), carID, slot, now)
+}
+
+// MockDealershipStore is a mock of DealershipStore interface.
+type MockDealershipStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDealershipStoreMockRecorder
+}
+
+// MockDealershipStoreMockRecorder is the mock recorder for MockDealershipStore.
+type MockDealershipStoreMockRecorder struct {
+	mock *MockDealershipStore
+}
+
+// NewMockDealershipStore creates a new mock instance.
+func NewMockDealershipStore(ctrl *gomock.Controller) *MockDealershipStore {
+	mock := &MockDealershipStore{ctrl: ctrl}
+	mock.recorder = &MockDealershipStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDealershipStore) EXPECT() *MockDealershipStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDealershipStore) Create(dealership *model.Dealership) (*model.Dealership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", dealership)
+	ret0, _ := ret[0].(*model.Dealership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDealershipStoreMockRecorder) Create(dealership interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDealershipStore)(nil).Create), dealership)
+}
+
+// GetAll mocks base method.
+func (m *MockDealershipStore) GetAll() ([]model.Dealership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.Dealership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDealershipStoreMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDealershipStore)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockDealershipStore) GetByID(id string) (*model.Dealership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Dealership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDealershipStoreMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDealershipStore)(nil).GetByID), id)
+}
+
+// Update mocks base method.
+func (m *MockDealershipStore) Update(dealership *model.Dealership) (*model.Dealership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", dealership)
+	ret0, _ := ret[0].(*model.Dealership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDealershipStoreMockRecorder) Update(dealership interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDealershipStore)(nil).Update), dealership)
+}
+
+// MockAttachmentStore is a mock of AttachmentStore interface.
+type MockAttachmentStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentStoreMockRecorder
+}
+
+// MockAttachmentStoreMockRecorder is the mock recorder for MockAttachmentStore.
+type MockAttachmentStoreMockRecorder struct {
+	mock *MockAttachmentStore
+}
+
+// NewMockAttachmentStore creates a new mock instance.
+func NewMockAttachmentStore(ctrl *gomock.Controller) *MockAttachmentStore {
+	mock := &MockAttachmentStore{ctrl: ctrl}
+	mock.recorder = &MockAttachmentStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentStore) EXPECT() *MockAttachmentStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachmentStore) Create(attachment *model.Attachment) (*model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", attachment)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentStoreMockRecorder) Create(attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachmentStore)(nil).Create), attachment)
+}
+
+// Delete mocks base method.
+func (m *MockAttachmentStore) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentStoreMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachmentStore)(nil).Delete), id)
+}
+
+// GetByCar mocks base method.
+func (m *MockAttachmentStore) GetByCar(carID string) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCar", carID)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCar indicates an expected call of GetByCar.
+func (mr *MockAttachmentStoreMockRecorder) GetByCar(carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCar", reflect.TypeOf((*MockAttachmentStore)(nil).GetByCar), carID)
+}
+
+// GetByID mocks base method.
+func (m *MockAttachmentStore) GetByID(id string) (*model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAttachmentStoreMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAttachmentStore)(nil).GetByID), id)
+}
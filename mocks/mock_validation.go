@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "carAPI/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCatalog is a mock of Catalog interface.
+type MockCatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogMockRecorder
+}
+
+// MockCatalogMockRecorder is the mock recorder for MockCatalog.
+type MockCatalogMockRecorder struct {
+	mock *MockCatalog
+}
+
+// NewMockCatalog creates a new mock instance.
+func NewMockCatalog(ctrl *gomock.Controller) *MockCatalog {
+	mock := &MockCatalog{ctrl: ctrl}
+	mock.recorder = &MockCatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalog) EXPECT() *MockCatalogMockRecorder {
+	return m.recorder
+}
+
+// Brands mocks base method.
+func (m *MockCatalog) Brands() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Brands")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Brands indicates an expected call of Brands.
+func (mr *MockCatalogMockRecorder) Brands() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Brands", reflect.TypeOf((*MockCatalog)(nil).Brands))
+}
+
+// EngineRules mocks base method.
+func (m *MockCatalog) EngineRules() (map[string]model.EngineRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EngineRules")
+	ret0, _ := ret[0].(map[string]model.EngineRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EngineRules indicates an expected call of EngineRules.
+func (mr *MockCatalogMockRecorder) EngineRules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EngineRules", reflect.TypeOf((*MockCatalog)(nil).EngineRules))
+}
+
+// FuelTypes mocks base method.
+func (m *MockCatalog) FuelTypes() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FuelTypes")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FuelTypes indicates an expected call of FuelTypes.
+func (mr *MockCatalogMockRecorder) FuelTypes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FuelTypes", reflect.TypeOf((*MockCatalog)(nil).FuelTypes))
+}
+
+// GetTrim mocks base method.
+func (m *MockCatalog) GetTrim(id string) (*model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrim", id)
+	ret0, _ := ret[0].(*model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrim indicates an expected call of GetTrim.
+func (mr *MockCatalogMockRecorder) GetTrim(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrim", reflect.TypeOf((*MockCatalog)(nil).GetTrim), id)
+}
@@ -1,10 +1,86 @@
 package model
 
+import "time"
+
 type Engine struct {
 	ID            string `json:"engineId"`
 	Displacement  int    `json:"displacement"`
 	NoOfCylinders int    `json:"noOfCylinders"`
 	Range         int    `json:"range"`
+
+	// BatteryCapacity is the capacity of the traction battery in kWh
+	BatteryCapacity float64 `json:"batteryCapacity,omitempty"`
+
+	// ElectricRange is the range in km on battery alone of a car which also has a combustion engine
+	ElectricRange int `json:"electricRange,omitempty"`
+
+	// TankCapacity is the capacity of the fuel tank, in litres for liquid fuels and in kg for gaseous fuels
+	TankCapacity float64 `json:"tankCapacity,omitempty"`
+
+	NoOfMotors int `json:"noOfMotors,omitempty"`
+
+	// Power is the maximum power in hp
+	Power int `json:"power,omitempty"`
+
+	// Torque is the maximum torque in Nm
+	Torque int `json:"torque,omitempty"`
+
+	Transmission string `json:"transmission,omitempty"`
+	Gears        int    `json:"gears,omitempty"`
+	Drivetrain   string `json:"drivetrain,omitempty"`
+}
+
+// WithDefaults returns e with its zero values replaced by the values of defaults, the ID of e is kept
+func (e Engine) WithDefaults(defaults Engine) Engine {
+	if e.Displacement == 0 {
+		e.Displacement = defaults.Displacement
+	}
+
+	if e.NoOfCylinders == 0 {
+		e.NoOfCylinders = defaults.NoOfCylinders
+	}
+
+	if e.Range == 0 {
+		e.Range = defaults.Range
+	}
+
+	if e.BatteryCapacity == 0 {
+		e.BatteryCapacity = defaults.BatteryCapacity
+	}
+
+	if e.ElectricRange == 0 {
+		e.ElectricRange = defaults.ElectricRange
+	}
+
+	if e.TankCapacity == 0 {
+		e.TankCapacity = defaults.TankCapacity
+	}
+
+	if e.NoOfMotors == 0 {
+		e.NoOfMotors = defaults.NoOfMotors
+	}
+
+	if e.Power == 0 {
+		e.Power = defaults.Power
+	}
+
+	if e.Torque == 0 {
+		e.Torque = defaults.Torque
+	}
+
+	if e.Transmission == "" {
+		e.Transmission = defaults.Transmission
+	}
+
+	if e.Gears == 0 {
+		e.Gears = defaults.Gears
+	}
+
+	if e.Drivetrain == "" {
+		e.Drivetrain = defaults.Drivetrain
+	}
+
+	return e
 }
 
 type Car struct {
@@ -13,7 +89,224 @@ type Car struct {
 	YearOfManufacture int    `json:"yearOfManufacture"`
 	Brand             string `json:"brand"`
 	FuelType          string `json:"fuelType"`
+	TrimID            string `json:"trimId,omitempty"`
 	Engine            Engine `json:"engine"`
+
+	// VIN is the vehicle identification number, it is unique among all cars
+	VIN string `json:"vin,omitempty"`
+
+	// Status is the inventory status of the car, it is only changed through status transitions
+	Status Status `json:"status,omitempty"`
+
+	// Price is the price of the car, it is only changed through price changes
+	Price *Price `json:"price,omitempty"`
+
+	// DealershipID is the dealership the car is stocked at, it is taken from the API key on create
+	// and only changed through transfers
+	DealershipID string `json:"dealershipId,omitempty"`
+}
+
+// Dealership is a showroom, every car is stocked at one dealership
+type Dealership struct {
+	ID   string `json:"dealershipId"`
+	Name string `json:"name"`
+	City string `json:"city,omitempty"`
+}
+
+// Transfer is a move of a car from one dealership to another, Actor identifies the API key which made the transfer
+type Transfer struct {
+	From  string    `json:"from"`
+	To    string    `json:"to"`
+	Actor string    `json:"actor"`
+	At    time.Time `json:"at"`
+}
+
+// Price is an amount in the minor unit of an ISO 4217 currency, e.g. cents of EUR
+type Price struct {
+	Currency string `json:"currency"`
+	List     int64  `json:"list"`
+
+	// Minimum is the lowest price the car may be sold for, it is hidden from viewer keys
+	Minimum int64 `json:"minimum,omitempty"`
+}
+
+// PriceChange is a change of the price of a car, Actor identifies the API key which made the change
+type PriceChange struct {
+	Price
+	Actor  string    `json:"actor"`
+	Reason string    `json:"reason"`
+	At     time.Time `json:"at"`
+}
+
+// Status is the inventory status of a car
+type Status string
+
+// StatusChange is a transition of a car from one status to another, From is empty for the initial status of a car
+type StatusChange struct {
+	From Status    `json:"from,omitempty"`
+	To   Status    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+type Brand struct {
+	Name string `json:"name"`
+}
+
+type FuelType struct {
+	Name string `json:"name"`
+
+	// Engine is the engine rule of cars of the fuel type, it is required on create and kept on update if it is left out
+	Engine *EngineRule `json:"engine,omitempty"`
+}
+
+// EngineRule declares the engine params a car of a fuel type must have and the ones it must not have
+type EngineRule struct {
+	Required  []string `json:"required"`
+	Forbidden []string `json:"forbidden"`
+}
+
+// CarFilter selects the cars to fetch, zero fields do not filter
+type CarFilter struct {
+	Brand        string
+	Status       Status
+	Transmission string
+	Drivetrain   string
+	MinPower     int
+	MaxPower     int
+
+	// MinPrice and MaxPrice bound the list price, in the minor unit of Currency if it is set
+	Currency string
+	MinPrice int64
+	MaxPrice int64
+
+	DealershipID string
+}
+
+// CarModel is a model line of a brand, e.g. Model 3 of Tesla
+type CarModel struct {
+	ID    string `json:"modelId"`
+	Brand string `json:"brand"`
+	Name  string `json:"name"`
+
+	// CarCount is the number of cars in stock of any trim of the model, it is ignored on input
+	CarCount int `json:"carCount"`
+}
+
+// Trim is a variant of a model, e.g. Long Range of Model 3, its engine is the default engine of its cars
+type Trim struct {
+	ID      string `json:"trimId"`
+	ModelID string `json:"modelId"`
+	Name    string `json:"name"`
+	Engine  Engine `json:"engine"`
+
+	// Brand is the brand of the model of the trim, it is ignored on input
+	Brand string `json:"brand"`
+
+	// CarCount is the number of cars in stock of the trim, it is ignored on input
+	CarCount int `json:"carCount"`
+}
+
+// Customer is a buyer of cars, the email of a customer is unique
+type Customer struct {
+	ID    string `json:"customerId"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// Order is the sale of a car to a customer, amounts are in the minor unit of Currency
+type Order struct {
+	ID          string `json:"orderId"`
+	CustomerID  string `json:"customerId"`
+	CarID       string `json:"carId"`
+	Currency    string `json:"currency"`
+	AgreedPrice int64  `json:"agreedPrice"`
+	Discount    int64  `json:"discount"`
+	Tax         int64  `json:"tax"`
+
+	// Total is the amount the customer pays, the agreed price minus the discount plus the tax, it is ignored on input
+	Total int64 `json:"total"`
+
+	PaymentStatus PaymentStatus `json:"paymentStatus"`
+
+	// Status is only changed by completing or cancelling the order, it is ignored on input
+	Status OrderStatus `json:"status"`
+
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// OrderStatus is the status of an order, orders are open until they are completed or cancelled
+type OrderStatus string
+
+// PaymentStatus is the status of the payment of an order
+type PaymentStatus string
+
+// OrderFilter selects the orders to fetch, zero fields do not filter
+type OrderFilter struct {
+	CustomerID string
+	CarID      string
+	Status     OrderStatus
+
+	// DealershipID keeps the orders of the cars stocked at the dealership
+	DealershipID string
+}
+
+// Booking reserves a car for a time slot, either for a test drive or to hold the car for a customer
+type Booking struct {
+	ID         string      `json:"bookingId"`
+	CarID      string      `json:"carId"`
+	CustomerID string      `json:"customerId,omitempty"`
+	Kind       BookingKind `json:"kind"`
+	Start      time.Time   `json:"start"`
+	End        time.Time   `json:"end"`
+
+	// Status is only changed by cancelling or expiring the booking, it is ignored on input
+	Status BookingStatus `json:"status"`
+
+	// ExpiresAt is the time a hold is released unless it ends before, it is ignored on input
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// BookingKind is the purpose of a booking
+type BookingKind string
+
+// BookingStatus is the status of a booking, only active bookings occupy their time slot
+type BookingStatus string
+
+// Slot is a time range, Start is included and End is not
+type Slot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Attachment is a photo or document of a car, its content is kept in the blob storage
+type Attachment struct {
+	ID          string         `json:"attachmentId"`
+	CarID       string         `json:"carId"`
+	Kind        AttachmentKind `json:"kind"`
+	FileName    string         `json:"fileName"`
+	ContentType string         `json:"contentType"`
+	Size        int64          `json:"size"`
+
+	// HasThumbnail is set for photos, their thumbnail is generated on upload
+	HasThumbnail bool `json:"hasThumbnail"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AttachmentKind tells photos from documents, it follows from the content type of an attachment
+type AttachmentKind string
+
+// Role is the role granted to an API key
+type Role string
+
+// APIKey is what an API key grants, a role on the cars of a dealership
+type APIKey struct {
+	Role         Role
+	DealershipID string
 }
 
 const (
@@ -22,16 +315,156 @@ const (
 	ParamBrand             = "brand"
 	ParamFuelType          = "fuelType"
 	ParamRange             = "range"
+	ParamEngine            = "engine"
 	ParamDisplacement      = "displacement"
 	ParamNoOfCylinders     = "noOfCylinders"
+	ParamBatteryCapacity   = "batteryCapacity"
+	ParamElectricRange     = "electricRange"
+	ParamTankCapacity      = "tankCapacity"
+	ParamNoOfMotors        = "noOfMotors"
+	ParamPower             = "power"
+	ParamTorque            = "torque"
+	ParamTransmission      = "transmission"
+	ParamGears             = "gears"
+	ParamDrivetrain        = "drivetrain"
+	ParamMinPower          = "minPower"
+	ParamMaxPower          = "maxPower"
+	ParamTrimID            = "trimId"
+	ParamModelID           = "modelId"
+	ParamVIN               = "vin"
+	ParamStatus            = "status"
+	ParamPrice             = "price"
+	ParamCurrency          = "currency"
+	ParamListPrice         = "list"
+	ParamMinimumPrice      = "minimum"
+	ParamReason            = "reason"
+	ParamMinPrice          = "minPrice"
+	ParamMaxPrice          = "maxPrice"
+	ParamEmail             = "email"
+	ParamPhone             = "phone"
+	ParamCustomerID        = "customerId"
+	ParamCarID             = "carId"
+	ParamAgreedPrice       = "agreedPrice"
+	ParamDiscount          = "discount"
+	ParamTax               = "tax"
+	ParamPaymentStatus     = "paymentStatus"
+	ParamKind              = "kind"
+	ParamStart             = "start"
+	ParamEnd               = "end"
+	ParamFrom              = "from"
+	ParamTo                = "to"
+	ParamDealershipID      = "dealershipId"
+	ParamCity              = "city"
+	ParamFile              = "file"
+	ParamMode              = "mode"
+	ParamDryRun            = "dryRun"
+	ParamFormat            = "format"
+
+	// DefaultDealershipID is the dealership of the cars created before dealerships were introduced,
+	// and of API keys which are not assigned to a dealership
+	DefaultDealershipID = "00000000-0000-0000-0000-000000000001"
 
 	MinYear = 1866
 
-	ValueElectric = "Electric"
-	ValuePetrol   = "Petrol"
-	ValueDiesel   = "Diesel"
-	ValueTesla    = "Tesla"
-	ValueFerrari  = "Ferrari"
-	ValueBMW      = "BMW"
-	ValuePorsche  = "Porsche"
+	// MaxCatalogNameLength is the maximum length of the name of a brand, fuel type, model or trim
+	MaxCatalogNameLength = 50
+
+	// fuel types with engine requirements, other fuel types are only known from the catalog
+	ValueElectric     = "Electric"
+	ValuePetrol       = "Petrol"
+	ValueDiesel       = "Diesel"
+	ValueHybrid       = "Hybrid"
+	ValuePlugInHybrid = "Plug-in Hybrid"
+	ValueCNG          = "CNG"
+	ValueHydrogen     = "Hydrogen"
+
+	// MaxReasonLength is the maximum length of the reason of a price change
+	MaxReasonLength = 255
+
+	// MaxCustomerNameLength and MaxPhoneLength are the maximum lengths of the fields of a customer
+	MaxCustomerNameLength = 100
+	MaxPhoneLength        = 20
+
+	// MaxDealershipFieldLength is the maximum length of the name and city of a dealership
+	MaxDealershipFieldLength = 50
+
+	// MaxPhotoSize and MaxDocumentSize are the maximum sizes of attachments in bytes
+	MaxPhotoSize    = 10 << 20
+	MaxDocumentSize = 20 << 20
+
+	// MaxFileNameLength is the maximum length of the file name of an attachment, longer names are cut
+	MaxFileNameLength = 255
+
+	// ThumbnailSize is the maximum width and height of thumbnails in pixels
+	ThumbnailSize = 256
+
+	// MaxPhotoPixels is the maximum number of pixels of photos, width times height
+	MaxPhotoPixels = 50000000
+
+	// BulkModeAtomic and BulkModeBestEffort are the modes of bulk requests, atomic requests change nothing if any item fails
+	// while best-effort requests change every item which does not fail
+	BulkModeAtomic     = "atomic"
+	BulkModeBestEffort = "best-effort"
+
+	// MaxBulkItems is the maximum number of items of a bulk request
+	MaxBulkItems = 500
+
+	// MaxBodySize is the maximum size in bytes of the body of a request, bulk requests and attachments have their own limits
+	MaxBodySize = 64 << 10
+
+	// MaxBulkBodySize is the maximum size in bytes of the body of a bulk request, 4KB for each of its items
+	MaxBulkBodySize = MaxBulkItems * (4 << 10)
+
+	// ExportFormatCSV, ExportFormatNDJSON and ExportFormatXLSX are the formats cars are exported in
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatXLSX   = "xlsx"
+
+	// MaxAvailabilityDays is the maximum number of days availability is requested for at once
+	MaxAvailabilityDays = 31
+
+	// DateLayout is the layout of dates in query params
+	DateLayout = "2006-01-02"
+
+	// maximum values of the engine specs, they are optional and only checked when present
+	MaxPower  = 2000
+	MaxTorque = 3000
+	MaxGears  = 10
+
+	TransmissionManual    = "Manual"
+	TransmissionAutomatic = "Automatic"
+	TransmissionDCT       = "DCT"
+	TransmissionCVT       = "CVT"
+
+	DrivetrainFWD = "FWD"
+	DrivetrainRWD = "RWD"
+	DrivetrainAWD = "AWD"
+
+	StatusInTransit Status = "in-transit"
+	StatusOnLot     Status = "on-lot"
+	StatusReserved  Status = "reserved"
+	StatusSold      Status = "sold"
+	StatusReturned  Status = "returned"
+
+	OrderOpen      OrderStatus = "open"
+	OrderCompleted OrderStatus = "completed"
+	OrderCancelled OrderStatus = "cancelled"
+
+	PaymentPending  PaymentStatus = "pending"
+	PaymentDeposit  PaymentStatus = "deposit-paid"
+	PaymentPaid     PaymentStatus = "paid"
+	PaymentRefunded PaymentStatus = "refunded"
+
+	BookingTestDrive BookingKind = "test-drive"
+	BookingHold      BookingKind = "hold"
+
+	BookingActive    BookingStatus = "active"
+	BookingCancelled BookingStatus = "cancelled"
+	BookingExpired   BookingStatus = "expired"
+
+	AttachmentPhoto    AttachmentKind = "photo"
+	AttachmentDocument AttachmentKind = "document"
+
+	RoleAdmin  Role = "admin"
+	RoleViewer Role = "viewer"
 )
@@ -0,0 +1,43 @@
+// Package openapi serves the OpenAPI document describing the API, and a Swagger UI page to browse it.
+// The Swagger UI assets are vendored into swagger-ui and embedded, so the page loads nothing from other origins.
+package openapi
+
+import (
+	"embed"
+	"net/http"
+)
+
+//go:generate sh swagger-ui.sh
+
+//go:embed openapi.json
+var document []byte
+
+//go:embed swagger.html
+var page []byte
+
+//go:embed swagger-ui
+var assets embed.FS
+
+// Assets serves the Swagger UI assets the page of UI loads from /docs/swagger-ui/
+var Assets = http.StripPrefix("/docs/", http.FileServer(http.FS(assets)))
+
+// Document returns the OpenAPI document
+func Document() []byte {
+	return document
+}
+
+// Spec serves the OpenAPI document
+func Spec(w http.ResponseWriter, _ *http.Request) {
+	write(w, "application/json", document)
+}
+
+// UI serves the Swagger UI page, which renders the document served by Spec
+func UI(w http.ResponseWriter, _ *http.Request) {
+	write(w, "text/html; charset=utf-8", page)
+}
+
+func write(w http.ResponseWriter, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUI(t *testing.T) {
+	w := httptest.NewRecorder()
+	UI(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the page loads Swagger UI from the API, so that it works offline and behind a strict content security policy
+	assert.NotContains(t, w.Body.String(), "https://")
+	assert.Contains(t, w.Body.String(), `src="/docs/swagger-ui/swagger-ui-bundle.js"`)
+}
+
+func TestAssets(t *testing.T) {
+	tests := []struct {
+		desc       string
+		path       string
+		statusCode int
+	}{
+		{"Embedded asset", "/docs/swagger-ui/init.js", http.StatusOK},
+		{"Unknown asset", "/docs/swagger-ui/unknown.js", http.StatusNotFound},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+		Assets.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+		assert.Equalf(t, tc.statusCode, w.Code, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
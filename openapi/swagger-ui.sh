@@ -0,0 +1,15 @@
+#!/bin/sh
+# vendors the swagger-ui-dist release named in swagger-ui/VERSION into swagger-ui, which is embedded into the binary
+set -eu
+
+cd "$(dirname "$0")"
+
+version=$(cat swagger-ui/VERSION)
+tmp=$(mktemp -d)
+trap 'rm -rf "$tmp"' EXIT
+
+curl -fsSL "https://registry.npmjs.org/swagger-ui-dist/-/swagger-ui-dist-$version.tgz" | tar -xz -C "$tmp"
+
+for f in swagger-ui.css swagger-ui-bundle.js LICENSE; do
+	cp "$tmp/package/$f" "swagger-ui/$f"
+done
@@ -0,0 +1,2 @@
+// initializes Swagger UI with the document served by the API, a separate file so that the page needs no inline script
+window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
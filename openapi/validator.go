@@ -0,0 +1,281 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/validation"
+)
+
+// the schema rules request bodies are validated by
+const (
+	ruleType                 = "type"
+	ruleEnum                 = "enum"
+	ruleRequired             = "required"
+	ruleAdditionalProperties = "additionalProperties"
+)
+
+// the catalog lists the x-catalog extension of a schema refers to
+const (
+	catalogBrands    = "brands"
+	catalogFuelTypes = "fuelTypes"
+)
+
+// schema is the subset of a JSON schema request bodies are validated against
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Enum                 []string           `json:"enum"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+
+	// Catalog names the catalog list whose names are the values of the schema, brands or fuelTypes
+	Catalog string `json:"x-catalog"`
+}
+
+// Validator validates request bodies against the schemas of the OpenAPI document
+type Validator struct {
+	catalog validation.Catalog
+
+	// bodies maps the method and path template of every validated operation to the schema of its JSON body
+	bodies  map[string]*schema
+	schemas map[string]*schema
+}
+
+// NewValidator returns a validator of the JSON bodies of the operations whose path starts with one of prefixes,
+// the values of x-catalog schemas are validated against the catalog c
+func NewValidator(c validation.Catalog, prefixes ...string) (*Validator, error) {
+	var doc struct {
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Content map[string]struct {
+					Schema *schema `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]*schema `json:"schemas"`
+		} `json:"components"`
+	}
+
+	err := json.Unmarshal(document, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Validator{catalog: c, bodies: make(map[string]*schema), schemas: doc.Components.Schemas}
+
+	for path, operations := range doc.Paths {
+		if !hasPrefix(path, prefixes) {
+			continue
+		}
+
+		for method, op := range operations {
+			if content, ok := op.RequestBody.Content["application/json"]; ok {
+				v.bodies[strings.ToUpper(method)+" "+path] = content.Schema
+			}
+		}
+	}
+
+	return v, nil
+}
+
+func hasPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validates reports whether the bodies of the operation with given method and path template are validated
+func (v *Validator) Validates(method, path string) bool {
+	_, ok := v.bodies[method+" "+path]
+	return ok
+}
+
+// Validate validates body, decoded into maps, slices, strings, float64s, bools and nils, against the schema of the operation
+// with given method and path template. Members are not required in PATCH bodies, which only change the members they have,
+// and may be null there to remove them
+func (v *Validator) Validate(method, path string, body interface{}) customErrors.InvalidFields {
+	s, ok := v.bodies[method+" "+path]
+	if !ok {
+		return nil
+	}
+
+	c := check{Validator: v, patch: method == "PATCH"}
+	c.value(s, body, "")
+
+	return c.errs
+}
+
+// check collects the violations of a single body
+type check struct {
+	*Validator
+
+	patch bool
+	errs  customErrors.InvalidFields
+}
+
+// value validates value at the JSON pointer path against s
+func (c *check) value(s *schema, value interface{}, path string) {
+	s = c.resolve(s)
+
+	got := typeOf(value)
+	if s.Type != "" && s.Type != got && (s.Type != "number" || got != "integer") {
+		c.fail(path, customErrors.FieldInvalid, ruleType, fmt.Sprintf("%v must be %v", name(path), article(s.Type)), nil)
+		return
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		c.object(s, value, path)
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range value {
+				c.value(s.Items, item, fmt.Sprintf("%v/%v", path, i))
+			}
+		}
+	case string:
+		c.enum(s, value, path)
+	}
+}
+
+// object validates the members of obj against the properties of s
+func (c *check) object(s *schema, obj map[string]interface{}, path string) {
+	if !c.patch {
+		for _, member := range s.Required {
+			if _, ok := obj[member]; !ok {
+				c.fail(path+"/"+member, customErrors.FieldRequired, ruleRequired, member+" is required", nil)
+			}
+		}
+	}
+
+	// members are validated in order, so that the violations are listed in the same order every time
+	members := make([]string, 0, len(obj))
+	for member := range obj {
+		members = append(members, member)
+	}
+
+	sort.Strings(members)
+
+	for _, member := range members {
+		p, ok := s.Properties[member]
+		if ok {
+			if !c.patch || obj[member] != nil {
+				c.value(p, obj[member], path+"/"+member)
+			}
+
+			continue
+		}
+
+		if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			c.fail(path+"/"+member, customErrors.FieldForbidden, ruleAdditionalProperties, member+" is not a known member", nil)
+		}
+	}
+}
+
+// enum validates that value is one of the values of s, if s restricts them
+func (c *check) enum(s *schema, value, path string) {
+	allowed := s.Enum
+
+	if s.Catalog != "" {
+		names, err := c.catalogNames(s.Catalog)
+		if err != nil {
+			// the handler reports the catalog error when it validates the body
+			return
+		}
+
+		allowed = names
+	}
+
+	if allowed == nil {
+		return
+	}
+
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+
+	c.fail(path, customErrors.FieldInvalid, ruleEnum, name(path)+" must be one of the allowed values", allowed)
+}
+
+// catalogNames returns the names of the catalog list with given name
+func (c *check) catalogNames(list string) ([]string, error) {
+	switch list {
+	case catalogBrands:
+		return c.catalog.Brands()
+	case catalogFuelTypes:
+		return c.catalog.FuelTypes()
+	}
+
+	return nil, fmt.Errorf("unknown catalog list %v", list)
+}
+
+// resolve follows the $ref of s to the schema it refers to
+func (c *check) resolve(s *schema) *schema {
+	for s.Ref != "" {
+		s = c.schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+
+	return s
+}
+
+func (c *check) fail(path, code, rule, message string, allowed []string) {
+	c.errs = append(c.errs, customErrors.FieldError{Path: path, Code: code, Message: message, Allowed: allowed, Rule: rule})
+}
+
+// typeOf returns the JSON schema type of a decoded JSON value, numbers without a fraction are integers
+func typeOf(value interface{}) string {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+
+		return "number"
+	}
+
+	return "null"
+}
+
+// name returns the name of the value at the JSON pointer path in messages, items of arrays are named by their index
+func name(path string) string {
+	if path == "" {
+		return "the body"
+	}
+
+	if _, err := strconv.Atoi(path[strings.LastIndex(path, "/")+1:]); err == nil {
+		return "item " + strings.TrimPrefix(path, "/")
+	}
+
+	return strings.TrimPrefix(path, "/")
+}
+
+// article returns the JSON schema type t with its indefinite article
+func article(t string) string {
+	if t == "integer" || t == "object" || t == "array" {
+		return "an " + t
+	}
+
+	return "a " + t
+}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCatalog(mockCtrl)
+
+	c.EXPECT().Brands().Return([]string{"Tesla", "BMW"}, nil).AnyTimes()
+	c.EXPECT().FuelTypes().Return([]string{"Electric", "Petrol"}, nil).AnyTimes()
+
+	v, err := NewValidator(c, "/v1/car", "/v2/car")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc   string
+		method string
+		path   string
+		body   string
+		errs   customErrors.InvalidFields
+	}{
+		{
+			"Valid car",
+			"POST", "/v1/car",
+			`{"name":"Model 3","yearOfManufacture":2020,"brand":"Tesla","fuelType":"Electric","engine":{"range":500},"status":"on-lot"}`,
+			nil,
+		},
+		{
+			"Type of a member",
+			"POST", "/v1/car",
+			`{"name":"Model 3","yearOfManufacture":"2000","brand":"Tesla","fuelType":"Electric"}`,
+			customErrors.InvalidFields{
+				{Path: "/yearOfManufacture", Code: customErrors.FieldInvalid, Message: "yearOfManufacture must be an integer", Rule: "type"},
+			},
+		},
+		{
+			"Required members",
+			"PUT", "/v1/car/{id}",
+			`{"yearOfManufacture":2000}`,
+			customErrors.InvalidFields{
+				{Path: "/name", Code: customErrors.FieldRequired, Message: "name is required", Rule: "required"},
+				{Path: "/brand", Code: customErrors.FieldRequired, Message: "brand is required", Rule: "required"},
+				{Path: "/fuelType", Code: customErrors.FieldRequired, Message: "fuelType is required", Rule: "required"},
+			},
+		},
+		{
+			"Members of a patch are not required",
+			"PATCH", "/v1/car/{id}",
+			`{"yearOfManufacture":2000}`,
+			nil,
+		},
+		{
+			"Null members of a patch remove them",
+			"PATCH", "/v1/car/{id}",
+			`{"vin":null,"engine":{"power":null}}`,
+			nil,
+		},
+		{
+			"Catalog and schema enums",
+			"PATCH", "/v1/car/{id}",
+			`{"brand":"Fiat","fuelType":"Petrol","engine":{"drivetrain":"4WD"}}`,
+			customErrors.InvalidFields{
+				{Path: "/brand", Code: customErrors.FieldInvalid, Message: "brand must be one of the allowed values",
+					Allowed: []string{"Tesla", "BMW"}, Rule: "enum"},
+				{Path: "/engine/drivetrain", Code: customErrors.FieldInvalid, Message: "engine/drivetrain must be one of the allowed values",
+					Allowed: []string{"FWD", "RWD", "AWD"}, Rule: "enum"},
+			},
+		},
+		{
+			"Unknown members",
+			"PATCH", "/v1/car/{id}",
+			`{"colour":"red","engine":{"power":300.5,"turbo":true}}`,
+			customErrors.InvalidFields{
+				{Path: "/colour", Code: customErrors.FieldForbidden, Message: "colour is not a known member", Rule: "additionalProperties"},
+				{Path: "/engine/power", Code: customErrors.FieldInvalid, Message: "engine/power must be an integer", Rule: "type"},
+				{Path: "/engine/turbo", Code: customErrors.FieldForbidden, Message: "turbo is not a known member", Rule: "additionalProperties"},
+			},
+		},
+		{
+			"Items of a bulk request",
+			"DELETE", "/v1/car/bulk",
+			`["a4f5b2b4-4c1e-4a8f-9d0b-000000000001", 7]`,
+			customErrors.InvalidFields{
+				{Path: "/1", Code: customErrors.FieldInvalid, Message: "item 1 must be a string", Rule: "type"},
+			},
+		},
+		{
+			"Body of another type",
+			"POST", "/v1/car/{id}/transition",
+			`null`,
+			customErrors.InvalidFields{
+				{Path: "", Code: customErrors.FieldInvalid, Message: "the body must be an object", Rule: "type"},
+			},
+		},
+		{
+			"Version 2 car",
+			"PUT", "/v2/car/{id}",
+			`{"name":"Model 3","yearOfManufacture":2020,"brand":"Tesla","fuelType":"Electric",` +
+				`"vin":"XP7YGCEL0YB000001","price":{"list":{"amount":100}}}`,
+			customErrors.InvalidFields{
+				{Path: "/price/list/currency", Code: customErrors.FieldRequired, Message: "currency is required", Rule: "required"},
+				{Path: "/vin", Code: customErrors.FieldInvalid, Message: "vin must be an object", Rule: "type"},
+			},
+		},
+		{
+			"Operation without a validated body",
+			"POST", "/v1/brands",
+			`{"name":5}`,
+			nil,
+		},
+	}
+
+	for i, tc := range tests {
+		var body interface{}
+
+		err := json.Unmarshal([]byte(tc.body), &body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		errs := v.Validate(tc.method, tc.path, body)
+
+		assert.Equalf(t, tc.errs, errs, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestValidator_CatalogErr(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCatalog(mockCtrl)
+
+	c.EXPECT().Brands().Return(nil, errors.New("connection refused"))
+
+	v, err := NewValidator(c, "/v1/car", "/v2/car")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the brand is left to the handler, which reports the catalog error
+	errs := v.Validate("PATCH", "/v1/car/{id}", map[string]interface{}{"brand": "Fiat"})
+
+	assert.Nil(t, errs)
+}
@@ -0,0 +1,68 @@
+package problem
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	customErrors "carAPI/custom-errors"
+)
+
+// ContentType is the media type of problem details responses
+const ContentType = "application/problem+json"
+
+// typeBaseURI prefixes the code of a problem to build its type URI
+const typeBaseURI = "/problems/"
+
+// Details is an RFC 7807 problem details object.
+// Besides the standard members it carries the error Code and a few extension
+// members which are only set for the problems they apply to.
+type Details struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Code     customErrors.Code `json:"code"`
+
+	// ID is the id of the entity the problem is about
+	ID string `json:"id,omitempty"`
+
+	// Param is the name of the request parameter the problem is about
+	Param string `json:"param,omitempty"`
+
+	// Errors lists the invalid fields of the request body
+	Errors []customErrors.FieldError `json:"errors,omitempty"`
+}
+
+// New returns the problem details for code, with status and title taken from the code registry
+func New(code customErrors.Code, detail string) *Details {
+	return &Details{
+		Type:   typeBaseURI + string(code),
+		Title:  code.Title(),
+		Status: code.Status(),
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// Write renders d as the response to r
+func (d *Details) Write(w http.ResponseWriter, r *http.Request) {
+	if d.Instance == "" {
+		d.Instance = r.URL.Path
+	}
+
+	body, err := json.Marshal(d)
+	if err != nil {
+		log.Println(err)
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(d.Status)
+	_, _ = w.Write(body)
+}
+
+// Write renders the problem identified by code as the response to r
+func Write(w http.ResponseWriter, r *http.Request, code customErrors.Code, detail string) {
+	New(code, detail).Write(w, r)
+}
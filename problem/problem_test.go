@@ -0,0 +1,71 @@
+package problem
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nsf/jsondiff"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+)
+
+func TestDetails_Write(t *testing.T) {
+	invalid := New(customErrors.CodeValidation, "")
+	invalid.Errors = customErrors.InvalidFields{
+		{Path: "/name", Code: customErrors.FieldRequired, Message: "name is required"},
+		{Path: "/brand", Code: customErrors.FieldInvalid, Message: "Pesla is not a valid brand", Allowed: []string{"Tesla", "BMW"}},
+	}
+
+	notFound := New(customErrors.CodeEntityNotFound, "Car not exists")
+	notFound.ID = `"quoted"`
+
+	tests := []struct {
+		desc       string
+		problem    *Details
+		statusCode int
+		resp       []byte
+	}{
+		{
+			"Field errors as array",
+			invalid,
+			http.StatusUnprocessableEntity,
+			[]byte(`{"type":"/problems/validation-failed","title":"Request body has invalid field(s)","status":422,
+							"instance":"/car/1","code":"validation-failed","errors":[
+								{"path":"/name","code":"required","message":"name is required"},
+								{"path":"/brand","code":"invalid-value","message":"Pesla is not a valid brand","allowed":["Tesla","BMW"]}]}`),
+		},
+		{
+			"ID is escaped",
+			notFound,
+			http.StatusNotFound,
+			[]byte(`{"type":"/problems/entity-not-found","title":"Entity not found","status":404,"detail":"Car not exists",
+							"instance":"/car/1","code":"entity-not-found","id":"\"quoted\""}`),
+		},
+	}
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/car/1?withEngine=true", nil)
+		w := httptest.NewRecorder()
+
+		tc.problem.Write(w, r)
+
+		result := w.Result()
+		body, _ := io.ReadAll(result.Body)
+
+		result.Body.Close()
+
+		assert.Equalf(t, tc.statusCode, result.StatusCode, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, ContentType, result.Header.Get("Content-Type"), "Testcase[%v] (%v)", i, tc.desc)
+
+		options := jsondiff.DefaultConsoleOptions()
+		diff, _ := jsondiff.Compare(tc.resp, body, &options)
+
+		if diff != jsondiff.FullMatch {
+			t.Errorf("Testcase[%v] failed (%v)\nExpected:\n%v\nGot:\n%v", i, tc.desc, string(tc.resp), string(body))
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/middleware"
+	"carAPI/model"
+)
+
+// keyMetadata is the metadata key of the API key, the counterpart of the x-api-key header
+const keyMetadata = "x-api-key"
+
+// authenticate returns ctx with the API key of the metadata of ctx, keys maps every valid API key to the role
+// and dealership it grants
+func authenticate(ctx context.Context, keys map[string]model.APIKey) (context.Context, error) {
+	var key string
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(keyMetadata); len(values) > 0 {
+		key = values[0]
+	}
+
+	ctx, ok := middleware.Authenticate(ctx, keys, key)
+	if !ok {
+		return nil, newStatus(customErrors.CodeUnauthorized, "", "")
+	}
+
+	return ctx, nil
+}
+
+func unaryAuth(keys map[string]model.APIKey) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func streamAuth(keys map[string]model.APIKey) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), keys)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, authenticated{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticated is a server stream whose context holds the API key of the stream
+type authenticated struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s authenticated) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+
+	"carAPI/carpb"
+	"carAPI/middleware"
+	"carAPI/model"
+)
+
+// newCar returns the message of car, its minimum price is left out unless the API key is an admin key
+func newCar(ctx context.Context, car *model.Car) *carpb.Car {
+	c := &carpb.Car{
+		Id:                car.ID,
+		Name:              car.Name,
+		YearOfManufacture: int32(car.YearOfManufacture),
+		Brand:             car.Brand,
+		FuelType:          car.FuelType,
+		TrimId:            car.TrimID,
+		Engine:            newEngine(&car.Engine),
+		Vin:               car.VIN,
+		Status:            string(car.Status),
+		DealershipId:      car.DealershipID,
+	}
+
+	if car.Price != nil {
+		c.Price = &carpb.Price{Currency: car.Price.Currency, List: car.Price.List}
+
+		if middleware.Role(ctx) == model.RoleAdmin {
+			c.Price.Minimum = car.Price.Minimum
+		}
+	}
+
+	return c
+}
+
+func newEngine(e *model.Engine) *carpb.Engine {
+	return &carpb.Engine{
+		Id:              e.ID,
+		Displacement:    int32(e.Displacement),
+		NoOfCylinders:   int32(e.NoOfCylinders),
+		Range:           int32(e.Range),
+		BatteryCapacity: e.BatteryCapacity,
+		ElectricRange:   int32(e.ElectricRange),
+		TankCapacity:    e.TankCapacity,
+		NoOfMotors:      int32(e.NoOfMotors),
+		Power:           int32(e.Power),
+		Torque:          int32(e.Torque),
+		Transmission:    e.Transmission,
+		Gears:           int32(e.Gears),
+		Drivetrain:      e.Drivetrain,
+	}
+}
+
+// readCar returns the car of the message c, its ID, price and dealership are ignored like in request bodies
+// of the REST API
+func readCar(c *carpb.Car) model.Car {
+	e := c.GetEngine()
+
+	return model.Car{
+		Name:              c.GetName(),
+		YearOfManufacture: int(c.GetYearOfManufacture()),
+		Brand:             c.GetBrand(),
+		FuelType:          c.GetFuelType(),
+		TrimID:            c.GetTrimId(),
+		VIN:               c.GetVin(),
+		Status:            model.Status(c.GetStatus()),
+		Engine: model.Engine{
+			Displacement:    int(e.GetDisplacement()),
+			NoOfCylinders:   int(e.GetNoOfCylinders()),
+			Range:           int(e.GetRange()),
+			BatteryCapacity: e.GetBatteryCapacity(),
+			ElectricRange:   int(e.GetElectricRange()),
+			TankCapacity:    e.GetTankCapacity(),
+			NoOfMotors:      int(e.GetNoOfMotors()),
+			Power:           int(e.GetPower()),
+			Torque:          int(e.GetTorque()),
+			Transmission:    e.GetTransmission(),
+			Gears:           int(e.GetGears()),
+			Drivetrain:      e.GetDrivetrain(),
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package rpc
+
+import (
+	"errors"
+	"log"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	customErrors "carAPI/custom-errors"
+)
+
+// domain is the domain of the ErrorInfo details of errors
+const domain = "carAPI"
+
+// grpcCode returns the gRPC status code errors identified by code are sent with, the counterpart of code.Status
+func grpcCode(code customErrors.Code) codes.Code {
+	switch code {
+	case customErrors.CodeUnauthorized:
+		return codes.Unauthenticated
+	case customErrors.CodeForbidden:
+		return codes.PermissionDenied
+	case customErrors.CodeInvalidID, customErrors.CodeInvalidQuery, customErrors.CodeValidation:
+		return codes.InvalidArgument
+	case customErrors.CodeEntityNotFound:
+		return codes.NotFound
+	case customErrors.CodeConflict:
+		// conflicts are not only about existing entities, they also refuse changes of the status of cars
+		return codes.FailedPrecondition
+	case customErrors.CodeUnavailable:
+		return codes.Unavailable
+	}
+
+	return codes.Internal
+}
+
+// newStatus returns the status error identified by code, the title of the code is its message if message is empty.
+// The code is the reason of its ErrorInfo detail, the ID of the entity the error is about is in its metadata
+func newStatus(code customErrors.Code, message, id string) error {
+	return withDetails(code, message, id).Err()
+}
+
+func withDetails(code customErrors.Code, message, id string, details ...*errdetails.BadRequest_FieldViolation) *status.Status {
+	if message == "" {
+		message = code.Title()
+	}
+
+	info := &errdetails.ErrorInfo{Reason: string(code), Domain: domain}
+	if id != "" {
+		info.Metadata = map[string]string{"id": id}
+	}
+
+	s, err := status.New(grpcCode(code), message).WithDetails(info)
+	if err != nil {
+		log.Println(err)
+		return status.New(grpcCode(code), message)
+	}
+
+	if len(details) == 0 {
+		return s
+	}
+
+	withFields, err := s.WithDetails(&errdetails.BadRequest{FieldViolations: details})
+	if err != nil {
+		log.Println(err)
+		return s
+	}
+
+	return withFields
+}
+
+// statusErr maps an error of the services to a status error, like the handlers map it to problem details.
+// The invalid fields of validation errors are the field violations of a BadRequest detail
+func statusErr(err error, id string) error {
+	log.Println(err)
+
+	var (
+		notExists customErrors.EntityNotExists
+		conflict  customErrors.Conflict
+		fields    customErrors.InvalidFields
+	)
+
+	// the message is taken from the classified error, so that the context added while wrapping is only logged
+	switch {
+	case errors.As(err, &notExists):
+		return newStatus(customErrors.CodeEntityNotFound, notExists.Error(), id)
+	case errors.As(err, &conflict):
+		return newStatus(customErrors.CodeConflict, conflict.Error(), id)
+	case errors.Is(err, customErrors.ErrNotFound):
+		return newStatus(customErrors.CodeEntityNotFound, "", id)
+	case errors.Is(err, customErrors.ErrConflict):
+		return newStatus(customErrors.CodeConflict, "", id)
+	case errors.As(err, &fields):
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+		for i, f := range fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Path, Description: f.Message}
+		}
+
+		return withDetails(customErrors.CodeValidation, "", "", violations...).Err()
+	case errors.Is(err, customErrors.ErrValidation):
+		return newStatus(customErrors.CodeValidation, err.Error(), "")
+	case errors.Is(err, customErrors.ErrUnavailable):
+		return newStatus(customErrors.CodeUnavailable, "", "")
+	}
+
+	return newStatus(customErrors.CodeDatabase, "", "")
+}
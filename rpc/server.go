@@ -0,0 +1,221 @@
+// Package rpc serves the car service over gRPC. Requests go through the same services and validation as the REST API,
+// cars are scoped to the dealership of the API key of the x-api-key metadata.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"carAPI/carpb"
+	customErrors "carAPI/custom-errors"
+	"carAPI/middleware"
+	"carAPI/model"
+	"carAPI/service"
+	"carAPI/validation"
+)
+
+type server struct {
+	carpb.UnimplementedCarServiceServer
+
+	svc     service.CarService
+	catalog validation.Catalog
+}
+
+//nolint:revive //server should not be exported
+func New(s service.CarService, c validation.Catalog) server {
+	return server{svc: s, catalog: c}
+}
+
+// NewServer returns a gRPC server of the car service s, requests are authenticated with keys
+func NewServer(s service.CarService, c validation.Catalog, keys map[string]model.APIKey) *grpc.Server {
+	gs := grpc.NewServer(grpc.UnaryInterceptor(unaryAuth(keys)), grpc.StreamInterceptor(streamAuth(keys)))
+
+	carpb.RegisterCarServiceServer(gs, New(s, c))
+
+	return gs
+}
+
+func (s server) GetAll(ctx context.Context, req *carpb.GetAllRequest) (*carpb.GetAllResponse, error) {
+	filter, err := readFilter(ctx, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	cars, err := s.svc.GetAll(filter, req.GetWithEngine())
+	if err != nil {
+		return nil, statusErr(err, "")
+	}
+
+	resp := &carpb.GetAllResponse{Cars: make([]*carpb.Car, len(cars))}
+	for i := range cars {
+		resp.Cars[i] = newCar(ctx, &cars[i])
+	}
+
+	return resp, nil
+}
+
+func (s server) GetByID(ctx context.Context, req *carpb.GetByIDRequest) (*carpb.Car, error) {
+	car, err := s.owned(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return newCar(ctx, car), nil
+}
+
+func (s server) Create(ctx context.Context, req *carpb.CreateRequest) (*carpb.Car, error) {
+	car := readCar(req.GetCar())
+
+	err := validation.Car(&car, s.catalog)
+	if err != nil {
+		return nil, statusErr(err, "")
+	}
+
+	// new cars are stocked at the dealership of the API key
+	car.DealershipID = middleware.Dealership(ctx)
+
+	newC, err := s.svc.Create(&car)
+	if err != nil {
+		return nil, statusErr(err, "")
+	}
+
+	return newCar(ctx, newC), nil
+}
+
+func (s server) Update(ctx context.Context, req *carpb.UpdateRequest) (*carpb.Car, error) {
+	_, err := s.owned(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	car := readCar(req.GetCar())
+
+	err = validation.Car(&car, s.catalog)
+	if err != nil {
+		return nil, statusErr(err, req.GetId())
+	}
+
+	car.ID = req.GetId()
+
+	updatedCar, err := s.svc.Update(&car)
+	if err != nil {
+		return nil, statusErr(err, car.ID)
+	}
+
+	return newCar(ctx, updatedCar), nil
+}
+
+func (s server) Delete(ctx context.Context, req *carpb.DeleteRequest) (*carpb.DeleteResponse, error) {
+	_, err := s.owned(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.svc.Delete(req.GetId())
+	if err != nil {
+		return nil, statusErr(err, req.GetId())
+	}
+
+	return &carpb.DeleteResponse{}, nil
+}
+
+// ListCars sends the cars one at a time as they are read from the DB, so that large inventories are never held in memory
+func (s server) ListCars(req *carpb.ListCarsRequest, stream carpb.CarService_ListCarsServer) error {
+	ctx := stream.Context()
+
+	filter, err := readFilter(ctx, req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	var sendErr error
+
+	err = s.svc.Each(filter, func(car *model.Car) error {
+		sendErr = stream.Send(newCar(ctx, car))
+		return sendErr
+	})
+
+	// errors of the stream already carry their status
+	if err != nil && err == sendErr {
+		return err
+	}
+
+	if err != nil {
+		return statusErr(err, "")
+	}
+
+	return nil
+}
+
+// owned fetches the car with given ID, the cars of other dealerships than the one of the API key are reported as not existing
+func (s server) owned(ctx context.Context, id string) (*model.Car, error) {
+	_, err := uuid.Parse(id)
+	if err != nil {
+		return nil, newStatus(customErrors.CodeInvalidID, "id must be a valid UUID", id)
+	}
+
+	car, err := s.svc.GetByID(id)
+	if err != nil {
+		return nil, statusErr(err, id)
+	}
+
+	if car.DealershipID != middleware.Dealership(ctx) {
+		return nil, statusErr(customErrors.CarNotExists(), id)
+	}
+
+	return car, nil
+}
+
+// readFilter returns the car filter of f for the dealership of the API key, the enums and bounds are checked
+// like the query params of the REST API
+func readFilter(ctx context.Context, f *carpb.CarFilter) (model.CarFilter, error) {
+	filter := model.CarFilter{
+		Brand:        f.GetBrand(),
+		Status:       model.Status(f.GetStatus()),
+		Transmission: f.GetTransmission(),
+		Drivetrain:   f.GetDrivetrain(),
+		MinPower:     int(f.GetMinPower()),
+		MaxPower:     int(f.GetMaxPower()),
+		Currency:     f.GetCurrency(),
+		MinPrice:     f.GetMinPrice(),
+		MaxPrice:     f.GetMaxPrice(),
+		DealershipID: middleware.Dealership(ctx),
+	}
+
+	enums := []struct {
+		name    string
+		value   string
+		allowed []string
+	}{
+		{"status", string(filter.Status), validation.Statuses()},
+		{"transmission", filter.Transmission, validation.Transmissions()},
+		{"drivetrain", filter.Drivetrain, validation.Drivetrains()},
+		{"currency", filter.Currency, validation.Currencies()},
+	}
+
+	for _, e := range enums {
+		if e.value != "" && !contains(e.allowed, e.value) {
+			return filter, newStatus(customErrors.CodeInvalidQuery, fmt.Sprintf("%v must be one of %v", e.name, strings.Join(e.allowed, ", ")), "")
+		}
+	}
+
+	if filter.MinPower < 0 || filter.MaxPower < 0 || filter.MinPrice < 0 || filter.MaxPrice < 0 {
+		return filter, newStatus(customErrors.CodeInvalidQuery, "the bounds of power and price must be non-negative", "")
+	}
+
+	return filter, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,296 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"carAPI/carpb"
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func id1() string {
+	return "86a4cc77-4a2b-4215-8a2c-ff3ecca19627"
+}
+
+func car1() *model.Car {
+	return &model.Car{
+		ID:                id1(),
+		Name:              "Roadster",
+		YearOfManufacture: 2000,
+		Brand:             "Tesla",
+		FuelType:          "Electric",
+		Engine:            model.Engine{ID: "e1", Range: 500},
+		Status:            model.StatusOnLot,
+		Price:             &model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000},
+		DealershipID:      "d1",
+	}
+}
+
+func pbCar1(withMinimum bool) *carpb.Car {
+	c := &carpb.Car{
+		Id:                id1(),
+		Name:              "Roadster",
+		YearOfManufacture: 2000,
+		Brand:             "Tesla",
+		FuelType:          "Electric",
+		Engine:            &carpb.Engine{Id: "e1", Range: 500},
+		Status:            "on-lot",
+		Price:             &carpb.Price{Currency: "EUR", List: 8999900},
+		DealershipId:      "d1",
+	}
+
+	if withMinimum {
+		c.Price.Minimum = 8500000
+	}
+
+	return c
+}
+
+// dial serves s over an in-memory listener and returns a client of it
+func dial(t *testing.T, mockCtrl *gomock.Controller, s *mocks.MockCarService) carpb.CarServiceClient {
+	t.Helper()
+
+	c := mocks.NewMockCatalog(mockCtrl)
+	c.EXPECT().Brands().Return([]string{"Tesla", "BMW"}, nil).AnyTimes()
+	c.EXPECT().FuelTypes().Return([]string{"Electric", "Petrol"}, nil).AnyTimes()
+	c.EXPECT().EngineRules().Return(map[string]model.EngineRule{
+		"Electric": {Required: []string{"range"}, Forbidden: []string{"displacement", "noOfCylinders", "electricRange", "tankCapacity"}},
+		"Petrol":   {Required: []string{"displacement", "noOfCylinders"}, Forbidden: []string{"batteryCapacity", "electricRange", "noOfMotors"}},
+	}, nil).AnyTimes()
+
+	lis := bufconn.Listen(1 << 20)
+	gs := NewServer(s, c, map[string]model.APIKey{
+		"admin-key": {Role: model.RoleAdmin, DealershipID: "d1"},
+		"north-key": {Role: model.RoleViewer, DealershipID: "d1"},
+		"south-key": {Role: model.RoleViewer, DealershipID: "d2"},
+	})
+
+	go func() {
+		_ = gs.Serve(lis)
+	}()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		gs.Stop()
+	})
+
+	return carpb.NewCarServiceClient(conn)
+}
+
+func withKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), keyMetadata, key)
+}
+
+// reason returns the reason of the ErrorInfo detail of err
+func reason(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+
+	return ""
+}
+
+func TestServer_GetByID(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+	client := dial(t, mockCtrl, m)
+
+	m.EXPECT().GetByID(id1()).Return(car1(), nil).Times(3)
+	m.EXPECT().GetByID(id1()).Return(nil, customErrors.Unavailable{Err: errors.New("connection refused")})
+
+	tests := []struct {
+		desc   string
+		key    string
+		id     string
+		car    *carpb.Car
+		code   codes.Code
+		reason customErrors.Code
+	}{
+		{"Admin keys see the minimum price", "admin-key", id1(), pbCar1(true), codes.OK, ""},
+		{"Viewer keys do not", "north-key", id1(), pbCar1(false), codes.OK, ""},
+		{"Car of another dealership", "south-key", id1(), nil, codes.NotFound, customErrors.CodeEntityNotFound},
+		{"Invalid ID", "admin-key", "1", nil, codes.InvalidArgument, customErrors.CodeInvalidID},
+		{"Unknown key", "unknown-key", id1(), nil, codes.Unauthenticated, customErrors.CodeUnauthorized},
+		{"DB unavailable", "admin-key", id1(), nil, codes.Unavailable, customErrors.CodeUnavailable},
+	}
+
+	for i, tc := range tests {
+		car, err := client.GetByID(withKey(tc.key), &carpb.GetByIDRequest{Id: tc.id})
+
+		assert.Equalf(t, tc.code, status.Code(err), "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, string(tc.reason), reason(err), "Testcase[%v] (%v)", i, tc.desc)
+		assert.Truef(t, proto.Equal(tc.car, car), "Testcase[%v] (%v)\nExpected %v\nGot %v", i, tc.desc, tc.car, car)
+	}
+}
+
+func TestServer_GetAll(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+	client := dial(t, mockCtrl, m)
+
+	m.EXPECT().GetAll(model.CarFilter{Brand: "Tesla", MinPower: 100, DealershipID: "d1"}, true).Return([]model.Car{*car1()}, nil)
+
+	resp, err := client.GetAll(withKey("north-key"),
+		&carpb.GetAllRequest{Filter: &carpb.CarFilter{Brand: "Tesla", MinPower: 100}, WithEngine: true})
+
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(&carpb.GetAllResponse{Cars: []*carpb.Car{pbCar1(false)}}, resp))
+
+	_, err = client.GetAll(withKey("north-key"), &carpb.GetAllRequest{Filter: &carpb.CarFilter{Status: "parked"}})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "status must be one of in-transit, on-lot, reserved, sold, returned", status.Convert(err).Message())
+}
+
+func TestServer_Create(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+	client := dial(t, mockCtrl, m)
+
+	m.EXPECT().Create(&model.Car{Name: "Roadster", YearOfManufacture: 2000, Brand: "Tesla", FuelType: "Electric",
+		Engine: model.Engine{Range: 500}, DealershipID: "d1"}).Return(car1(), nil)
+
+	// the price is ignored on input
+	input := pbCar1(true)
+	input.Id, input.Engine.Id, input.Status, input.DealershipId = "", "", "", ""
+
+	car, err := client.Create(withKey("admin-key"), &carpb.CreateRequest{Car: input})
+
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(pbCar1(true), car))
+
+	input.Brand = "Fiat"
+
+	_, err = client.Create(withKey("admin-key"), &carpb.CreateRequest{Car: input})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, string(customErrors.CodeValidation), reason(err))
+
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			violations = br.FieldViolations
+		}
+	}
+
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "/brand", violations[0].Field)
+		assert.Equal(t, "Fiat is not a valid brand", violations[0].Description)
+	}
+}
+
+func TestServer_UpdateDelete(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+	client := dial(t, mockCtrl, m)
+
+	m.EXPECT().GetByID(id1()).Return(car1(), nil).Times(3)
+	m.EXPECT().Update(gomock.Any()).DoAndReturn(func(car *model.Car) (*model.Car, error) {
+		assert.Equal(t, id1(), car.ID)
+
+		return car1(), nil
+	})
+	m.EXPECT().Delete(id1()).Return(nil)
+	m.EXPECT().Delete(id1()).Return(customErrors.Conflict{Entity: "Car", Reason: "the car has an open order"})
+
+	car, err := client.Update(withKey("north-key"), &carpb.UpdateRequest{Id: id1(), Car: pbCar1(false)})
+
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(pbCar1(false), car))
+
+	_, err = client.Delete(withKey("north-key"), &carpb.DeleteRequest{Id: id1()})
+
+	assert.Nil(t, err)
+
+	_, err = client.Delete(withKey("north-key"), &carpb.DeleteRequest{Id: id1()})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "Car conflicts: the car has an open order", status.Convert(err).Message())
+}
+
+func TestServer_ListCars(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarService(mockCtrl)
+	client := dial(t, mockCtrl, m)
+
+	m.EXPECT().Each(model.CarFilter{DealershipID: "d1"}, gomock.Any()).DoAndReturn(func(_ model.CarFilter, fn func(*model.Car) error) error {
+		for i := 0; i < 3; i++ {
+			err := fn(car1())
+			if err != nil {
+				return err
+			}
+		}
+
+		return errors.New("DB error")
+	})
+
+	stream, err := client.ListCars(withKey("admin-key"), &carpb.ListCarsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received int
+
+	for {
+		car, err := stream.Recv()
+		if err != nil {
+			// the cars read before the DB failed are received before the error
+			assert.Equal(t, 3, received)
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.NotEqual(t, io.EOF, err)
+
+			break
+		}
+
+		assert.True(t, proto.Equal(pbCar1(true), car))
+
+		received++
+	}
+
+	// streams are authenticated before the first car is sent
+	stream, err = client.ListCars(withKey("unknown-key"), &carpb.ListCarsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = stream.Recv()
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
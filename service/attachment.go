@@ -0,0 +1,180 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"carAPI/blob"
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store"
+	"carAPI/thumbnail"
+)
+
+type attachmentService struct {
+	store    store.AttachmentStore
+	carStore store.CarStore
+	blobs    blob.Storage
+}
+
+//nolint:revive //attachmentService should not be exported
+func NewAttachment(a store.AttachmentStore, c store.CarStore, b blob.Storage) attachmentService {
+	return attachmentService{store: a, carStore: c, blobs: b}
+}
+
+func (s attachmentService) GetByCar(carID string) ([]model.Attachment, error) {
+	return s.store.GetByCar(carID)
+}
+
+func (s attachmentService) Upload(attachment *model.Attachment, data []byte) (*model.Attachment, error) {
+	_, err := s.carStore.GetByID(attachment.CarID)
+	if err != nil {
+		return nil, err
+	}
+
+	var thumb []byte
+
+	if attachment.Kind == model.AttachmentPhoto {
+		thumb, err = thumbnail.Generate(data, model.ThumbnailSize, model.MaxPhotoPixels)
+		if errors.Is(err, thumbnail.ErrTooLarge) {
+			return nil, customErrors.InvalidFields{{
+				Path:    "/" + model.ParamFile,
+				Code:    customErrors.FieldOutOfRange,
+				Message: fmt.Sprintf("file must have at most %v pixels", model.MaxPhotoPixels),
+			}}
+		}
+
+		if err != nil {
+			log.Println(err)
+
+			return nil, customErrors.InvalidFields{{
+				Path:    "/" + model.ParamFile,
+				Code:    customErrors.FieldInvalid,
+				Message: "file is not a valid " + attachment.ContentType + " image",
+			}}
+		}
+	}
+
+	attachment.Size = int64(len(data))
+	attachment.HasThumbnail = thumb != nil
+	attachment.CreatedAt = time.Now()
+
+	newAttachment, err := s.store.Create(attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.putContent(newAttachment, data, thumb)
+	if err != nil {
+		// an attachment without content cannot be downloaded, so it is removed again
+		if deleteErr := s.store.Delete(newAttachment.ID); deleteErr != nil {
+			log.Println(deleteErr)
+		}
+
+		return nil, err
+	}
+
+	return newAttachment, nil
+}
+
+// putContent stores the content of attachment and its thumbnail if it has one
+func (s attachmentService) putContent(attachment *model.Attachment, data, thumb []byte) error {
+	err := s.blobs.Put(contentKey(attachment), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	if thumb == nil {
+		return nil
+	}
+
+	return s.blobs.Put(thumbnailKey(attachment), bytes.NewReader(thumb))
+}
+
+func (s attachmentService) Open(carID, id string) (*model.Attachment, io.ReadCloser, error) {
+	attachment, err := s.get(carID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Open(contentKey(attachment))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+func (s attachmentService) OpenThumbnail(carID, id string) (*model.Attachment, io.ReadCloser, error) {
+	attachment, err := s.get(carID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !attachment.HasThumbnail {
+		return nil, nil, customErrors.ThumbnailNotExists()
+	}
+
+	content, err := s.blobs.Open(thumbnailKey(attachment))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+func (s attachmentService) Delete(carID, id string) error {
+	attachment, err := s.get(carID, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	// the attachment is gone once it is deleted from the DB, content which cannot be deleted is only logged
+	for _, key := range []string{contentKey(attachment), thumbnailKey(attachment)} {
+		if deleteErr := s.blobs.Delete(key); deleteErr != nil {
+			log.Println(deleteErr)
+		}
+	}
+
+	return nil
+}
+
+func (s attachmentService) DeleteByCar(carID string) error {
+	return s.blobs.DeleteDir(carDir(carID))
+}
+
+// get fetches the attachment with given ID, attachments of other cars are reported as not existing
+func (s attachmentService) get(carID, id string) (*model.Attachment, error) {
+	attachment, err := s.store.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if attachment.CarID != carID {
+		return nil, customErrors.AttachmentNotExists()
+	}
+
+	return attachment, nil
+}
+
+// carDir is the blob directory of the attachments of a car, so that they can be deleted with the car
+func carDir(carID string) string {
+	return "cars/" + carID
+}
+
+func contentKey(attachment *model.Attachment) string {
+	return carDir(attachment.CarID) + "/" + attachment.ID
+}
+
+func thumbnailKey(attachment *model.Attachment) string {
+	return contentKey(attachment) + "-thumbnail"
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+// photo returns a PNG image of 512 x 256 pixels
+func photo() []byte {
+	var buf bytes.Buffer
+
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 512, 256)))
+
+	return buf.Bytes()
+}
+
+// pngHeader returns the signature and header chunk of a PNG image which declares w x h pixels, without any pixel data
+func pngHeader(w, h uint32) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], w)
+	binary.BigEndian.PutUint32(ihdr[8:], h)
+	// 8 bit RGBA
+	ihdr[12], ihdr[13] = 8, 6
+
+	var buf bytes.Buffer
+
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	_ = binary.Write(&buf, binary.BigEndian, uint32(13))
+	buf.Write(ihdr)
+	_ = binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(ihdr))
+
+	return buf.Bytes()
+}
+
+func TestAttachment_Upload(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	a := mocks.NewMockAttachmentStore(mockCtrl)
+	c := mocks.NewMockCarStore(mockCtrl)
+	b := mocks.NewMockStorage(mockCtrl)
+
+	created := func(attachment *model.Attachment) (*model.Attachment, error) {
+		attachment.ID = "a1"
+		return attachment, nil
+	}
+
+	c.EXPECT().GetByID("1").Return(&model.Car{ID: "1"}, nil).Times(5)
+	c.EXPECT().GetByID("2").Return(nil, customErrors.CarNotExists())
+
+	// photos are stored with their thumbnail
+	a.EXPECT().Create(gomock.Any()).DoAndReturn(created)
+	b.EXPECT().Put("cars/1/a1", gomock.Any()).Return(nil)
+	b.EXPECT().Put("cars/1/a1-thumbnail", gomock.Any()).Return(nil)
+
+	a.EXPECT().Create(gomock.Any()).DoAndReturn(created)
+	b.EXPECT().Put("cars/1/a1", gomock.Any()).Return(nil)
+
+	// an attachment whose content cannot be stored is removed again
+	a.EXPECT().Create(gomock.Any()).DoAndReturn(created)
+	b.EXPECT().Put("cars/1/a1", gomock.Any()).Return(errors.New("disk full"))
+	a.EXPECT().Delete("a1").Return(nil)
+
+	pdf := []byte("%PDF-1.7 inspection report")
+
+	tests := []struct {
+		desc         string
+		attachment   model.Attachment
+		data         []byte
+		hasThumbnail bool
+		err          error
+	}{
+		{"Photo", model.Attachment{CarID: "1", Kind: model.AttachmentPhoto, ContentType: "image/png"}, photo(), true, nil},
+		{"Document", model.Attachment{CarID: "1", Kind: model.AttachmentDocument, ContentType: "application/pdf"}, pdf, false, nil},
+		{"Broken photo", model.Attachment{CarID: "1", Kind: model.AttachmentPhoto, ContentType: "image/png"}, photo()[:64], false,
+			customErrors.InvalidFields{{Path: "/file", Code: customErrors.FieldInvalid, Message: "file is not a valid image/png image"}}},
+		{"Photo with too many pixels", model.Attachment{CarID: "1", Kind: model.AttachmentPhoto, ContentType: "image/png"},
+			pngHeader(20000, 20000), false, customErrors.InvalidFields{{Path: "/file", Code: customErrors.FieldOutOfRange,
+				Message: "file must have at most 50000000 pixels"}}},
+		{"Storage error", model.Attachment{CarID: "1", Kind: model.AttachmentDocument, ContentType: "application/pdf"}, pdf, false,
+			errors.New("disk full")},
+		{"Car not exists", model.Attachment{CarID: "2", Kind: model.AttachmentDocument}, pdf, false, customErrors.CarNotExists()},
+	}
+
+	svc := NewAttachment(a, c, b)
+
+	for i, tc := range tests {
+		attachment, err := svc.Upload(&tc.attachment, tc.data)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		if tc.err == nil {
+			assert.Equalf(t, int64(len(tc.data)), attachment.Size, "Testcase[%v] (%v)", i, tc.desc)
+			assert.Equalf(t, tc.hasThumbnail, attachment.HasThumbnail, "Testcase[%v] (%v)", i, tc.desc)
+			assert.Falsef(t, attachment.CreatedAt.IsZero(), "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
+
+func TestAttachment_OpenThumbnail(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	a := mocks.NewMockAttachmentStore(mockCtrl)
+	b := mocks.NewMockStorage(mockCtrl)
+
+	front := &model.Attachment{ID: "a1", CarID: "1", Kind: model.AttachmentPhoto, HasThumbnail: true}
+	report := &model.Attachment{ID: "a2", CarID: "1", Kind: model.AttachmentDocument}
+
+	a.EXPECT().GetByID("a1").Return(front, nil).Times(2)
+	a.EXPECT().GetByID("a2").Return(report, nil)
+	a.EXPECT().GetByID("a3").Return(nil, customErrors.AttachmentNotExists())
+	b.EXPECT().Open("cars/1/a1-thumbnail").Return(io.NopCloser(bytes.NewReader([]byte("thumbnail"))), nil)
+
+	tests := []struct {
+		desc    string
+		carID   string
+		id      string
+		content string
+		err     error
+	}{
+		{"Success", "1", "a1", "thumbnail", nil},
+		{"Attachment of another car", "2", "a1", "", customErrors.AttachmentNotExists()},
+		{"Document", "1", "a2", "", customErrors.ThumbnailNotExists()},
+		{"Attachment not exists", "1", "a3", "", customErrors.AttachmentNotExists()},
+	}
+
+	svc := NewAttachment(a, nil, b)
+
+	for i, tc := range tests {
+		_, r, err := svc.OpenThumbnail(tc.carID, tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		if tc.err == nil {
+			content, _ := io.ReadAll(r)
+			r.Close()
+
+			assert.Equalf(t, tc.content, string(content), "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
+
+func TestAttachment_Delete(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	a := mocks.NewMockAttachmentStore(mockCtrl)
+	b := mocks.NewMockStorage(mockCtrl)
+
+	a.EXPECT().GetByID("a1").Return(&model.Attachment{ID: "a1", CarID: "1"}, nil)
+	a.EXPECT().Delete("a1").Return(nil)
+	b.EXPECT().Delete("cars/1/a1").Return(nil)
+	b.EXPECT().Delete("cars/1/a1-thumbnail").Return(errors.New("disk error"))
+
+	a.EXPECT().GetByID("a2").Return(&model.Attachment{ID: "a2", CarID: "1"}, nil)
+	a.EXPECT().Delete("a2").Return(errors.New("DB error"))
+
+	tests := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{"Content not deleted", "a1", nil},
+		{"DB error", "a2", errors.New("DB error")},
+	}
+
+	svc := NewAttachment(a, nil, b)
+
+	for i, tc := range tests {
+		err := svc.Delete("1", tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
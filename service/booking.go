@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store"
+)
+
+type bookingService struct {
+	bookingStore  store.BookingStore
+	carStore      store.CarStore
+	customerStore store.CustomerStore
+
+	// holdDuration is the time after which holds expire
+	holdDuration time.Duration
+}
+
+//nolint:revive //bookingService should not be exported
+func NewBooking(b store.BookingStore, c store.CarStore, cu store.CustomerStore, holdDuration time.Duration) bookingService {
+	return bookingService{
+		bookingStore:  b,
+		carStore:      c,
+		customerStore: cu,
+		holdDuration:  holdDuration,
+	}
+}
+
+func (s bookingService) GetByCar(carID string) ([]model.Booking, error) {
+	// distinguishes a missing car from a car without bookings
+	_, err := s.carStore.GetByID(carID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.bookingStore.GetByCar(carID)
+}
+
+func (s bookingService) GetByID(id string) (*model.Booking, error) {
+	return s.bookingStore.GetByID(id)
+}
+
+func (s bookingService) Create(booking *model.Booking) (*model.Booking, error) {
+	err := s.checkReferences(booking)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	// the overlap is checked again by the store while the car is locked, this check names the overlapping booking
+	overlapping, err := s.bookingStore.GetOverlapping(booking.CarID, model.Slot{Start: booking.Start, End: booking.End}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(overlapping) != 0 {
+		return nil, customErrors.Conflict{
+			Entity: "Booking",
+			Reason: fmt.Sprintf("the car is already booked from %v to %v", overlapping[0].Start.Format(time.RFC3339),
+				overlapping[0].End.Format(time.RFC3339)),
+		}
+	}
+
+	booking.Status = model.BookingActive
+	booking.CreatedAt = now
+	booking.ExpiresAt = nil
+
+	// holds are released after the hold duration from the start of their slot, unless they end before.
+	// A hold released before its slot starts would leave the slot free to be booked again
+	if booking.Kind == model.BookingHold {
+		from := now
+		if booking.Start.After(now) {
+			from = booking.Start
+		}
+
+		expiresAt := from.Add(s.holdDuration)
+		if expiresAt.Before(booking.End) {
+			booking.ExpiresAt = &expiresAt
+		}
+	}
+
+	return s.bookingStore.Create(booking)
+}
+
+func (s bookingService) Cancel(id string) (*model.Booking, error) {
+	booking, err := s.bookingStore.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status != model.BookingActive {
+		return nil, customErrors.Conflict{Entity: "Booking", Reason: fmt.Sprintf("booking is %v", booking.Status)}
+	}
+
+	err = s.bookingStore.Cancel(id)
+	if err != nil {
+		return nil, err
+	}
+
+	booking.Status = model.BookingCancelled
+
+	return booking, nil
+}
+
+func (s bookingService) Availability(carID string, slot model.Slot) ([]model.Slot, error) {
+	_, err := s.carStore.GetByID(carID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.bookingStore.GetOverlapping(carID, slot, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return freeSlots(slot, bookings), nil
+}
+
+// Sweep expires the holds past their expiry every interval, until ctx is done
+func (s bookingService) Sweep(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := s.bookingStore.ExpireHolds(now)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+
+			if n != 0 {
+				log.Printf("expired %v holds", n)
+			}
+		}
+	}
+}
+
+// checkReferences checks the car of booking can be booked, and the customer it is held for exists.
+// Holds need a customer, test drives may be booked for walk-in visitors.
+func (s bookingService) checkReferences(booking *model.Booking) error {
+	car, err := s.carStore.GetByID(booking.CarID)
+	if errors.Is(err, customErrors.ErrNotFound) {
+		return invalidReference(model.ParamCarID, "car", booking.CarID)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if car.Status == model.StatusSold {
+		return customErrors.Conflict{Entity: "Booking", Reason: "car is sold"}
+	}
+
+	if booking.CustomerID == "" {
+		if booking.Kind == model.BookingHold {
+			return customErrors.InvalidFields{{
+				Path:    "/" + model.ParamCustomerID,
+				Code:    customErrors.FieldRequired,
+				Message: fmt.Sprintf("%v is required for a hold", model.ParamCustomerID),
+			}}
+		}
+
+		return nil
+	}
+
+	_, err = s.customerStore.GetByID(booking.CustomerID)
+	if errors.Is(err, customErrors.ErrNotFound) {
+		return invalidReference(model.ParamCustomerID, "customer", booking.CustomerID)
+	}
+
+	return err
+}
+
+// freeSlots returns the parts of slot which are not covered by any of bookings, in order
+func freeSlots(slot model.Slot, bookings []model.Booking) []model.Slot {
+	sort.Slice(bookings, func(i, j int) bool {
+		return bookings[i].Start.Before(bookings[j].Start)
+	})
+
+	free := make([]model.Slot, 0)
+	start := slot.Start
+
+	for _, b := range bookings {
+		if b.Start.After(start) {
+			free = append(free, model.Slot{Start: start, End: minTime(b.Start, slot.End)})
+		}
+
+		if b.End.After(start) {
+			start = b.End
+		}
+	}
+
+	if start.Before(slot.End) {
+		free = append(free, model.Slot{Start: start, End: slot.End})
+	}
+
+	return free
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestBooking_Create(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	b := mocks.NewMockBookingStore(mockCtrl)
+	c := mocks.NewMockCarStore(mockCtrl)
+	cu := mocks.NewMockCustomerStore(mockCtrl)
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	slot := model.Slot{Start: start, End: start.Add(72 * time.Hour)}
+
+	// the slot of a later hold starts after the hold duration
+	later := model.Slot{Start: start.Add(5 * 24 * time.Hour), End: start.Add(8 * 24 * time.Hour)}
+
+	c.EXPECT().GetByID("car1").Return(&model.Car{ID: "car1", Status: model.StatusOnLot}, nil).Times(4)
+	c.EXPECT().GetByID("car2").Return(&model.Car{ID: "car2", Status: model.StatusSold}, nil)
+	cu.EXPECT().GetByID("c1").Return(&model.Customer{ID: "c1"}, nil).Times(3)
+
+	// a hold longer than the hold duration expires before it ends, the hold duration runs from the start of the slot
+	b.EXPECT().GetOverlapping("car1", slot, gomock.Any()).Return(nil, nil)
+	b.EXPECT().Create(gomock.Any()).DoAndReturn(func(booking *model.Booking) (*model.Booking, error) {
+		assert.Equal(t, model.BookingActive, booking.Status)
+		assert.NotNil(t, booking.ExpiresAt)
+		assert.Equal(t, slot.Start.Add(48*time.Hour), *booking.ExpiresAt)
+
+		return booking, nil
+	})
+
+	b.EXPECT().GetOverlapping("car1", later, gomock.Any()).Return(nil, nil)
+	b.EXPECT().Create(gomock.Any()).DoAndReturn(func(booking *model.Booking) (*model.Booking, error) {
+		assert.NotNil(t, booking.ExpiresAt)
+		assert.Equal(t, later.Start.Add(48*time.Hour), *booking.ExpiresAt)
+
+		return booking, nil
+	})
+
+	b.EXPECT().GetOverlapping("car1", slot, gomock.Any()).
+		Return([]model.Booking{{ID: "b1", Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)}}, nil)
+
+	tests := []struct {
+		desc       string
+		carID      string
+		customerID string
+		kind       model.BookingKind
+		slot       model.Slot
+		err        error
+	}{
+		{"Hold", "car1", "c1", model.BookingHold, slot, nil},
+		{"Hold of a slot after the hold duration", "car1", "c1", model.BookingHold, later, nil},
+		{"Overlapping booking", "car1", "c1", model.BookingHold, slot, customErrors.Conflict{
+			Entity: "Booking",
+			Reason: "the car is already booked from " + start.Add(time.Hour).Format(time.RFC3339) + " to " +
+				start.Add(2*time.Hour).Format(time.RFC3339),
+		}},
+		{"Hold without customer", "car1", "", model.BookingHold, slot, customErrors.InvalidFields{
+			{Path: "/customerId", Code: customErrors.FieldRequired, Message: "customerId is required for a hold"},
+		}},
+		{"Car sold", "car2", "", model.BookingTestDrive, slot, customErrors.Conflict{Entity: "Booking", Reason: "car is sold"}},
+	}
+
+	svc := NewBooking(b, c, cu, 48*time.Hour)
+
+	for i, tc := range tests {
+		_, err := svc.Create(&model.Booking{CarID: tc.carID, CustomerID: tc.customerID, Kind: tc.kind, Start: tc.slot.Start, End: tc.slot.End})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestBooking_Availability(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	b := mocks.NewMockBookingStore(mockCtrl)
+	c := mocks.NewMockCarStore(mockCtrl)
+
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	slot := model.Slot{Start: day, End: day.AddDate(0, 0, 1)}
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+
+	c.EXPECT().GetByID("car1").Return(&model.Car{ID: "car1"}, nil).AnyTimes()
+	c.EXPECT().GetByID("car2").Return(nil, customErrors.CarNotExists())
+
+	// bookings may overlap each other if they were created before the overlap was checked, and may exceed the slot
+	b.EXPECT().GetOverlapping("car1", slot, gomock.Any()).Return([]model.Booking{
+		{Start: at(14), End: at(15)},
+		{Start: at(-2), End: at(9)},
+		{Start: at(10), End: at(12)},
+		{Start: at(11), End: at(13)},
+		{Start: at(23), End: at(26)},
+	}, nil)
+	b.EXPECT().GetOverlapping("car1", slot, gomock.Any()).Return([]model.Booking{}, nil)
+
+	tests := []struct {
+		desc  string
+		carID string
+		slots []model.Slot
+		err   error
+	}{
+		{"Booked car", "car1", []model.Slot{{Start: at(9), End: at(10)}, {Start: at(13), End: at(14)}, {Start: at(15), End: at(23)}}, nil},
+		{"Free car", "car1", []model.Slot{slot}, nil},
+		{"Car not exists", "car2", nil, customErrors.CarNotExists()},
+	}
+
+	svc := NewBooking(b, c, nil, time.Hour)
+
+	for i, tc := range tests {
+		slots, err := svc.Availability(tc.carID, slot)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.slots, slots, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
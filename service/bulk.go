@@ -0,0 +1,338 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func (s service) CreateMany(cars []*model.Car, atomic bool) (map[int]customErrors.InvalidFields, error) {
+	valid, errs, err := s.checkCreate(cars)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canWrite(len(valid), errs, atomic) {
+		return errs, nil
+	}
+
+	err = s.carStore.CreateMany(valid, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+func (s service) CheckMany(cars []*model.Car) (map[int]customErrors.InvalidFields, error) {
+	_, errs, err := s.checkCreate(cars)
+
+	return errs, err
+}
+
+// checkCreate prepares cars for CreateMany, the cars which can be created are returned
+// and the invalid fields of the others by their index
+func (s service) checkCreate(cars []*model.Car) ([]*model.Car, map[int]customErrors.InvalidFields, error) {
+	vins, err := s.takenVINs(cars)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	errs := make(map[int]customErrors.InvalidFields)
+	trims := make(map[string]*model.Trim)
+	valid := make([]*model.Car, 0, len(cars))
+
+	for i, car := range cars {
+		fields, err := s.prepareCreate(car, trims)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// VINs are unique, a duplicate would fail the insert of every car of the request
+		if fields == nil {
+			fields = claimVIN("", car.VIN, vins)
+		}
+
+		if fields != nil {
+			errs[i] = fields
+			continue
+		}
+
+		valid = append(valid, car)
+	}
+
+	return valid, errs, nil
+}
+
+func (s service) UpdateMany(dealershipID string, cars []*model.Car, atomic bool) (map[int]customErrors.InvalidFields, error) {
+	ids := make([]string, len(cars))
+	for i, car := range cars {
+		ids[i] = car.ID
+	}
+
+	existing, err := s.ownedCars(dealershipID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	vins, err := s.takenVINs(cars)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(map[int]customErrors.InvalidFields)
+	trims := make(map[string]*model.Trim)
+	valid := make([]*model.Car, 0, len(cars))
+
+	for i, car := range cars {
+		fields, err := s.prepareUpdate(car, existing, trims)
+		if err != nil {
+			return nil, err
+		}
+
+		// a VIN of another car would fail the update of every car of the request
+		if fields == nil {
+			fields = claimVIN(car.ID, car.VIN, vins)
+		}
+
+		if fields != nil {
+			errs[i] = fields
+			continue
+		}
+
+		valid = append(valid, car)
+	}
+
+	if !canWrite(len(valid), errs, atomic) {
+		return errs, nil
+	}
+
+	err = s.carStore.UpdateMany(valid)
+	if err != nil {
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+func (s service) DeleteMany(dealershipID string, ids []string, atomic bool) (map[int]customErrors.InvalidFields, error) {
+	existing, err := s.ownedCars(dealershipID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// orders keep their cars, deleting an ordered car would fail the delete of every car of the request
+	ordered, err := s.carStore.GetOrderedIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(map[int]customErrors.InvalidFields)
+	valid := make([]model.Car, 0, len(ids))
+
+	for i, id := range ids {
+		// the path of an ID is the item itself
+		fields := checkBulkID("", id, existing)
+		if fields == nil && contains(ordered, id) {
+			fields = customErrors.InvalidFields{{Code: customErrors.FieldConflict, Message: fmt.Sprintf("car %v has orders", id)}}
+		}
+
+		if fields != nil {
+			errs[i] = fields
+			continue
+		}
+
+		valid = append(valid, existing[id])
+
+		// a car listed more than once is only deleted once
+		delete(existing, id)
+	}
+
+	if !canWrite(len(valid), errs, atomic) {
+		return errs, nil
+	}
+
+	err = s.carStore.DeleteMany(valid)
+	if err != nil {
+		return nil, err
+	}
+
+	// the attachments of the cars are deleted with them by the DB, only their content is left to delete
+	for i := range valid {
+		err = s.attachments.DeleteByCar(valid[i].ID)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	return errs, nil
+}
+
+// ownedCars fetches the cars with given IDs which are stocked at the dealership with given ID, mapped to their IDs
+func (s service) ownedCars(dealershipID string, ids []string) (map[string]model.Car, error) {
+	cars, err := s.carStore.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make(map[string]model.Car, len(cars))
+
+	for i := range cars {
+		// the cars of other dealerships are reported as not existing
+		if cars[i].DealershipID == dealershipID {
+			owned[cars[i].ID] = cars[i]
+		}
+	}
+
+	return owned, nil
+}
+
+// prepareCreate prepares car for CreateMany like Create does, the invalid fields of car are returned
+func (s service) prepareCreate(car *model.Car, trims map[string]*model.Trim) (customErrors.InvalidFields, error) {
+	var fields customErrors.InvalidFields
+
+	// new cars are priced through a price change, so that every price is recorded with its actor and reason
+	car.Price = nil
+
+	err := initialStatus(car)
+	if errors.As(err, &fields) {
+		return fields, nil
+	}
+
+	return s.applyCachedTrim(car, trims)
+}
+
+// prepareUpdate prepares car for UpdateMany like Update does, the invalid fields of car are returned.
+// The car is removed from existing, so that a car listed more than once is only updated once
+func (s service) prepareUpdate(car *model.Car, existing map[string]model.Car,
+	trims map[string]*model.Trim) (customErrors.InvalidFields, error) {
+	fields := checkBulkID(model.ParamCarID, car.ID, existing)
+	if fields != nil {
+		return fields, nil
+	}
+
+	fields, err := s.applyCachedTrim(car, trims)
+	if err != nil || fields != nil {
+		return fields, err
+	}
+
+	carFromDB := existing[car.ID]
+	delete(existing, car.ID)
+
+	// the status is only changed through transitions, the price through price changes
+	// and the dealership through transfers
+	car.Status = carFromDB.Status
+	car.Price = carFromDB.Price
+	car.DealershipID = carFromDB.DealershipID
+	car.Engine.ID = carFromDB.Engine.ID
+
+	return nil, nil
+}
+
+// takenVINs fetches which of the VINs of cars are taken by cars in DB. The VINs are mapped to the IDs of those cars,
+// claimVIN adds the VINs of the request mapped to the empty string
+func (s service) takenVINs(cars []*model.Car) (map[string]string, error) {
+	vins := make([]string, 0, len(cars))
+
+	for _, car := range cars {
+		if car.VIN != "" {
+			vins = append(vins, car.VIN)
+		}
+	}
+
+	taken := make(map[string]string, len(vins))
+
+	if len(vins) == 0 {
+		return taken, nil
+	}
+
+	existing, err := s.carStore.GetByVINs(vins)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range existing {
+		taken[existing[i].VIN] = existing[i].ID
+	}
+
+	return taken, nil
+}
+
+// claimVIN reports the VIN of the car with given ID, which is empty for new cars, as conflicting if it is taken
+// by another car in DB or by an earlier car of the request, otherwise the VIN is claimed for the car.
+// A VIN taken concurrently still fails the write as a whole
+func claimVIN(id, vin string, vins map[string]string) customErrors.InvalidFields {
+	if vin == "" {
+		return nil
+	}
+
+	owner, taken := vins[vin]
+	if !taken || (id != "" && owner == id) {
+		vins[vin] = ""
+		return nil
+	}
+
+	message := fmt.Sprintf("VIN %v is listed more than once", vin)
+	if owner != "" {
+		message = fmt.Sprintf("a car with VIN %v already exists", vin)
+	}
+
+	return customErrors.InvalidFields{{Path: "/" + model.ParamVIN, Code: customErrors.FieldConflict, Message: message}}
+}
+
+// canWrite reports whether the valid items of a bulk request are written, atomic requests are only written without errors
+func canWrite(valid int, errs map[int]customErrors.InvalidFields, atomic bool) bool {
+	return valid != 0 && (!atomic || len(errs) == 0)
+}
+
+// checkBulkID reports the car with given ID at param as invalid if it is not in existing,
+// cars are removed from existing once they are changed so that a car listed twice is reported too
+func checkBulkID(param, id string, existing map[string]model.Car) customErrors.InvalidFields {
+	if _, ok := existing[id]; ok {
+		return nil
+	}
+
+	path := ""
+	if param != "" {
+		path = "/" + param
+	}
+
+	return customErrors.InvalidFields{{
+		Path:    path,
+		Code:    customErrors.FieldInvalid,
+		Message: fmt.Sprintf("car %v not exists or is listed more than once", id),
+	}}
+}
+
+// applyCachedTrim is applyTrim for bulk requests, every trim is fetched once per request
+// and a missing trim is reported as an invalid field
+func (s service) applyCachedTrim(car *model.Car, trims map[string]*model.Trim) (customErrors.InvalidFields, error) {
+	if car.TrimID == "" {
+		return nil, nil
+	}
+
+	trim, ok := trims[car.TrimID]
+	if !ok {
+		var err error
+
+		trim, err = s.trimStore.GetByID(car.TrimID)
+		if err != nil && !errors.Is(err, customErrors.ErrNotFound) {
+			return nil, err
+		}
+
+		// a missing trim is cached as nil
+		trims[car.TrimID] = trim
+	}
+
+	if trim == nil {
+		return invalidReference(model.ParamTrimID, "trim", car.TrimID), nil
+	}
+
+	car.Engine = car.Engine.WithDefaults(trim.Engine)
+
+	return nil, nil
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestService_CreateMany(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCarStore(mockCtrl)
+	tr := mocks.NewMockTrimStore(mockCtrl)
+
+	trim := &model.Trim{ID: "t1", Engine: model.Engine{Range: 500, Power: 300}}
+	invalidTrim := customErrors.InvalidFields{{Path: "/trimId", Code: customErrors.FieldInvalid, Message: "trim t2 not exists"}}
+
+	// every trim is fetched once per request
+	tr.EXPECT().GetByID("t1").Return(trim, nil).Times(2)
+	tr.EXPECT().GetByID("t2").Return(nil, customErrors.TrimNotExists()).Times(2)
+	tr.EXPECT().GetByID("t3").Return(nil, errors.New("DB error"))
+
+	c.EXPECT().CreateMany([]*model.Car{
+		{Name: "Roadster", TrimID: "t1", Status: model.StatusInTransit, Engine: model.Engine{Range: 500, Power: 300}},
+		{Name: "Model S", TrimID: "t1", Status: model.StatusOnLot, Engine: model.Engine{Range: 600, Power: 300}},
+	}, gomock.Any()).Return(nil)
+
+	tests := []struct {
+		desc   string
+		cars   []*model.Car
+		atomic bool
+		errs   map[int]customErrors.InvalidFields
+		err    error
+	}{
+		{
+			"Best effort creates the valid cars",
+			[]*model.Car{
+				{Name: "Roadster", TrimID: "t1"},
+				{Name: "Model X", TrimID: "t2"},
+				{Name: "Model S", TrimID: "t1", Status: model.StatusOnLot, Engine: model.Engine{Range: 600}},
+			},
+			false,
+			map[int]customErrors.InvalidFields{1: invalidTrim},
+			nil,
+		},
+		{
+			"Atomic creates nothing if any car is invalid",
+			[]*model.Car{{Name: "Roadster", TrimID: "t1"}, {Name: "Model X", TrimID: "t2"}, {Name: "Model 3", Status: model.StatusSold}},
+			true,
+			map[int]customErrors.InvalidFields{1: invalidTrim, 2: {{
+				Path:    "/status",
+				Code:    customErrors.FieldInvalid,
+				Message: "a car cannot be created with status sold",
+				Allowed: []string{"in-transit", "on-lot"},
+			}}},
+			nil,
+		},
+		{"Trim not fetched", []*model.Car{{Name: "Roadster", TrimID: "t3"}}, true, nil, errors.New("DB error")},
+	}
+
+	svc := New(c, nil, tr, nil)
+
+	for i, tc := range tests {
+		errs, err := svc.CreateMany(tc.cars, tc.atomic)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.errs, errs, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestService_CreateManyVINs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCarStore(mockCtrl)
+
+	vin1, vin2, vin3 := "XP7YGCEL0YB000001", "XP7YGCEL0YB000002", "XP7YGCEL0YB000003"
+
+	c.EXPECT().GetByVINs([]string{vin1, vin2, vin1, vin3}).Return([]model.Car{{ID: "1", VIN: vin2}}, nil)
+	c.EXPECT().GetByVINs([]string{vin1}).Return(nil, errors.New("DB error"))
+
+	// only the first car with a free VIN is created
+	c.EXPECT().CreateMany([]*model.Car{
+		{Name: "Roadster", VIN: vin1, Status: model.StatusInTransit},
+		{Name: "Model 3", VIN: vin3, Status: model.StatusInTransit},
+		{Name: "Model Y", Status: model.StatusInTransit},
+	}, gomock.Any()).Return(nil)
+
+	svc := New(c, nil, nil, nil)
+
+	errs, err := svc.CreateMany([]*model.Car{
+		{Name: "Roadster", VIN: vin1},
+		{Name: "Model S", VIN: vin2},
+		{Name: "Model X", VIN: vin1},
+		{Name: "Model 3", VIN: vin3},
+		{Name: "Model Y"},
+	}, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]customErrors.InvalidFields{
+		1: {{Path: "/vin", Code: customErrors.FieldConflict, Message: "a car with VIN " + vin2 + " already exists"}},
+		2: {{Path: "/vin", Code: customErrors.FieldConflict, Message: "VIN " + vin1 + " is listed more than once"}},
+	}, errs)
+
+	_, err = svc.CreateMany([]*model.Car{{Name: "Roadster", VIN: vin1}}, false)
+
+	assert.Equal(t, errors.New("DB error"), err)
+}
+
+func TestService_CheckMany(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCarStore(mockCtrl)
+	tr := mocks.NewMockTrimStore(mockCtrl)
+
+	vin := "XP7YGCEL0YB000001"
+
+	// the cars are checked like they are on create, but never created
+	c.EXPECT().GetByVINs([]string{vin}).Return([]model.Car{{ID: "1", VIN: vin}}, nil)
+	tr.EXPECT().GetByID("t2").Return(nil, customErrors.TrimNotExists())
+
+	svc := New(c, nil, tr, nil)
+
+	errs, err := svc.CheckMany([]*model.Car{
+		{Name: "Roadster"},
+		{Name: "Model X", TrimID: "t2"},
+		{Name: "Model S", VIN: vin},
+		{Name: "Model 3", Status: model.StatusSold},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]customErrors.InvalidFields{
+		1: {{Path: "/trimId", Code: customErrors.FieldInvalid, Message: "trim t2 not exists"}},
+		2: {{Path: "/vin", Code: customErrors.FieldConflict, Message: "a car with VIN " + vin + " already exists"}},
+		3: {{Path: "/status", Code: customErrors.FieldInvalid, Message: "a car cannot be created with status sold",
+			Allowed: []string{"in-transit", "on-lot"}}},
+	}, errs)
+}
+
+func TestService_UpdateMany(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCarStore(mockCtrl)
+
+	price := &model.Price{Currency: "EUR", List: 5000000}
+	existing := []model.Car{
+		{ID: "1", Name: "Roadster", Status: model.StatusOnLot, Price: price, DealershipID: "d1", Engine: model.Engine{ID: "e1"}},
+		{ID: "2", Name: "Model S", DealershipID: "d2", Engine: model.Engine{ID: "e2"}},
+	}
+
+	c.EXPECT().GetByIDs([]string{"1", "2", "3", "1"}).Return(existing, nil)
+	c.EXPECT().UpdateMany([]*model.Car{
+		{ID: "1", Name: "Roadster Sport", Status: model.StatusOnLot, Price: price, DealershipID: "d1", Engine: model.Engine{ID: "e1"}},
+	}).Return(nil)
+	c.EXPECT().GetByIDs([]string{"1"}).Return(existing, nil)
+	c.EXPECT().UpdateMany(gomock.Any()).Return(errors.New("DB error"))
+
+	// car 1 keeps its VIN, car 3 takes the VIN of car 2 and car 4 the VIN claimed by car 1
+	vin1, vin2 := "XP7YGCEL0YB000001", "XP7YGCEL0YB000002"
+	existing = append(existing,
+		model.Car{ID: "3", DealershipID: "d1", Engine: model.Engine{ID: "e3"}}, model.Car{ID: "4", DealershipID: "d1", Engine: model.Engine{ID: "e4"}})
+
+	c.EXPECT().GetByIDs([]string{"1", "3", "4"}).Return(existing, nil)
+	c.EXPECT().GetByVINs([]string{vin1, vin2, vin1}).Return([]model.Car{{ID: "1", VIN: vin1}, {ID: "2", VIN: vin2}}, nil)
+	c.EXPECT().UpdateMany([]*model.Car{
+		{ID: "1", Name: "Roadster Sport", VIN: vin1, Status: model.StatusOnLot, Price: price, DealershipID: "d1", Engine: model.Engine{ID: "e1"}},
+	}).Return(nil)
+
+	notExists := func(path, id string) customErrors.InvalidFields {
+		return customErrors.InvalidFields{{Path: path, Code: customErrors.FieldInvalid, Message: "car " + id + " not exists or is listed more than once"}}
+	}
+
+	tests := []struct {
+		desc string
+		cars []*model.Car
+		errs map[int]customErrors.InvalidFields
+		err  error
+	}{
+		{
+			// car 2 is stocked at another dealership, car 1 is listed twice
+			"Missing and duplicate cars are reported",
+			[]*model.Car{{ID: "1", Name: "Roadster Sport"}, {ID: "2"}, {ID: "3"}, {ID: "1"}},
+			map[int]customErrors.InvalidFields{1: notExists("/carId", "2"), 2: notExists("/carId", "3"), 3: notExists("/carId", "1")},
+			nil,
+		},
+		{"Cars not updated", []*model.Car{{ID: "1"}}, nil, errors.New("DB error")},
+		{
+			"VINs of other cars are reported",
+			[]*model.Car{{ID: "1", Name: "Roadster Sport", VIN: vin1}, {ID: "3", VIN: vin2}, {ID: "4", VIN: vin1}},
+			map[int]customErrors.InvalidFields{
+				1: {{Path: "/vin", Code: customErrors.FieldConflict, Message: "a car with VIN " + vin2 + " already exists"}},
+				2: {{Path: "/vin", Code: customErrors.FieldConflict, Message: "VIN " + vin1 + " is listed more than once"}},
+			},
+			nil,
+		},
+	}
+
+	svc := New(c, nil, nil, nil)
+
+	for i, tc := range tests {
+		errs, err := svc.UpdateMany("d1", tc.cars, false)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.errs, errs, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestService_DeleteMany(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCarStore(mockCtrl)
+	a := mocks.NewMockAttachmentService(mockCtrl)
+
+	existing := []model.Car{
+		{ID: "1", DealershipID: "d1", Engine: model.Engine{ID: "e1"}},
+		{ID: "2", DealershipID: "d1", Engine: model.Engine{ID: "e2"}},
+	}
+	notExists := customErrors.InvalidFields{{Code: customErrors.FieldInvalid, Message: "car 3 not exists or is listed more than once"}}
+
+	c.EXPECT().GetByIDs([]string{"1", "2"}).Return(existing, nil)
+	c.EXPECT().GetOrderedIDs([]string{"1", "2"}).Return([]string{}, nil)
+	c.EXPECT().DeleteMany(existing).Return(nil)
+	a.EXPECT().DeleteByCar("1").Return(nil)
+
+	// the cars are deleted even if the content of their attachments is not
+	a.EXPECT().DeleteByCar("2").Return(errors.New("disk error"))
+
+	c.EXPECT().GetByIDs([]string{"1", "3"}).Return(existing[:1], nil)
+	c.EXPECT().GetOrderedIDs([]string{"1", "3"}).Return([]string{}, nil)
+
+	// car 2 is sold through an order, only car 1 is deleted
+	c.EXPECT().GetByIDs([]string{"1", "2"}).Return(existing, nil)
+	c.EXPECT().GetOrderedIDs([]string{"1", "2"}).Return([]string{"2"}, nil)
+	c.EXPECT().DeleteMany(existing[:1]).Return(nil)
+	a.EXPECT().DeleteByCar("1").Return(nil)
+
+	tests := []struct {
+		desc   string
+		ids    []string
+		atomic bool
+		errs   map[int]customErrors.InvalidFields
+	}{
+		{"Success", []string{"1", "2"}, true, map[int]customErrors.InvalidFields{}},
+		{"Atomic deletes nothing if any car is missing", []string{"1", "3"}, true, map[int]customErrors.InvalidFields{1: notExists}},
+		{
+			"Cars with orders are reported",
+			[]string{"1", "2"},
+			false,
+			map[int]customErrors.InvalidFields{1: {{Code: customErrors.FieldConflict, Message: "car 2 has orders"}}},
+		},
+	}
+
+	svc := New(c, nil, nil, a)
+
+	for i, tc := range tests {
+		errs, err := svc.DeleteMany("d1", tc.ids, tc.atomic)
+
+		assert.Nilf(t, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.errs, errs, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
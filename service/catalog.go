@@ -0,0 +1,350 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store"
+)
+
+// catalog serves brands and fuel types. As every car is validated against them, their names and the engine rules
+// of the fuel types are cached in memory and the cache is refreshed whenever the catalog is changed through this service.
+type catalog struct {
+	brandStore    store.BrandStore
+	fuelTypeStore store.FuelTypeStore
+	modelStore    store.CarModelStore
+	trimStore     store.TrimStore
+	engineStore   store.EngineStore
+
+	mu          sync.RWMutex
+	loaded      bool
+	brands      []string
+	fuelTypes   []string
+	engineRules map[string]model.EngineRule
+}
+
+//nolint:revive //catalog should not be exported
+func NewCatalog(b store.BrandStore, f store.FuelTypeStore, m store.CarModelStore, t store.TrimStore, e store.EngineStore) *catalog {
+	return &catalog{
+		brandStore:    b,
+		fuelTypeStore: f,
+		modelStore:    m,
+		trimStore:     t,
+		engineStore:   e,
+	}
+}
+
+func (c *catalog) GetBrands() ([]model.Brand, error) {
+	return c.brandStore.GetAll()
+}
+
+func (c *catalog) CreateBrand(brand *model.Brand) (*model.Brand, error) {
+	newBrand, err := c.brandStore.Create(brand)
+	if err != nil {
+		return nil, err
+	}
+
+	c.changed()
+
+	return newBrand, nil
+}
+
+// UpdateBrand leaves it to the store to report a missing brand, since the cache may lag behind other instances
+func (c *catalog) UpdateBrand(name string, brand *model.Brand) (*model.Brand, error) {
+	updatedBrand, err := c.brandStore.Update(name, brand)
+	if err != nil {
+		return nil, err
+	}
+
+	c.changed()
+
+	return updatedBrand, nil
+}
+
+func (c *catalog) DeleteBrand(name string) error {
+	err := c.brandStore.Delete(name)
+	if err != nil {
+		return err
+	}
+
+	c.changed()
+
+	return nil
+}
+
+func (c *catalog) GetFuelTypes() ([]model.FuelType, error) {
+	return c.fuelTypeStore.GetAll()
+}
+
+func (c *catalog) CreateFuelType(fuelType *model.FuelType) (*model.FuelType, error) {
+	newFuelType, err := c.fuelTypeStore.Create(fuelType)
+	if err != nil {
+		return nil, err
+	}
+
+	c.changed()
+
+	return newFuelType, nil
+}
+
+// UpdateFuelType leaves it to the store to report a missing fuel type, since the cache may lag behind other instances
+func (c *catalog) UpdateFuelType(name string, fuelType *model.FuelType) (*model.FuelType, error) {
+	updatedFuelType, err := c.fuelTypeStore.Update(name, fuelType)
+	if err != nil {
+		return nil, err
+	}
+
+	c.changed()
+
+	return updatedFuelType, nil
+}
+
+func (c *catalog) DeleteFuelType(name string) error {
+	err := c.fuelTypeStore.Delete(name)
+	if err != nil {
+		return err
+	}
+
+	c.changed()
+
+	return nil
+}
+
+func (c *catalog) GetModels(brand string) ([]model.CarModel, error) {
+	brands, err := c.Brands()
+	if err != nil {
+		return nil, err
+	}
+
+	if !contains(brands, brand) {
+		return nil, customErrors.BrandNotExists()
+	}
+
+	return c.modelStore.GetByBrand(brand)
+}
+
+func (c *catalog) CreateModel(carModel *model.CarModel) (*model.CarModel, error) {
+	return c.modelStore.Create(carModel)
+}
+
+func (c *catalog) UpdateModel(carModel *model.CarModel) (*model.CarModel, error) {
+	modelFromDB, err := c.modelStore.GetByID(carModel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	modelFromDB.Name = carModel.Name
+
+	return c.modelStore.Update(modelFromDB)
+}
+
+func (c *catalog) DeleteModel(id string) error {
+	return c.modelStore.Delete(id)
+}
+
+func (c *catalog) GetTrims(modelID string) ([]model.Trim, error) {
+	// distinguishes a missing model from a model without trims
+	_, err := c.modelStore.GetByID(modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.trimStore.GetByModel(modelID)
+}
+
+func (c *catalog) GetTrim(id string) (*model.Trim, error) {
+	return c.trimStore.GetByID(id)
+}
+
+func (c *catalog) CreateTrim(trim *model.Trim) (*model.Trim, error) {
+	if trim.ModelID == "" {
+		return nil, customErrors.InvalidFields{{
+			Path:    "/" + model.ParamModelID,
+			Code:    customErrors.FieldRequired,
+			Message: fmt.Sprintf("%v is required", model.ParamModelID),
+		}}
+	}
+
+	carModel, err := c.modelStore.GetByID(trim.ModelID)
+	if errors.Is(err, customErrors.ErrNotFound) {
+		return nil, customErrors.InvalidFields{{
+			Path:    "/" + model.ParamModelID,
+			Code:    customErrors.FieldInvalid,
+			Message: fmt.Sprintf("model %v not exists", trim.ModelID),
+		}}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	engine, err := c.engineStore.Create(&trim.Engine)
+	if err != nil {
+		return nil, err
+	}
+
+	trim.Engine = *engine
+
+	newTrim, err := c.trimStore.Create(trim)
+	if err != nil {
+		return nil, err
+	}
+
+	newTrim.Brand = carModel.Brand
+
+	return newTrim, nil
+}
+
+func (c *catalog) UpdateTrim(trim *model.Trim) (*model.Trim, error) {
+	trimFromDB, err := c.trimStore.GetByID(trim.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	trimFromDB.Name = trim.Name
+
+	_, err = c.trimStore.Update(trimFromDB)
+	if err != nil {
+		return nil, err
+	}
+
+	trim.Engine.ID = trimFromDB.Engine.ID
+
+	engine, err := c.engineStore.Update(&trim.Engine)
+	if err != nil {
+		return nil, err
+	}
+
+	trimFromDB.Engine = *engine
+
+	return trimFromDB, nil
+}
+
+func (c *catalog) DeleteTrim(id string) error {
+	trim, err := c.trimStore.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	err = c.trimStore.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return c.engineStore.Delete(trim.Engine.ID)
+}
+
+func (c *catalog) Brands() ([]string, error) {
+	err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.brands, nil
+}
+
+func (c *catalog) FuelTypes() ([]string, error) {
+	err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.fuelTypes, nil
+}
+
+func (c *catalog) EngineRules() (map[string]model.EngineRule, error) {
+	err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.engineRules, nil
+}
+
+func (c *catalog) Refresh() error {
+	brands, err := c.brandStore.GetAll()
+	if err != nil {
+		return err
+	}
+
+	fuelTypes, err := c.fuelTypeStore.GetAll()
+	if err != nil {
+		return err
+	}
+
+	// the cached slices are handed out to callers, so they are replaced and never modified in place
+	brandNames := make([]string, len(brands))
+	for i := range brands {
+		brandNames[i] = brands[i].Name
+	}
+
+	fuelTypeNames := make([]string, len(fuelTypes))
+	engineRules := make(map[string]model.EngineRule, len(fuelTypes))
+
+	for i := range fuelTypes {
+		fuelTypeNames[i] = fuelTypes[i].Name
+
+		if fuelTypes[i].Engine != nil {
+			engineRules[fuelTypes[i].Name] = *fuelTypes[i].Engine
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.brands = brandNames
+	c.fuelTypes = fuelTypeNames
+	c.engineRules = engineRules
+	c.loaded = true
+
+	return nil
+}
+
+// changed refreshes the cache after the catalog was changed,
+// if the refresh fails the cache is marked stale and reloaded on next use
+func (c *catalog) changed() {
+	c.mu.Lock()
+	c.loaded = false
+	c.mu.Unlock()
+
+	err := c.Refresh()
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// load fills the cache on first use
+func (c *catalog) load() error {
+	c.mu.RLock()
+	loaded := c.loaded
+	c.mu.RUnlock()
+
+	if loaded {
+		return nil
+	}
+
+	return c.Refresh()
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestCatalog_Cache(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	b := mocks.NewMockBrandStore(mockCtrl)
+	f := mocks.NewMockFuelTypeStore(mockCtrl)
+
+	// the cache is loaded once, and reloaded after every change
+	gomock.InOrder(
+		b.EXPECT().GetAll().Return([]model.Brand{{Name: "Tesla"}}, nil),
+		b.EXPECT().Create(&model.Brand{Name: "Audi"}).Return(&model.Brand{Name: "Audi"}, nil),
+		b.EXPECT().GetAll().Return([]model.Brand{{Name: "Audi"}, {Name: "Tesla"}}, nil),
+	)
+
+	electric := model.EngineRule{Required: []string{"range"}, Forbidden: []string{"displacement"}}
+
+	f.EXPECT().GetAll().Return([]model.FuelType{{Name: "Electric", Engine: &electric}}, nil).Times(2)
+
+	svc := NewCatalog(b, f, nil, nil, nil)
+
+	brands, err := svc.Brands()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Tesla"}, brands)
+
+	fuelTypes, err := svc.FuelTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Electric"}, fuelTypes)
+
+	engineRules, err := svc.EngineRules()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]model.EngineRule{"Electric": electric}, engineRules)
+
+	brand, err := svc.CreateBrand(&model.Brand{Name: "Audi"})
+	assert.Nil(t, err)
+	assert.Equal(t, &model.Brand{Name: "Audi"}, brand)
+
+	brands, err = svc.Brands()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Audi", "Tesla"}, brands)
+}
+
+func TestCatalog_CacheLoadError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	b := mocks.NewMockBrandStore(mockCtrl)
+	f := mocks.NewMockFuelTypeStore(mockCtrl)
+
+	b.EXPECT().GetAll().Return(nil, errors.New("DB error"))
+	b.EXPECT().GetAll().Return([]model.Brand{{Name: "Tesla"}}, nil)
+	f.EXPECT().GetAll().Return([]model.FuelType{{Name: "Electric"}}, nil)
+
+	svc := NewCatalog(b, f, nil, nil, nil)
+
+	// a failed load is retried on next use
+	_, err := svc.Brands()
+	assert.Equal(t, errors.New("DB error"), err)
+
+	brands, err := svc.Brands()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Tesla"}, brands)
+}
+
+func TestCatalog_UpdateBrand(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	b := mocks.NewMockBrandStore(mockCtrl)
+	f := mocks.NewMockFuelTypeStore(mockCtrl)
+
+	// the cache does not know Audi yet, which was created through another instance
+	gomock.InOrder(
+		b.EXPECT().GetAll().Return([]model.Brand{{Name: "Tesla"}}, nil),
+		b.EXPECT().Update("Audi", &model.Brand{Name: "Audi AG"}).Return(&model.Brand{Name: "Audi AG"}, nil),
+		b.EXPECT().GetAll().Return([]model.Brand{{Name: "Audi AG"}, {Name: "Tesla"}}, nil),
+		b.EXPECT().Update("Opel", &model.Brand{Name: "Opel AG"}).Return(nil, customErrors.BrandNotExists()),
+	)
+
+	f.EXPECT().GetAll().Return([]model.FuelType{{Name: "Electric"}}, nil).AnyTimes()
+
+	tests := []struct {
+		desc     string
+		name     string
+		input    *model.Brand
+		expected *model.Brand
+		err      error
+	}{
+		{"Success", "Audi", &model.Brand{Name: "Audi AG"}, &model.Brand{Name: "Audi AG"}, nil},
+		{"Brand not exists", "Opel", &model.Brand{Name: "Opel AG"}, nil, customErrors.BrandNotExists()},
+	}
+
+	svc := NewCatalog(b, f, nil, nil, nil)
+
+	_, err := svc.Brands()
+	assert.Nil(t, err)
+
+	for i, tc := range tests {
+		brand, err := svc.UpdateBrand(tc.name, tc.input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, brand, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	brands, err := svc.Brands()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Audi AG", "Tesla"}, brands)
+}
+
+func TestCatalog_DeleteFuelType(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	b := mocks.NewMockBrandStore(mockCtrl)
+	f := mocks.NewMockFuelTypeStore(mockCtrl)
+
+	conflict := customErrors.Conflict{Entity: "FuelType", Reason: "referenced by another entity"}
+
+	f.EXPECT().Delete("CNG").Return(nil)
+	b.EXPECT().GetAll().Return([]model.Brand{{Name: "Tesla"}}, nil)
+	f.EXPECT().GetAll().Return([]model.FuelType{{Name: "Electric"}}, nil)
+	f.EXPECT().Delete("Electric").Return(conflict)
+
+	tests := []struct {
+		desc string
+		name string
+		err  error
+	}{
+		{"Success", "CNG", nil},
+		{"Fuel type of existing cars", "Electric", conflict},
+	}
+
+	svc := NewCatalog(b, f, nil, nil, nil)
+
+	for i, tc := range tests {
+		err := svc.DeleteFuelType(tc.name)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestCatalog_GetModels(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	b := mocks.NewMockBrandStore(mockCtrl)
+	f := mocks.NewMockFuelTypeStore(mockCtrl)
+	m := mocks.NewMockCarModelStore(mockCtrl)
+
+	models := []model.CarModel{{ID: "m1", Brand: "Tesla", Name: "Model 3", CarCount: 2}}
+
+	b.EXPECT().GetAll().Return([]model.Brand{{Name: "Tesla"}}, nil)
+	f.EXPECT().GetAll().Return([]model.FuelType{{Name: "Electric"}}, nil)
+	m.EXPECT().GetByBrand("Tesla").Return(models, nil)
+
+	tests := []struct {
+		desc     string
+		brand    string
+		expected []model.CarModel
+		err      error
+	}{
+		{"Success", "Tesla", models, nil},
+		{"Brand not exists", "Audi", nil, customErrors.BrandNotExists()},
+	}
+
+	svc := NewCatalog(b, f, m, nil, nil)
+
+	for i, tc := range tests {
+		result, err := svc.GetModels(tc.brand)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, result, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestCatalog_CreateTrim(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	m := mocks.NewMockCarModelStore(mockCtrl)
+	tr := mocks.NewMockTrimStore(mockCtrl)
+	e := mocks.NewMockEngineStore(mockCtrl)
+
+	m.EXPECT().GetByID("m1").Return(&model.CarModel{ID: "m1", Brand: "Tesla", Name: "Model 3"}, nil)
+	m.EXPECT().GetByID("m2").Return(nil, customErrors.ModelNotExists())
+	e.EXPECT().Create(&model.Engine{Range: 500}).Return(&model.Engine{ID: "e1", Range: 500}, nil)
+	tr.EXPECT().Create(&model.Trim{ModelID: "m1", Name: "Long Range", Engine: model.Engine{ID: "e1", Range: 500}}).
+		Return(&model.Trim{ID: "t1", ModelID: "m1", Name: "Long Range", Engine: model.Engine{ID: "e1", Range: 500}}, nil)
+
+	tests := []struct {
+		desc     string
+		input    *model.Trim
+		expected *model.Trim
+		err      error
+	}{
+		{
+			"Success",
+			&model.Trim{ModelID: "m1", Name: "Long Range", Engine: model.Engine{Range: 500}},
+			&model.Trim{ID: "t1", ModelID: "m1", Name: "Long Range", Engine: model.Engine{ID: "e1", Range: 500}, Brand: "Tesla"},
+			nil,
+		},
+		{
+			"Model not exists",
+			&model.Trim{ModelID: "m2", Name: "Long Range"},
+			nil,
+			customErrors.InvalidFields{{Path: "/modelId", Code: customErrors.FieldInvalid, Message: "model m2 not exists"}},
+		},
+	}
+
+	svc := NewCatalog(nil, nil, m, tr, e)
+
+	for i, tc := range tests {
+		result, err := svc.CreateTrim(tc.input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, result, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestCatalog_DeleteTrim(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tr := mocks.NewMockTrimStore(mockCtrl)
+	e := mocks.NewMockEngineStore(mockCtrl)
+
+	conflict := customErrors.Conflict{Entity: "Trim", Reason: "referenced by another entity"}
+
+	tr.EXPECT().GetByID("t1").Return(&model.Trim{ID: "t1", Engine: model.Engine{ID: "e1"}}, nil)
+	tr.EXPECT().Delete("t1").Return(nil)
+	e.EXPECT().Delete("e1").Return(nil)
+	tr.EXPECT().GetByID("t2").Return(&model.Trim{ID: "t2", Engine: model.Engine{ID: "e2"}}, nil)
+	tr.EXPECT().Delete("t2").Return(conflict)
+	tr.EXPECT().GetByID("t3").Return(nil, customErrors.TrimNotExists())
+
+	tests := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{"Success", "t1", nil},
+		{"Trim of existing cars", "t2", conflict},
+		{"Trim not exists", "t3", customErrors.TrimNotExists()},
+	}
+
+	svc := NewCatalog(nil, nil, nil, tr, e)
+
+	for i, tc := range tests {
+		err := svc.DeleteTrim(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
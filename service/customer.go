@@ -0,0 +1,41 @@
+package service
+
+import (
+	"carAPI/model"
+	"carAPI/store"
+)
+
+type customerService struct {
+	store store.CustomerStore
+}
+
+//nolint:revive //customerService should not be exported
+func NewCustomer(c store.CustomerStore) customerService {
+	return customerService{store: c}
+}
+
+func (s customerService) GetAll() ([]model.Customer, error) {
+	return s.store.GetAll()
+}
+
+func (s customerService) GetByID(id string) (*model.Customer, error) {
+	return s.store.GetByID(id)
+}
+
+func (s customerService) Create(customer *model.Customer) (*model.Customer, error) {
+	return s.store.Create(customer)
+}
+
+func (s customerService) Update(customer *model.Customer) (*model.Customer, error) {
+	// updating a missing customer affects no rows, which is not an error of the store
+	_, err := s.store.GetByID(customer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.Update(customer)
+}
+
+func (s customerService) Delete(id string) error {
+	return s.store.Delete(id)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store"
+)
+
+type dealershipService struct {
+	store    store.DealershipStore
+	carStore store.CarStore
+}
+
+//nolint:revive //dealershipService should not be exported
+func NewDealership(d store.DealershipStore, c store.CarStore) dealershipService {
+	return dealershipService{store: d, carStore: c}
+}
+
+func (s dealershipService) GetAll() ([]model.Dealership, error) {
+	return s.store.GetAll()
+}
+
+func (s dealershipService) GetByID(id string) (*model.Dealership, error) {
+	return s.store.GetByID(id)
+}
+
+func (s dealershipService) Create(dealership *model.Dealership) (*model.Dealership, error) {
+	return s.store.Create(dealership)
+}
+
+func (s dealershipService) Update(dealership *model.Dealership) (*model.Dealership, error) {
+	// updating a missing dealership affects no rows, which is not an error of the store
+	_, err := s.store.GetByID(dealership.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.Update(dealership)
+}
+
+func (s dealershipService) Transfer(carID string, transfer *model.Transfer) (*model.Transfer, error) {
+	car, err := s.carStore.GetByID(carID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.store.GetByID(transfer.To)
+	if errors.Is(err, customErrors.ErrNotFound) {
+		return nil, invalidReference(model.ParamTo, "dealership", transfer.To)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if car.Status == model.StatusSold {
+		return nil, customErrors.Conflict{Entity: "Car", Reason: "car is sold"}
+	}
+
+	if car.DealershipID == transfer.To {
+		return nil, customErrors.Conflict{Entity: "Car", Reason: fmt.Sprintf("car is already at dealership %v", transfer.To)}
+	}
+
+	transfer.From = car.DealershipID
+	transfer.At = time.Now()
+
+	err = s.carStore.Transfer(carID, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (s dealershipService) GetTransfers(carID string) ([]model.Transfer, error) {
+	// distinguishes a missing car from a car which has never been transferred
+	_, err := s.carStore.GetByID(carID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.carStore.GetTransfers(carID)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestDealership_Update(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	d := mocks.NewMockDealershipStore(mockCtrl)
+
+	north := &model.Dealership{ID: "d2", Name: "North", City: "Hamburg"}
+
+	d.EXPECT().GetByID("d2").Return(&model.Dealership{ID: "d2", Name: "North"}, nil)
+	d.EXPECT().Update(north).Return(north, nil)
+	d.EXPECT().GetByID("d3").Return(nil, customErrors.DealershipNotExists())
+
+	tests := []struct {
+		desc       string
+		input      *model.Dealership
+		dealership *model.Dealership
+		err        error
+	}{
+		{"Success", north, north, nil},
+		{"Dealership not exists", &model.Dealership{ID: "d3", Name: "South"}, nil, customErrors.DealershipNotExists()},
+	}
+
+	svc := NewDealership(d, nil)
+
+	for i, tc := range tests {
+		dealership, err := svc.Update(tc.input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.dealership, dealership, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestDealership_Transfer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	d := mocks.NewMockDealershipStore(mockCtrl)
+	c := mocks.NewMockCarStore(mockCtrl)
+
+	c.EXPECT().GetByID("1").Return(&model.Car{ID: "1", Status: model.StatusOnLot, DealershipID: "d1"}, nil).Times(4)
+	c.EXPECT().GetByID("2").Return(&model.Car{ID: "2", Status: model.StatusSold, DealershipID: "d1"}, nil)
+	c.EXPECT().GetByID("3").Return(nil, customErrors.CarNotExists())
+	d.EXPECT().GetByID("d1").Return(&model.Dealership{ID: "d1"}, nil)
+	d.EXPECT().GetByID("d2").Return(&model.Dealership{ID: "d2"}, nil).Times(3)
+	d.EXPECT().GetByID("d3").Return(nil, customErrors.DealershipNotExists())
+
+	c.EXPECT().Transfer("1", gomock.Any()).DoAndReturn(func(id string, transfer *model.Transfer) error {
+		assert.Equal(t, "d1", transfer.From)
+		assert.False(t, transfer.At.IsZero())
+
+		return nil
+	})
+	c.EXPECT().Transfer("1", gomock.Any()).Return(errors.New("DB error"))
+
+	tests := []struct {
+		desc  string
+		carID string
+		to    string
+		err   error
+	}{
+		{"Success", "1", "d2", nil},
+		{"Car at the dealership already", "1", "d1", customErrors.Conflict{Entity: "Car", Reason: "car is already at dealership d1"}},
+		{"Dealership not exists", "1", "d3", customErrors.InvalidFields{
+			{Path: "/to", Code: customErrors.FieldInvalid, Message: "dealership d3 not exists"},
+		}},
+		{"Car sold", "2", "d2", customErrors.Conflict{Entity: "Car", Reason: "car is sold"}},
+		{"Car not exists", "3", "d2", customErrors.CarNotExists()},
+		{"DB error", "1", "d2", errors.New("DB error")},
+	}
+
+	svc := NewDealership(d, c)
+
+	for i, tc := range tests {
+		transfer, err := svc.Transfer(tc.carID, &model.Transfer{To: tc.to, Actor: "admin:1a2b3c4d"})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		if tc.err == nil {
+			assert.Equalf(t, "d2", transfer.To, "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
+
+func TestDealership_GetTransfers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCarStore(mockCtrl)
+
+	transfers := []model.Transfer{{From: "d1", To: "d2", Actor: "admin:1a2b3c4d"}}
+
+	c.EXPECT().GetByID("1").Return(&model.Car{ID: "1"}, nil)
+	c.EXPECT().GetTransfers("1").Return(transfers, nil)
+	c.EXPECT().GetByID("2").Return(nil, customErrors.CarNotExists())
+
+	tests := []struct {
+		desc      string
+		carID     string
+		transfers []model.Transfer
+		err       error
+	}{
+		{"Success", "1", transfers, nil},
+		{"Car not exists", "2", nil, customErrors.CarNotExists()},
+	}
+
+	svc := NewDealership(nil, c)
+
+	for i, tc := range tests {
+		result, err := svc.GetTransfers(tc.carID)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.transfers, result, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
@@ -1,19 +1,34 @@
 package service
 
 import (
+	"io"
+
+	customErrors "carAPI/custom-errors"
 	"carAPI/model"
 )
 
 type CarService interface {
 
-	// GetAll takes two params- brand and withEngine
-	// if empty string is passed to brand, then cars of all brands are fetched
-	GetAll(brand string, withEngine bool) ([]model.Car, error)
+	// GetAll takes two params- filter and withEngine
+	// if a zero filter is passed, then all cars are fetched
+	GetAll(filter model.CarFilter, withEngine bool) ([]model.Car, error)
+
+	// Page fetches up to limit cars matching filter from offset on, without their engines, and the number of all cars
+	// matching filter
+	Page(filter model.CarFilter, limit, offset int) ([]model.Car, int, error)
+
+	// Each passes the cars matching filter with their engines to fn one at a time, as they are read from the DB.
+	// It stops at the first error of fn and returns it
+	Each(filter model.CarFilter, fn func(car *model.Car) error) error
 
 	// GetByID fetches a car with a given carID from DB
 	GetByID(id string) (*model.Car, error)
 
-	// Create creates a car and its underlying engine in the DB
+	// GetByVIN fetches the car with a given VIN from DB
+	GetByVIN(vin string) (*model.Car, error)
+
+	// Create creates a car and its underlying engine in the DB, new cars are in transit unless created on the lot.
+	// New cars have no price, the price of a car is set through SetPrice
 	Create(car *model.Car) (*model.Car, error)
 
 	// Update updates an existing car in DB
@@ -21,4 +36,197 @@ type CarService interface {
 
 	// Delete deletes the car with given ID from the DB
 	Delete(id string) error
+
+	// Transition changes the status of the car with given ID, only the transitions of the status state machine are allowed
+	Transition(id string, to model.Status) (*model.Car, error)
+
+	// GetStatusHistory fetches all status changes of the car with given ID, oldest first
+	GetStatusHistory(id string) ([]model.StatusChange, error)
+
+	// SetPrice changes the price of the car with given ID and records the change with its actor and reason
+	SetPrice(id string, change *model.PriceChange) (*model.Car, error)
+
+	// GetPriceHistory fetches all price changes of the car with given ID, oldest first
+	GetPriceHistory(id string) ([]model.PriceChange, error)
+
+	// CreateMany creates cars like Create, all in one transaction. The invalid fields of every car which cannot be created
+	// are returned by its index, if atomic is set no car is created when any car is invalid
+	CreateMany(cars []*model.Car, atomic bool) (map[int]customErrors.InvalidFields, error)
+
+	// CheckMany checks cars like CreateMany without creating any of them,
+	// the invalid fields of every car which cannot be created are returned by its index
+	CheckMany(cars []*model.Car) (map[int]customErrors.InvalidFields, error)
+
+	// UpdateMany updates cars of the dealership with given ID like Update, all in one transaction.
+	// The invalid fields of every car which cannot be updated are returned by its index,
+	// if atomic is set no car is updated when any car is invalid
+	UpdateMany(dealershipID string, cars []*model.Car, atomic bool) (map[int]customErrors.InvalidFields, error)
+
+	// DeleteMany deletes the cars of the dealership with given IDs, all in one transaction.
+	// The invalid fields of every ID which cannot be deleted are returned by its index,
+	// if atomic is set no car is deleted when any ID is invalid
+	DeleteMany(dealershipID string, ids []string, atomic bool) (map[int]customErrors.InvalidFields, error)
+}
+
+type CatalogService interface {
+	// GetBrands fetches all brands
+	GetBrands() ([]model.Brand, error)
+
+	// CreateBrand creates a new brand
+	CreateBrand(brand *model.Brand) (*model.Brand, error)
+
+	// UpdateBrand renames the brand with given name
+	UpdateBrand(name string, brand *model.Brand) (*model.Brand, error)
+
+	// DeleteBrand deletes the brand with given name, brands of existing cars cannot be deleted
+	DeleteBrand(name string) error
+
+	// GetFuelTypes fetches all fuel types
+	GetFuelTypes() ([]model.FuelType, error)
+
+	// CreateFuelType creates a new fuel type with its engine rule
+	CreateFuelType(fuelType *model.FuelType) (*model.FuelType, error)
+
+	// UpdateFuelType renames the fuel type with given name and replaces its engine rule if one is given
+	UpdateFuelType(name string, fuelType *model.FuelType) (*model.FuelType, error)
+
+	// DeleteFuelType deletes the fuel type with given name, fuel types of existing cars cannot be deleted
+	DeleteFuelType(name string) error
+
+	// GetModels fetches all models of the given brand with the number of their cars
+	GetModels(brand string) ([]model.CarModel, error)
+
+	// CreateModel creates a new model of an existing brand
+	CreateModel(carModel *model.CarModel) (*model.CarModel, error)
+
+	// UpdateModel renames an existing model, the brand of a model cannot be changed
+	UpdateModel(carModel *model.CarModel) (*model.CarModel, error)
+
+	// DeleteModel deletes the model with given ID, models with trims cannot be deleted
+	DeleteModel(id string) error
+
+	// GetTrims fetches all trims of the model with given ID with the number of their cars
+	GetTrims(modelID string) ([]model.Trim, error)
+
+	// GetTrim fetches the trim with given ID and its default engine
+	GetTrim(id string) (*model.Trim, error)
+
+	// CreateTrim creates a new trim of an existing model and its default engine
+	CreateTrim(trim *model.Trim) (*model.Trim, error)
+
+	// UpdateTrim updates the name and default engine of an existing trim
+	UpdateTrim(trim *model.Trim) (*model.Trim, error)
+
+	// DeleteTrim deletes the trim with given ID and its default engine, trims of existing cars cannot be deleted
+	DeleteTrim(id string) error
+
+	// Brands returns the cached names of all brands
+	Brands() ([]string, error)
+
+	// FuelTypes returns the cached names of all fuel types
+	FuelTypes() ([]string, error)
+
+	// EngineRules returns the cached engine rules of all fuel types, keyed by fuel type
+	EngineRules() (map[string]model.EngineRule, error)
+
+	// Refresh reloads the cached brand and fuel type names and engine rules from the DB
+	Refresh() error
+}
+
+type CustomerService interface {
+	// GetAll fetches all customers, ordered by name
+	GetAll() ([]model.Customer, error)
+
+	// GetByID fetches the customer with given ID
+	GetByID(id string) (*model.Customer, error)
+
+	// Create creates a new customer, the email of a customer is unique
+	Create(customer *model.Customer) (*model.Customer, error)
+
+	// Update updates an existing customer
+	Update(customer *model.Customer) (*model.Customer, error)
+
+	// Delete deletes the customer with given ID, customers with orders cannot be deleted
+	Delete(id string) error
+}
+
+type OrderService interface {
+	// GetAll fetches all orders matching filter, newest first
+	GetAll(filter model.OrderFilter) ([]model.Order, error)
+
+	// GetByID fetches the order with given ID
+	GetByID(id string) (*model.Order, error)
+
+	// Create creates an open order of an existing customer for a car which can be sold, in the currency of the price of the car
+	Create(order *model.Order) (*model.Order, error)
+
+	// Update updates the amounts and payment status of an open order, its customer and car cannot be changed
+	Update(order *model.Order) (*model.Order, error)
+
+	// Cancel cancels an open order, the car of the order is not changed
+	Cancel(id string) (*model.Order, error)
+
+	// Complete completes an open and paid order, its car is sold in the same transaction
+	Complete(id string) (*model.Order, error)
+}
+
+type BookingService interface {
+	// GetByCar fetches all bookings of the car with given ID, ordered by start
+	GetByCar(carID string) ([]model.Booking, error)
+
+	// GetByID fetches the booking with given ID
+	GetByID(id string) (*model.Booking, error)
+
+	// Create books a car for a time slot, a conflict is returned if the slot overlaps an active booking of the car.
+	// Holds expire the configured hold duration after their slot starts, or after they are created if it has started
+	Create(booking *model.Booking) (*model.Booking, error)
+
+	// Cancel cancels an active booking, its slot becomes free again
+	Cancel(id string) (*model.Booking, error)
+
+	// Availability returns the parts of slot in which the car with given ID is not booked
+	Availability(carID string, slot model.Slot) ([]model.Slot, error)
+}
+
+type DealershipService interface {
+	// GetAll fetches all dealerships, ordered by name
+	GetAll() ([]model.Dealership, error)
+
+	// GetByID fetches the dealership with given ID
+	GetByID(id string) (*model.Dealership, error)
+
+	// Create creates a new dealership, the name of a dealership is unique
+	Create(dealership *model.Dealership) (*model.Dealership, error)
+
+	// Update updates the name and city of an existing dealership
+	Update(dealership *model.Dealership) (*model.Dealership, error)
+
+	// Transfer moves the car with given ID to the dealership transfer.To and records the transfer with its actor,
+	// sold cars cannot be transferred
+	Transfer(carID string, transfer *model.Transfer) (*model.Transfer, error)
+
+	// GetTransfers fetches all transfers of the car with given ID, oldest first
+	GetTransfers(carID string) ([]model.Transfer, error)
+}
+
+type AttachmentService interface {
+	// GetByCar fetches all attachments of the car with given ID, oldest first
+	GetByCar(carID string) ([]model.Attachment, error)
+
+	// Upload stores data as a new attachment of the car attachment.CarID, a thumbnail is generated for photos
+	Upload(attachment *model.Attachment, data []byte) (*model.Attachment, error)
+
+	// Open returns the attachment with given ID of the car with given ID and a reader of its content,
+	// the caller must close the reader
+	Open(carID, id string) (*model.Attachment, io.ReadCloser, error)
+
+	// OpenThumbnail returns the attachment with given ID of the car with given ID and a reader of its thumbnail,
+	// the caller must close the reader
+	OpenThumbnail(carID, id string) (*model.Attachment, io.ReadCloser, error)
+
+	// Delete deletes the attachment with given ID of the car with given ID and its content
+	Delete(carID, id string) error
+
+	// DeleteByCar deletes the content of all attachments of the car with given ID, the attachments are deleted with the car
+	DeleteByCar(carID string) error
 }
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store"
+)
+
+type orderService struct {
+	orderStore    store.OrderStore
+	customerStore store.CustomerStore
+	carStore      store.CarStore
+}
+
+//nolint:revive //orderService should not be exported
+func NewOrder(o store.OrderStore, cu store.CustomerStore, c store.CarStore) orderService {
+	return orderService{
+		orderStore:    o,
+		customerStore: cu,
+		carStore:      c,
+	}
+}
+
+func (s orderService) GetAll(filter model.OrderFilter) ([]model.Order, error) {
+	return s.orderStore.Get(filter)
+}
+
+func (s orderService) GetByID(id string) (*model.Order, error) {
+	return s.orderStore.GetByID(id)
+}
+
+func (s orderService) Create(order *model.Order) (*model.Order, error) {
+	errs := requiredReferences(order)
+	if len(errs) != 0 {
+		return nil, errs
+	}
+
+	_, err := s.customerStore.GetByID(order.CustomerID)
+	if errors.Is(err, customErrors.ErrNotFound) {
+		return nil, invalidReference(model.ParamCustomerID, "customer", order.CustomerID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	car, err := s.carStore.GetByID(order.CarID)
+	if errors.Is(err, customErrors.ErrNotFound) {
+		return nil, invalidReference(model.ParamCarID, "car", order.CarID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// cars which cannot be sold right now cannot be ordered either
+	if !canTransition(car.Status, model.StatusSold) {
+		return nil, customErrors.Conflict{Entity: "Order", Reason: fmt.Sprintf("car is %v", car.Status)}
+	}
+
+	fields := checkCurrency(order, car)
+	if fields != nil {
+		return nil, fields
+	}
+
+	if order.PaymentStatus == "" {
+		order.PaymentStatus = model.PaymentPending
+	}
+
+	order.Status = model.OrderOpen
+	order.CreatedAt = time.Now()
+	order.CompletedAt = nil
+	order.Total = total(order)
+
+	return s.orderStore.Create(order)
+}
+
+func (s orderService) Update(order *model.Order) (*model.Order, error) {
+	orderFromDB, err := s.openOrder(order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the customer and car of an order cannot be changed
+	order.CustomerID = orderFromDB.CustomerID
+	order.CarID = orderFromDB.CarID
+	order.Status = orderFromDB.Status
+	order.CreatedAt = orderFromDB.CreatedAt
+
+	if order.PaymentStatus == "" {
+		order.PaymentStatus = orderFromDB.PaymentStatus
+	}
+
+	car, err := s.carStore.GetByID(order.CarID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := checkCurrency(order, car)
+	if fields != nil {
+		return nil, fields
+	}
+
+	order.Total = total(order)
+
+	return s.orderStore.Update(order)
+}
+
+func (s orderService) Cancel(id string) (*model.Order, error) {
+	order, err := s.openOrder(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.orderStore.Cancel(id)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Status = model.OrderCancelled
+
+	return order, nil
+}
+
+func (s orderService) Complete(id string) (*model.Order, error) {
+	order, err := s.openOrder(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.PaymentStatus != model.PaymentPaid {
+		return nil, customErrors.Conflict{
+			Entity: "Order",
+			Reason: fmt.Sprintf("payment is %v, only paid orders can be completed", order.PaymentStatus),
+		}
+	}
+
+	car, err := s.carStore.GetByID(order.CarID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canTransition(car.Status, model.StatusSold) {
+		return nil, customErrors.Conflict{Entity: "Car", Reason: fmt.Sprintf("status cannot change from %v to %v", car.Status, model.StatusSold)}
+	}
+
+	// the price of the car may have changed its currency since the order was made
+	fields := checkCurrency(order, car)
+	if fields != nil {
+		return nil, fields
+	}
+
+	at := time.Now()
+
+	err = s.orderStore.Complete(order, car.Status, at)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Status = model.OrderCompleted
+	order.CompletedAt = &at
+
+	return order, nil
+}
+
+// openOrder fetches the order with given ID, a conflict is returned if it is completed or cancelled
+func (s orderService) openOrder(id string) (*model.Order, error) {
+	order, err := s.orderStore.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != model.OrderOpen {
+		return nil, customErrors.Conflict{Entity: "Order", Reason: fmt.Sprintf("order is %v", order.Status)}
+	}
+
+	return order, nil
+}
+
+// requiredReferences reports the customer and car of a new order if they are missing
+func requiredReferences(order *model.Order) customErrors.InvalidFields {
+	var errs customErrors.InvalidFields
+
+	references := []struct {
+		param string
+		id    string
+	}{
+		{model.ParamCustomerID, order.CustomerID},
+		{model.ParamCarID, order.CarID},
+	}
+
+	for _, ref := range references {
+		if ref.id == "" {
+			errs = append(errs, customErrors.FieldError{
+				Path:    "/" + ref.param,
+				Code:    customErrors.FieldRequired,
+				Message: fmt.Sprintf("%v is required", ref.param),
+			})
+		}
+	}
+
+	return errs
+}
+
+func invalidReference(param, entity, id string) customErrors.InvalidFields {
+	return customErrors.InvalidFields{{
+		Path:    "/" + param,
+		Code:    customErrors.FieldInvalid,
+		Message: fmt.Sprintf("%v %v not exists", entity, id),
+	}}
+}
+
+// checkCurrency reports the currency of order if it is not the currency of the price of its car,
+// cars without a price cannot be sold through an order
+func checkCurrency(order *model.Order, car *model.Car) customErrors.InvalidFields {
+	if car.Price == nil {
+		return customErrors.InvalidFields{{
+			Path:    "/" + model.ParamCarID,
+			Code:    customErrors.FieldInvalid,
+			Message: fmt.Sprintf("car %v has no price", car.ID),
+		}}
+	}
+
+	if order.Currency != car.Price.Currency {
+		return customErrors.InvalidFields{{
+			Path:    "/" + model.ParamCurrency,
+			Code:    customErrors.FieldInvalid,
+			Message: fmt.Sprintf("%v must be the currency of the price of the car", model.ParamCurrency),
+			Allowed: []string{car.Price.Currency},
+		}}
+	}
+
+	return nil
+}
+
+// total is the amount the customer pays for order
+func total(order *model.Order) int64 {
+	return order.AgreedPrice - order.Discount + order.Tax
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+func TestOrder_Create(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	o := mocks.NewMockOrderStore(mockCtrl)
+	cu := mocks.NewMockCustomerStore(mockCtrl)
+	c := mocks.NewMockCarStore(mockCtrl)
+
+	price := &model.Price{Currency: "EUR", List: 9000000}
+
+	cu.EXPECT().GetByID("c1").Return(&model.Customer{ID: "c1"}, nil).Times(5)
+	cu.EXPECT().GetByID("c2").Return(nil, customErrors.CustomerNotExists())
+	c.EXPECT().GetByID("car1").Return(&model.Car{ID: "car1", Status: model.StatusOnLot, Price: price}, nil)
+	c.EXPECT().GetByID("car4").Return(&model.Car{ID: "car4", Status: model.StatusOnLot}, nil)
+	c.EXPECT().GetByID("car5").Return(&model.Car{ID: "car5", Status: model.StatusOnLot, Price: &model.Price{Currency: "USD", List: 9000000}}, nil)
+	c.EXPECT().GetByID("car2").Return(&model.Car{ID: "car2", Status: model.StatusSold}, nil)
+	c.EXPECT().GetByID("car3").Return(nil, customErrors.CarNotExists())
+	o.EXPECT().Create(gomock.Any()).DoAndReturn(func(order *model.Order) (*model.Order, error) {
+		assert.Equal(t, model.OrderOpen, order.Status)
+		assert.Equal(t, model.PaymentPending, order.PaymentStatus)
+		assert.Equal(t, int64(10591000), order.Total)
+		assert.False(t, order.CreatedAt.IsZero())
+
+		order.ID = "o1"
+
+		return order, nil
+	})
+
+	tests := []struct {
+		desc       string
+		customerID string
+		carID      string
+		err        error
+	}{
+		{"Success", "c1", "car1", nil},
+		{"Car sold", "c1", "car2", customErrors.Conflict{Entity: "Order", Reason: "car is sold"}},
+		{"Car not exists", "c1", "car3", customErrors.InvalidFields{
+			{Path: "/carId", Code: customErrors.FieldInvalid, Message: "car car3 not exists"},
+		}},
+		{"Car without a price", "c1", "car4", customErrors.InvalidFields{
+			{Path: "/carId", Code: customErrors.FieldInvalid, Message: "car car4 has no price"},
+		}},
+		{"Currency of another price", "c1", "car5", customErrors.InvalidFields{
+			{Path: "/currency", Code: customErrors.FieldInvalid, Message: "currency must be the currency of the price of the car", Allowed: []string{"USD"}},
+		}},
+		{"Customer not exists", "c2", "car1", customErrors.InvalidFields{
+			{Path: "/customerId", Code: customErrors.FieldInvalid, Message: "customer c2 not exists"},
+		}},
+		{"Missing references", "", "", customErrors.InvalidFields{
+			{Path: "/customerId", Code: customErrors.FieldRequired, Message: "customerId is required"},
+			{Path: "/carId", Code: customErrors.FieldRequired, Message: "carId is required"},
+		}},
+	}
+
+	svc := NewOrder(o, cu, c)
+
+	for i, tc := range tests {
+		_, err := svc.Create(&model.Order{
+			CustomerID: tc.customerID, CarID: tc.carID, Currency: "EUR", AgreedPrice: 8999900, Discount: 99900, Tax: 1691000,
+		})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestOrder_Update(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	o := mocks.NewMockOrderStore(mockCtrl)
+
+	open := &model.Order{ID: "o1", CustomerID: "c1", CarID: "car1", Status: model.OrderOpen, PaymentStatus: model.PaymentPending}
+	expected := &model.Order{
+		ID: "o1", CustomerID: "c1", CarID: "car1", Currency: "EUR", AgreedPrice: 100, Discount: 10, Tax: 20, Total: 110,
+		Status: model.OrderOpen, PaymentStatus: model.PaymentDeposit,
+	}
+
+	c := mocks.NewMockCarStore(mockCtrl)
+
+	o.EXPECT().GetByID("o1").Return(open, nil)
+	c.EXPECT().GetByID("car1").Return(&model.Car{ID: "car1", Price: &model.Price{Currency: "EUR", List: 100}}, nil)
+	o.EXPECT().Update(expected).Return(expected, nil)
+	o.EXPECT().GetByID("o2").Return(&model.Order{ID: "o2", Status: model.OrderCancelled}, nil)
+	o.EXPECT().GetByID("o3").Return(&model.Order{ID: "o3", CarID: "car3", Status: model.OrderOpen}, nil)
+	c.EXPECT().GetByID("car3").Return(&model.Car{ID: "car3", Price: &model.Price{Currency: "USD", List: 100}}, nil)
+
+	tests := []struct {
+		desc     string
+		id       string
+		expected *model.Order
+		err      error
+	}{
+		{"Success", "o1", expected, nil},
+		{"Order cancelled", "o2", nil, customErrors.Conflict{Entity: "Order", Reason: "order is cancelled"}},
+		{"Currency of another price", "o3", nil, customErrors.InvalidFields{
+			{Path: "/currency", Code: customErrors.FieldInvalid, Message: "currency must be the currency of the price of the car", Allowed: []string{"USD"}},
+		}},
+	}
+
+	svc := NewOrder(o, nil, c)
+
+	for i, tc := range tests {
+		// the customer and car are ignored on update
+		order, err := svc.Update(&model.Order{
+			ID: tc.id, CustomerID: "c9", CarID: "car9", Currency: "EUR", AgreedPrice: 100, Discount: 10, Tax: 20,
+			PaymentStatus: model.PaymentDeposit,
+		})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, order, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestOrder_Complete(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	o := mocks.NewMockOrderStore(mockCtrl)
+	c := mocks.NewMockCarStore(mockCtrl)
+
+	paid := &model.Order{ID: "o1", CarID: "car1", Currency: "EUR", Status: model.OrderOpen, PaymentStatus: model.PaymentPaid}
+
+	o.EXPECT().GetByID("o1").Return(paid, nil)
+	c.EXPECT().GetByID("car1").Return(&model.Car{ID: "car1", Status: model.StatusReserved, Price: &model.Price{Currency: "EUR"}}, nil)
+	o.EXPECT().Complete(paid, model.StatusReserved, gomock.Any()).Return(nil)
+
+	o.EXPECT().GetByID("o2").Return(&model.Order{ID: "o2", Status: model.OrderOpen, PaymentStatus: model.PaymentDeposit}, nil)
+
+	o.EXPECT().GetByID("o3").Return(&model.Order{ID: "o3", CarID: "car3", Status: model.OrderOpen, PaymentStatus: model.PaymentPaid}, nil)
+	c.EXPECT().GetByID("car3").Return(&model.Car{ID: "car3", Status: model.StatusInTransit}, nil)
+
+	o.EXPECT().GetByID("o4").Return(&model.Order{ID: "o4", Status: model.OrderCompleted}, nil)
+	o.EXPECT().GetByID("o5").Return(nil, customErrors.OrderNotExists())
+
+	// the price of the car has been changed to another currency since the order was made
+	o.EXPECT().GetByID("o6").Return(&model.Order{ID: "o6", CarID: "car6", Currency: "EUR", Status: model.OrderOpen, PaymentStatus: model.PaymentPaid}, nil)
+	c.EXPECT().GetByID("car6").Return(&model.Car{ID: "car6", Status: model.StatusOnLot, Price: &model.Price{Currency: "USD"}}, nil)
+
+	tests := []struct {
+		desc   string
+		id     string
+		status model.OrderStatus
+		err    error
+	}{
+		{"Success", "o1", model.OrderCompleted, nil},
+		{"Order not paid", "o2", "", customErrors.Conflict{Entity: "Order", Reason: "payment is deposit-paid, only paid orders can be completed"}},
+		{"Car cannot be sold", "o3", "", customErrors.Conflict{Entity: "Car", Reason: "status cannot change from in-transit to sold"}},
+		{"Order completed", "o4", "", customErrors.Conflict{Entity: "Order", Reason: "order is completed"}},
+		{"Order not exists", "o5", "", customErrors.OrderNotExists()},
+		{"Currency of another price", "o6", "", customErrors.InvalidFields{
+			{Path: "/currency", Code: customErrors.FieldInvalid, Message: "currency must be the currency of the price of the car", Allowed: []string{"USD"}},
+		}},
+	}
+
+	svc := NewOrder(o, nil, c)
+
+	for i, tc := range tests {
+		order, err := svc.Complete(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		if err == nil {
+			assert.Equalf(t, tc.status, order.Status, "Testcase[%v] (%v)", i, tc.desc)
+			assert.NotNilf(t, order.CompletedAt, "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package service
 
 import (
+	"fmt"
+	"log"
+	"time"
+
+	customErrors "carAPI/custom-errors"
 	"carAPI/model"
 	"carAPI/store"
 )
@@ -8,18 +13,22 @@ import (
 type service struct {
 	carStore    store.CarStore
 	engineStore store.EngineStore
+	trimStore   store.TrimStore
+	attachments AttachmentService
 }
 
 //nolint:revive //service should not be exported
-func New(c store.CarStore, e store.EngineStore) service {
+func New(c store.CarStore, e store.EngineStore, t store.TrimStore, a AttachmentService) service {
 	return service{
 		carStore:    c,
 		engineStore: e,
+		trimStore:   t,
+		attachments: a,
 	}
 }
 
-func (s service) GetAll(brand string, withEngine bool) ([]model.Car, error) {
-	cars, err := s.carStore.GetByBrand(brand)
+func (s service) GetAll(filter model.CarFilter, withEngine bool) ([]model.Car, error) {
+	cars, err := s.carStore.Get(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -47,12 +56,34 @@ func (s service) GetAll(brand string, withEngine bool) ([]model.Car, error) {
 	return cars, nil
 }
 
+func (s service) Page(filter model.CarFilter, limit, offset int) ([]model.Car, int, error) {
+	return s.carStore.Page(filter, limit, offset)
+}
+
+func (s service) Each(filter model.CarFilter, fn func(car *model.Car) error) error {
+	return s.carStore.Each(filter, fn)
+}
+
 func (s service) GetByID(id string) (*model.Car, error) {
 	car, err := s.carStore.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	return s.withEngine(car)
+}
+
+func (s service) GetByVIN(vin string) (*model.Car, error) {
+	car, err := s.carStore.GetByVIN(vin)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withEngine(car)
+}
+
+// withEngine fetches the engine of car
+func (s service) withEngine(car *model.Car) (*model.Car, error) {
 	engine, err := s.engineStore.GetByID(car.Engine.ID)
 	if err != nil {
 		return nil, err
@@ -64,6 +95,19 @@ func (s service) GetByID(id string) (*model.Car, error) {
 }
 
 func (s service) Create(car *model.Car) (*model.Car, error) {
+	err := initialStatus(car)
+	if err != nil {
+		return nil, err
+	}
+
+	// new cars are priced through a price change, so that every price is recorded with its actor and reason
+	car.Price = nil
+
+	err = s.applyTrim(car)
+	if err != nil {
+		return nil, err
+	}
+
 	engine, err := s.engineStore.Create(&car.Engine)
 	if err != nil {
 		return nil, err
@@ -71,7 +115,7 @@ func (s service) Create(car *model.Car) (*model.Car, error) {
 
 	car.Engine.ID = engine.ID
 
-	newCar, err := s.carStore.Create(car)
+	newCar, err := s.carStore.Create(car, time.Now())
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +131,17 @@ func (s service) Update(car *model.Car) (*model.Car, error) {
 		return nil, err
 	}
 
+	err = s.applyTrim(car)
+	if err != nil {
+		return nil, err
+	}
+
+	// the status is only changed through transitions, the price through price changes
+	// and the dealership through transfers
+	car.Status = carFromDB.Status
+	car.Price = carFromDB.Price
+	car.DealershipID = carFromDB.DealershipID
+
 	updatedCar, err := s.carStore.Update(car)
 	if err != nil {
 		return nil, err
@@ -120,5 +175,126 @@ func (s service) Delete(id string) error {
 		return err
 	}
 
+	// the attachments of the car are deleted with it by the DB, only their content is left to delete.
+	// The car is gone at this point, so content which cannot be deleted is only logged
+	err = s.attachments.DeleteByCar(id)
+	if err != nil {
+		log.Println(err)
+	}
+
+	return nil
+}
+
+func (s service) Transition(id string, to model.Status) (*model.Car, error) {
+	car, err := s.carStore.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canTransition(car.Status, to) {
+		return nil, customErrors.Conflict{Entity: "Car", Reason: fmt.Sprintf("status cannot change from %v to %v", car.Status, to)}
+	}
+
+	err = s.carStore.Transition(id, &model.StatusChange{From: car.Status, To: to, At: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+
+	car.Status = to
+
+	return s.withEngine(car)
+}
+
+func (s service) GetStatusHistory(id string) ([]model.StatusChange, error) {
+	// distinguishes a missing car from a car without history
+	_, err := s.carStore.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.carStore.GetStatusHistory(id)
+}
+
+func (s service) SetPrice(id string, change *model.PriceChange) (*model.Car, error) {
+	car, err := s.carStore.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	change.At = time.Now()
+
+	err = s.carStore.SetPrice(id, change)
+	if err != nil {
+		return nil, err
+	}
+
+	price := change.Price
+	car.Price = &price
+
+	return s.withEngine(car)
+}
+
+func (s service) GetPriceHistory(id string) ([]model.PriceChange, error) {
+	// distinguishes a missing car from a car which has never been priced
+	_, err := s.carStore.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.carStore.GetPriceHistory(id)
+}
+
+// transitions is the state machine of the car status, it maps every status to the statuses a car can change to from it
+func transitions() map[model.Status][]model.Status {
+	return map[model.Status][]model.Status{
+		model.StatusInTransit: {model.StatusOnLot},
+		model.StatusOnLot:     {model.StatusReserved, model.StatusSold},
+		model.StatusReserved:  {model.StatusOnLot, model.StatusSold},
+		model.StatusSold:      {model.StatusReturned},
+		model.StatusReturned:  {model.StatusOnLot},
+	}
+}
+
+func canTransition(from, to model.Status) bool {
+	for _, status := range transitions()[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// initialStatus defaults the status of a new car to in transit, a car can only be created in transit or on the lot
+func initialStatus(car *model.Car) error {
+	switch car.Status {
+	case "":
+		car.Status = model.StatusInTransit
+	case model.StatusInTransit, model.StatusOnLot:
+	default:
+		return customErrors.InvalidFields{{
+			Path:    "/" + model.ParamStatus,
+			Code:    customErrors.FieldInvalid,
+			Message: fmt.Sprintf("a car cannot be created with status %v", car.Status),
+			Allowed: []string{string(model.StatusInTransit), string(model.StatusOnLot)},
+		}}
+	}
+
+	return nil
+}
+
+// applyTrim fills the engine params missing from car with the default engine of its trim
+func (s service) applyTrim(car *model.Car) error {
+	if car.TrimID == "" {
+		return nil
+	}
+
+	trim, err := s.trimStore.GetByID(car.TrimID)
+	if err != nil {
+		return err
+	}
+
+	car.Engine = car.Engine.WithDefaults(trim.Engine)
+
 	return nil
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -78,11 +79,11 @@ func TestService_GetAll(t *testing.T) {
 	m := mocks.NewMockCarStore(mockCtrl)
 	s := mocks.NewMockEngineStore(mockCtrl)
 
-	m.EXPECT().GetByBrand("Tesla").Return([]model.Car{car3()}, nil).AnyTimes()
+	m.EXPECT().Get(model.CarFilter{Brand: "Tesla"}).Return([]model.Car{car3()}, nil).AnyTimes()
 
-	m.EXPECT().GetByBrand("").Return([]model.Car{car3(), car4()}, nil).AnyTimes()
+	m.EXPECT().Get(model.CarFilter{}).Return([]model.Car{car3(), car4()}, nil).AnyTimes()
 
-	m.EXPECT().GetByBrand("Jaguar").Return(nil, errors.New("server error"))
+	m.EXPECT().Get(model.CarFilter{Brand: "Jaguar"}).Return(nil, errors.New("server error"))
 
 	s.EXPECT().GetAll().Return(map[string]model.Engine{
 		"1": {
@@ -100,34 +101,34 @@ func TestService_GetAll(t *testing.T) {
 	}, nil).AnyTimes()
 
 	tests := []struct {
-		desc  string
-		brand string
-		cars  []model.Car
-		err   error
+		desc   string
+		filter model.CarFilter
+		cars   []model.Car
+		err    error
 	}{
 		{
 			"Fetch Tesla cars",
-			"Tesla",
+			model.CarFilter{Brand: "Tesla"},
 			[]model.Car{car1},
 			nil,
 		},
 		{
 			"Fetch all cars",
-			"",
+			model.CarFilter{},
 			[]model.Car{car1, car2},
 			nil,
 		}, {
 			"Server error in fetching car",
-			"Jaguar",
+			model.CarFilter{Brand: "Jaguar"},
 			nil,
 			errors.New("server error"),
 		},
 	}
 
-	svc := New(m, s)
+	svc := New(m, s, nil, nil)
 
 	for i, tc := range tests {
-		cars, err := svc.GetAll(tc.brand, true)
+		cars, err := svc.GetAll(tc.filter, true)
 
 		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
 
@@ -142,11 +143,11 @@ func TestService_GetAll_WithoutEngine(t *testing.T) {
 	m := mocks.NewMockCarStore(mockCtrl)
 	s := mocks.NewMockEngineStore(mockCtrl)
 
-	m.EXPECT().GetByBrand("Tesla").Return([]model.Car{car3()}, nil)
+	m.EXPECT().Get(model.CarFilter{Brand: "Tesla"}).Return([]model.Car{car3()}, nil)
 
-	svc := New(m, s)
+	svc := New(m, s, nil, nil)
 
-	cars, err := svc.GetAll("Tesla", false)
+	cars, err := svc.GetAll(model.CarFilter{Brand: "Tesla"}, false)
 
 	assert.Nil(t, err)
 
@@ -160,12 +161,12 @@ func TestService_GetAll_ErrorGettingEngines(t *testing.T) {
 	m := mocks.NewMockCarStore(mockCtrl)
 	s := mocks.NewMockEngineStore(mockCtrl)
 
-	m.EXPECT().GetByBrand("Tesla").Return([]model.Car{car3()}, nil)
+	m.EXPECT().Get(model.CarFilter{Brand: "Tesla"}).Return([]model.Car{car3()}, nil)
 	s.EXPECT().GetAll().Return(nil, errors.New("server error"))
 
-	svc := New(m, s)
+	svc := New(m, s, nil, nil)
 
-	cars, err := svc.GetAll("Tesla", true)
+	cars, err := svc.GetAll(model.CarFilter{Brand: "Tesla"}, true)
 
 	assert.Equal(t, errors.New("server error"), err)
 
@@ -212,7 +213,7 @@ func TestService_GetByID(t *testing.T) {
 		{"Car not exists", "3", nil, customErrors.CarNotExists()},
 	}
 
-	svc := New(c, e)
+	svc := New(c, e, nil, nil)
 
 	for i, tc := range tests {
 		car, err := svc.GetByID(tc.id)
@@ -254,7 +255,8 @@ func TestService_Create(t *testing.T) {
 			NoOfCylinders: 0,
 			Range:         400,
 		},
-	}).Return(&car1, nil)
+		Status: model.StatusInTransit,
+	}, gomock.Any()).Return(&car1, nil)
 
 	e.EXPECT().Create(&model.Engine{}).Return(nil, errors.New("server error"))
 
@@ -282,7 +284,7 @@ func TestService_Create(t *testing.T) {
 			errors.New("server error")},
 	}
 
-	svc := New(c, e)
+	svc := New(c, e, nil, nil)
 
 	for i, tc := range tests {
 		car, err := svc.Create(tc.input)
@@ -293,6 +295,51 @@ func TestService_Create(t *testing.T) {
 	}
 }
 
+func TestService_CreateWithTrim(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCarStore(mockCtrl)
+	e := mocks.NewMockEngineStore(mockCtrl)
+	tr := mocks.NewMockTrimStore(mockCtrl)
+
+	trim := &model.Trim{ID: "t1", Brand: "Tesla", Engine: model.Engine{ID: "e1", Range: 500}}
+
+	tr.EXPECT().GetByID("t1").Return(trim, nil).Times(2)
+	tr.EXPECT().GetByID("t2").Return(nil, customErrors.TrimNotExists())
+
+	// engine params of the car take precedence over the defaults of its trim, the trim engine itself is never reused
+	e.EXPECT().Create(&model.Engine{Range: 500}).Return(&model.Engine{ID: "1", Range: 500}, nil)
+	e.EXPECT().Create(&model.Engine{Range: 300}).Return(&model.Engine{ID: "2", Range: 300}, nil)
+
+	c.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(car *model.Car, at time.Time) (*model.Car, error) {
+		return car, nil
+	}).Times(2)
+
+	tests := []struct {
+		desc   string
+		input  *model.Car
+		engine model.Engine
+		err    error
+	}{
+		{"Engine from trim", &model.Car{TrimID: "t1"}, model.Engine{ID: "1", Range: 500}, nil},
+		{"Engine overrides trim", &model.Car{TrimID: "t1", Engine: model.Engine{Range: 300}}, model.Engine{ID: "2", Range: 300}, nil},
+		{"Trim not exists", &model.Car{TrimID: "t2"}, model.Engine{}, customErrors.TrimNotExists()},
+	}
+
+	svc := New(c, e, tr, nil)
+
+	for i, tc := range tests {
+		car, err := svc.Create(tc.input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		if err == nil {
+			assert.Equalf(t, tc.engine, car.Engine, "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
+
 func TestService_Update(t *testing.T) {
 	car1 := car1()
 	car2 := car2()
@@ -347,7 +394,7 @@ func TestService_Update(t *testing.T) {
 		},
 	}
 
-	svc := New(c, e)
+	svc := New(c, e, nil, nil)
 
 	for i, tc := range tests {
 		car, err := svc.Update(tc.input)
@@ -367,10 +414,18 @@ func TestService_Delete(t *testing.T) {
 
 	c := mocks.NewMockCarStore(mockCtrl)
 	e := mocks.NewMockEngineStore(mockCtrl)
+	a := mocks.NewMockAttachmentService(mockCtrl)
 
 	c.EXPECT().GetByID("1").Return(&car1, nil)
 	c.EXPECT().Delete("1").Return(nil)
 	e.EXPECT().Delete("1").Return(nil)
+	a.EXPECT().DeleteByCar("1").Return(nil)
+
+	// the car is deleted even if the content of its attachments is not
+	c.EXPECT().GetByID("5").Return(&model.Car{ID: "5", Engine: model.Engine{ID: "5"}}, nil)
+	c.EXPECT().Delete("5").Return(nil)
+	e.EXPECT().Delete("5").Return(nil)
+	a.EXPECT().DeleteByCar("5").Return(errors.New("disk error"))
 
 	c.EXPECT().GetByID("2").Return(&model.Car{ID: "2"}, nil)
 	c.EXPECT().Delete("2").Return(customErrors.CarNotExists())
@@ -394,9 +449,10 @@ func TestService_Delete(t *testing.T) {
 		{"Server error while deleting car", "3", errors.New("server error")},
 		{"Error in getByID", "4", errors.New("server error")},
 		{"Server error while deleting engine", car2.ID, errors.New("server error")},
+		{"Attachment content not deleted", "5", nil},
 	}
 
-	svc := New(c, e)
+	svc := New(c, e, nil, a)
 
 	for i, tc := range tests {
 		err := svc.Delete(tc.id)
@@ -404,3 +460,126 @@ func TestService_Delete(t *testing.T) {
 		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
 	}
 }
+
+func TestService_Transition(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCarStore(mockCtrl)
+	e := mocks.NewMockEngineStore(mockCtrl)
+
+	onLot := &model.Car{ID: "1", Engine: model.Engine{ID: "e1"}, Status: model.StatusOnLot}
+	sold := &model.Car{ID: "2", Engine: model.Engine{ID: "e2"}, Status: model.StatusSold}
+
+	c.EXPECT().GetByID("1").Return(onLot, nil)
+	c.EXPECT().Transition("1", gomock.Any()).DoAndReturn(func(id string, change *model.StatusChange) error {
+		assert.Equal(t, model.StatusOnLot, change.From)
+		assert.Equal(t, model.StatusReserved, change.To)
+		assert.False(t, change.At.IsZero())
+
+		return nil
+	})
+	e.EXPECT().GetByID("e1").Return(&model.Engine{ID: "e1", Range: 400}, nil)
+
+	c.EXPECT().GetByID("2").Return(sold, nil)
+	c.EXPECT().GetByID("3").Return(nil, customErrors.CarNotExists())
+
+	tests := []struct {
+		desc string
+		id   string
+		to   model.Status
+		car  *model.Car
+		err  error
+	}{
+		{
+			"Reserve car on the lot",
+			"1",
+			model.StatusReserved,
+			&model.Car{ID: "1", Engine: model.Engine{ID: "e1", Range: 400}, Status: model.StatusReserved},
+			nil,
+		},
+		{
+			"Reserve sold car",
+			"2",
+			model.StatusReserved,
+			nil,
+			customErrors.Conflict{Entity: "Car", Reason: "status cannot change from sold to reserved"},
+		},
+		{"Car not exists", "3", model.StatusSold, nil, customErrors.CarNotExists()},
+	}
+
+	svc := New(c, e, nil, nil)
+
+	for i, tc := range tests {
+		car, err := svc.Transition(tc.id, tc.to)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.car, car, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestService_CreateStatus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	svc := New(mocks.NewMockCarStore(mockCtrl), mocks.NewMockEngineStore(mockCtrl), nil, nil)
+
+	// sold cars cannot be created, the store is never called
+	car, err := svc.Create(&model.Car{Status: model.StatusSold})
+
+	assert.Nil(t, car)
+	assert.Equal(t, customErrors.InvalidFields{{
+		Path:    "/status",
+		Code:    customErrors.FieldInvalid,
+		Message: "a car cannot be created with status sold",
+		Allowed: []string{"in-transit", "on-lot"},
+	}}, err)
+}
+
+func TestService_SetPrice(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCarStore(mockCtrl)
+	e := mocks.NewMockEngineStore(mockCtrl)
+
+	price := model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000}
+
+	c.EXPECT().GetByID("1").Return(&model.Car{ID: "1", Engine: model.Engine{ID: "e1"}}, nil)
+	c.EXPECT().SetPrice("1", gomock.Any()).DoAndReturn(func(id string, change *model.PriceChange) error {
+		assert.Equal(t, price, change.Price)
+		assert.Equal(t, "admin:1a2b3c4d", change.Actor)
+		assert.Equal(t, "spring sale", change.Reason)
+		assert.False(t, change.At.IsZero())
+
+		return nil
+	})
+	e.EXPECT().GetByID("e1").Return(&model.Engine{ID: "e1", Range: 400}, nil)
+
+	c.EXPECT().GetByID("2").Return(&model.Car{ID: "2", Engine: model.Engine{ID: "e2"}}, nil)
+	c.EXPECT().SetPrice("2", gomock.Any()).Return(errors.New("DB error"))
+
+	c.EXPECT().GetByID("3").Return(nil, customErrors.CarNotExists())
+
+	tests := []struct {
+		desc string
+		id   string
+		car  *model.Car
+		err  error
+	}{
+		{"Success", "1", &model.Car{ID: "1", Engine: model.Engine{ID: "e1", Range: 400}, Price: &price}, nil},
+		{"DB error", "2", nil, errors.New("DB error")},
+		{"Car not exists", "3", nil, customErrors.CarNotExists()},
+	}
+
+	svc := New(c, e, nil, nil)
+
+	for i, tc := range tests {
+		car, err := svc.SetPrice(tc.id, &model.PriceChange{Price: price, Actor: "admin:1a2b3c4d", Reason: "spring sale"})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.car, car, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
@@ -0,0 +1,120 @@
+package attachment
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/google/uuid"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store/dberr"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+//nolint:revive //store should not be exported
+func New(db *sql.DB) store {
+	return store{db: db}
+}
+
+func (s store) GetByCar(carID string) ([]model.Attachment, error) {
+	attachments := make([]model.Attachment, 0)
+
+	rows, err := s.db.Query(getAttachments, carID)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.AttachmentNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		attachment, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, *attachment)
+	}
+
+	return attachments, nil
+}
+
+func (s store) GetByID(id string) (*model.Attachment, error) {
+	attachment, err := scan(s.db.QueryRow(getAttachmentByID, id))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.AttachmentNotExists())
+	}
+
+	return attachment, nil
+}
+
+func (s store) Create(attachment *model.Attachment) (*model.Attachment, error) {
+	attachment.ID = uuid.NewString()
+
+	stmt, err := s.db.Prepare(insertAttachment)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.AttachmentNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(attachment.ID, attachment.CarID, attachment.Kind, attachment.FileName, attachment.ContentType,
+		attachment.Size, attachment.HasThumbnail, attachment.CreatedAt)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.AttachmentNotExists())
+	}
+
+	return attachment, nil
+}
+
+func (s store) Delete(id string) error {
+	stmt, err := s.db.Prepare(deleteAttachment)
+	if err != nil {
+		return dberr.Classify(err, customErrors.AttachmentNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return dberr.Classify(err, customErrors.AttachmentNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.AttachmentNotExists())
+	}
+
+	if n == 0 {
+		return customErrors.AttachmentNotExists()
+	}
+
+	return nil
+}
+
+// row is implemented by both *sql.Row and *sql.Rows
+type row interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(r row) (*model.Attachment, error) {
+	var attachment model.Attachment
+
+	err := r.Scan(&attachment.ID, &attachment.CarID, &attachment.Kind, &attachment.FileName, &attachment.ContentType,
+		&attachment.Size, &attachment.HasThumbnail, &attachment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
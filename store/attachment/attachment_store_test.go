@@ -0,0 +1,170 @@
+package attachment
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func columns() []string {
+	return []string{"attachmentId", "carId", "kind", "fileName", "contentType", "size", "hasThumbnail", "createdAt"}
+}
+
+func TestStore_GetByCar(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows(columns()).
+		AddRow("a1", "1", "photo", "front.jpg", "image/jpeg", 2048, true, at).
+		AddRow("a2", "1", "document", "inspection.pdf", "application/pdf", 4096, false, at.Add(time.Hour))
+
+	query := "select attachmentId, carId, kind, fileName, contentType, size, hasThumbnail, createdAt from attachments\\s+where carId = \\?"
+
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("2").WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc        string
+		carID       string
+		attachments []model.Attachment
+		err         error
+	}{
+		{"Success", "1", []model.Attachment{
+			{ID: "a1", CarID: "1", Kind: model.AttachmentPhoto, FileName: "front.jpg", ContentType: "image/jpeg", Size: 2048,
+				HasThumbnail: true, CreatedAt: at},
+			{ID: "a2", CarID: "1", Kind: model.AttachmentDocument, FileName: "inspection.pdf", ContentType: "application/pdf", Size: 4096,
+				CreatedAt: at.Add(time.Hour)},
+		}, nil},
+		{"DB error", "2", nil, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		attachments, err := store.GetByCar(tc.carID)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.attachments, attachments, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows(columns()).AddRow("a1", "1", "photo", "front.jpg", "image/jpeg", 2048, true, at)
+
+	query := "select attachmentId, carId, kind, fileName, contentType, size, hasThumbnail, createdAt from attachments\\s+where attachmentId = \\?"
+
+	mock.ExpectQuery(query).WithArgs("a1").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("a2").WillReturnError(sql.ErrNoRows)
+
+	tests := []struct {
+		desc       string
+		id         string
+		attachment *model.Attachment
+		err        error
+	}{
+		{"Success", "a1", &model.Attachment{ID: "a1", CarID: "1", Kind: model.AttachmentPhoto, FileName: "front.jpg",
+			ContentType: "image/jpeg", Size: 2048, HasThumbnail: true, CreatedAt: at}, nil},
+		{"Attachment not exists", "a2", nil, customErrors.AttachmentNotExists()},
+	}
+
+	for i, tc := range tests {
+		attachment, err := store.GetByID(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.attachment, attachment, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	missingCar := &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"}
+
+	query := "insert into attachments \\(attachmentId, carId, kind, fileName, contentType, size, hasThumbnail, createdAt\\)"
+
+	mock.ExpectPrepare(query).ExpectExec().
+		WithArgs(sqlmock.AnyArg(), "1", model.AttachmentPhoto, "front.jpg", "image/jpeg", int64(2048), true, at).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(query).ExpectExec().WillReturnError(missingCar)
+
+	tests := []struct {
+		desc string
+		err  error
+	}{
+		{"Success", nil},
+		{"Car deleted concurrently", customErrors.Conflict{Entity: "Attachment", Reason: "references a missing entity", Err: missingCar}},
+	}
+
+	for i, tc := range tests {
+		attachment, err := store.Create(&model.Attachment{CarID: "1", Kind: model.AttachmentPhoto, FileName: "front.jpg",
+			ContentType: "image/jpeg", Size: 2048, HasThumbnail: true, CreatedAt: at})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		if tc.err == nil {
+			assert.NotEmptyf(t, attachment.ID, "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	query := "delete from attachments where attachmentId = \\?"
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs("a1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(query).ExpectExec().WithArgs("a2").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tests := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{"Success", "a1", nil},
+		{"Attachment not exists", "a2", customErrors.AttachmentNotExists()},
+	}
+
+	for i, tc := range tests {
+		err := store.Delete(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
@@ -0,0 +1,11 @@
+package attachment
+
+const (
+	getAttachments = `select attachmentId, carId, kind, fileName, contentType, size, hasThumbnail, createdAt from attachments
+					where carId = ? order by createdAt, attachmentId`
+	getAttachmentByID = `select attachmentId, carId, kind, fileName, contentType, size, hasThumbnail, createdAt from attachments
+					where attachmentId = ?`
+	insertAttachment = `insert into attachments (attachmentId, carId, kind, fileName, contentType, size, hasThumbnail, createdAt)
+					values (?, ?, ?, ?, ?, ?, ?, ?)`
+	deleteAttachment = "delete from attachments where attachmentId = ?"
+)
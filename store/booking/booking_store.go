@@ -0,0 +1,193 @@
+package booking
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store/dberr"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+//nolint:revive //store should not be exported
+func New(db *sql.DB) store {
+	return store{db: db}
+}
+
+func (s store) GetByCar(carID string) ([]model.Booking, error) {
+	return s.query(getBookings, carID)
+}
+
+func (s store) GetByID(id string) (*model.Booking, error) {
+	booking, err := scan(s.db.QueryRow(getBookingByID, id))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	return booking, nil
+}
+
+func (s store) GetOverlapping(carID string, slot model.Slot, now time.Time) ([]model.Booking, error) {
+	return s.query(getOverlapping, carID, slot.End, slot.Start, now)
+}
+
+// Create locks the car of booking and checks for overlapping bookings in the same transaction as the insert,
+// so that the check also holds for bookings created concurrently
+func (s store) Create(booking *model.Booking) (*model.Booking, error) {
+	booking.ID = uuid.NewString()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	// rolling back a committed transaction is a no-op
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var carID string
+
+	err = tx.QueryRow(lockCar, booking.CarID).Scan(&carID)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	var n int
+
+	err = tx.QueryRow(countOverlapping, booking.CarID, booking.End, booking.Start, booking.CreatedAt).Scan(&n)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	if n != 0 {
+		return nil, customErrors.Conflict{Entity: "Booking", Reason: "the car is already booked in this time slot"}
+	}
+
+	_, err = tx.Exec(insertBooking, booking.ID, booking.CarID, nullString(booking.CustomerID), booking.Kind, booking.Start, booking.End,
+		booking.Status, booking.ExpiresAt, booking.CreatedAt)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	return booking, nil
+}
+
+func (s store) Cancel(id string) error {
+	stmt, err := s.db.Prepare(cancelBooking)
+	if err != nil {
+		return dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	if n == 0 {
+		return customErrors.Conflict{Entity: "Booking", Reason: "booking is no longer active"}
+	}
+
+	return nil
+}
+
+func (s store) ExpireHolds(now time.Time) (int64, error) {
+	stmt, err := s.db.Prepare(expireHolds)
+	if err != nil {
+		return 0, dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(now)
+	if err != nil {
+		return 0, dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	return n, nil
+}
+
+func (s store) query(query string, args ...interface{}) ([]model.Booking, error) {
+	bookings := make([]model.Booking, 0)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BookingNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		booking, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bookings = append(bookings, *booking)
+	}
+
+	return bookings, nil
+}
+
+// row is implemented by both *sql.Row and *sql.Rows
+type row interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(r row) (*model.Booking, error) {
+	var (
+		booking    model.Booking
+		customerID sql.NullString
+		expiresAt  sql.NullTime
+	)
+
+	err := r.Scan(&booking.ID, &booking.CarID, &customerID, &booking.Kind, &booking.Start, &booking.End, &booking.Status, &expiresAt,
+		&booking.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	booking.CustomerID = customerID.String
+
+	if expiresAt.Valid {
+		booking.ExpiresAt = &expiresAt.Time
+	}
+
+	return &booking, nil
+}
+
+// nullString maps the empty string to NULL, for optional references
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
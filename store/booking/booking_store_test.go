@@ -0,0 +1,176 @@
+package booking
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func columns() []string {
+	return []string{"bookingId", "carId", "customerId", "kind", "startsAt", "endsAt", "status", "expiresAt", "createdAt"}
+}
+
+func booking() model.Booking {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	return model.Booking{
+		CarID:     "car1",
+		Kind:      model.BookingTestDrive,
+		Start:     start,
+		End:       start.Add(time.Hour),
+		Status:    model.BookingActive,
+		CreatedAt: start.Add(-24 * time.Hour),
+	}
+}
+
+func TestStore_GetOverlapping(t *testing.T) {
+	b := booking()
+	b.ID = "b1"
+	expiresAt := b.End
+	b.Kind = model.BookingHold
+	b.CustomerID = "c1"
+	b.ExpiresAt = &expiresAt
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	slot := model.Slot{Start: b.Start.Add(-time.Hour), End: b.End.Add(time.Hour)}
+	rows := sqlmock.NewRows(columns()).AddRow(b.ID, b.CarID, b.CustomerID, b.Kind, b.Start, b.End, b.Status, expiresAt, b.CreatedAt)
+
+	mock.ExpectQuery("select .* from bookings\\s+where carId = \\? and status = 'active' and startsAt < \\? and endsAt > \\?").
+		WithArgs("car1", slot.End, slot.Start, b.CreatedAt).WillReturnRows(rows)
+
+	bookings, err := store.GetOverlapping("car1", slot, b.CreatedAt)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Booking{b}, bookings)
+}
+
+func TestStore_Create(t *testing.T) {
+	b := booking()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	lockCar := "select carId from cars where carId = \\? for update"
+	countOverlapping := "select count\\(\\*\\) from bookings"
+	insertBooking := "insert into bookings"
+
+	// success, the car is locked before checking for overlaps
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockCar).WithArgs("car1").WillReturnRows(sqlmock.NewRows([]string{"carId"}).AddRow("car1"))
+	mock.ExpectQuery(countOverlapping).WithArgs("car1", b.End, b.Start, b.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(insertBooking).
+		WithArgs(sqlmock.AnyArg(), "car1", nil, b.Kind, b.Start, b.End, b.Status, nil, b.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// a booking overlapping it was created concurrently
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockCar).WithArgs("car1").WillReturnRows(sqlmock.NewRows([]string{"carId"}).AddRow("car1"))
+	mock.ExpectQuery(countOverlapping).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	// the car was deleted concurrently
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockCar).WithArgs("car1").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	// DB error
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockCar).WithArgs("car1").WillReturnRows(sqlmock.NewRows([]string{"carId"}).AddRow("car1"))
+	mock.ExpectQuery(countOverlapping).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(insertBooking).WillReturnError(errors.New("DB error"))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		desc string
+		err  error
+	}{
+		{"Success", nil},
+		{"Slot booked concurrently", customErrors.Conflict{Entity: "Booking", Reason: "the car is already booked in this time slot"}},
+		{"Car not exists", customErrors.CarNotExists()},
+		{"DB error", errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		input := b
+
+		_, err := store.Create(&input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_Cancel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	query := "update bookings set status = 'cancelled' where bookingId = \\? and status = 'active'"
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs("b1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(query).ExpectExec().WithArgs("b2").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tests := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{"Success", "b1", nil},
+		{"Booking expired concurrently", "b2", customErrors.Conflict{Entity: "Booking", Reason: "booking is no longer active"}},
+	}
+
+	for i, tc := range tests {
+		err := store.Cancel(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_ExpireHolds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectPrepare("update bookings set status = 'expired' where status = 'active' and expiresAt <= \\?").
+		ExpectExec().WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	n, err := store.ExpireHolds(now)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), n)
+}
@@ -0,0 +1,21 @@
+package booking
+
+// holds past their expiry which have not been swept yet do not occupy their slot
+const (
+	getBookings = `select bookingId, carId, customerId, kind, startsAt, endsAt, status, expiresAt, createdAt from bookings
+					where carId = ? order by startsAt, bookingId`
+	getBookingByID = `select bookingId, carId, customerId, kind, startsAt, endsAt, status, expiresAt, createdAt from bookings
+					where bookingId = ?`
+	getOverlapping = `select bookingId, carId, customerId, kind, startsAt, endsAt, status, expiresAt, createdAt from bookings
+					where carId = ? and status = 'active' and startsAt < ? and endsAt > ? and (expiresAt is null or expiresAt > ?)
+					order by startsAt, bookingId`
+	countOverlapping = `select count(*) from bookings
+					where carId = ? and status = 'active' and startsAt < ? and endsAt > ? and (expiresAt is null or expiresAt > ?)`
+	insertBooking = `insert into bookings (bookingId, carId, customerId, kind, startsAt, endsAt, status, expiresAt, createdAt)
+					values (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	cancelBooking = "update bookings set status = 'cancelled' where bookingId = ? and status = 'active'"
+	expireHolds   = "update bookings set status = 'expired' where status = 'active' and expiresAt <= ?"
+
+	// lockCar serializes the creation of bookings of a car, so that concurrent bookings cannot both pass the overlap check
+	lockCar = "select carId from cars where carId = ? for update"
+)
@@ -0,0 +1,116 @@
+package brand
+
+import (
+	"database/sql"
+	"log"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store/dberr"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+//nolint:revive //store should not be exported
+func New(db *sql.DB) store {
+	return store{db: db}
+}
+
+func (s store) GetAll() ([]model.Brand, error) {
+	var brand model.Brand
+
+	brands := make([]model.Brand, 0)
+
+	rows, err := s.db.Query(getAllBrands)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BrandNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		err := rows.Scan(&brand.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		brands = append(brands, brand)
+	}
+
+	return brands, nil
+}
+
+func (s store) Create(brand *model.Brand) (*model.Brand, error) {
+	stmt, err := s.db.Prepare(insertBrand)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BrandNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(brand.Name)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BrandNotExists())
+	}
+
+	return brand, nil
+}
+
+func (s store) Update(name string, brand *model.Brand) (*model.Brand, error) {
+	stmt, err := s.db.Prepare(updateBrand)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BrandNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(brand.Name, name)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BrandNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.BrandNotExists())
+	}
+
+	if n == 0 {
+		return nil, customErrors.BrandNotExists()
+	}
+
+	return brand, nil
+}
+
+func (s store) Delete(name string) error {
+	stmt, err := s.db.Prepare(deleteBrand)
+	if err != nil {
+		return dberr.Classify(err, customErrors.BrandNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(name)
+	if err != nil {
+		return dberr.Classify(err, customErrors.BrandNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.BrandNotExists())
+	}
+
+	if n == 0 {
+		return customErrors.BrandNotExists()
+	}
+
+	return nil
+}
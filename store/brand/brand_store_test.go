@@ -0,0 +1,167 @@
+package brand
+
+import (
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func TestStore_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows([]string{"name"}).AddRow("BMW").AddRow("Tesla")
+
+	mock.ExpectQuery("select name from brands order by name").WillReturnRows(rows)
+	mock.ExpectQuery("select name from brands order by name").WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc   string
+		brands []model.Brand
+		err    error
+	}{
+		{"Success", []model.Brand{{Name: "BMW"}, {Name: "Tesla"}}, nil},
+		{"DB error", nil, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		brands, err := store.GetAll()
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.brands, brands, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+
+	query := "insert into brands \\(name\\) values \\(\\?\\)"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Audi").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Tesla").WillReturnError(duplicate)
+
+	tests := []struct {
+		desc     string
+		input    *model.Brand
+		expected *model.Brand
+		err      error
+	}{
+		{"Success", &model.Brand{Name: "Audi"}, &model.Brand{Name: "Audi"}, nil},
+		{"Duplicate", &model.Brand{Name: "Tesla"}, nil,
+			customErrors.Conflict{Entity: "Brand", Reason: "duplicate entry", Err: duplicate}},
+	}
+
+	for i, tc := range tests {
+		brand, err := store.Create(tc.input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, brand, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	query := "update brands set name = \\? where name = \\?"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Audi AG", "Audi").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Opel AG", "Opel").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc     string
+		name     string
+		input    *model.Brand
+		expected *model.Brand
+		err      error
+	}{
+		{"Success", "Audi", &model.Brand{Name: "Audi AG"}, &model.Brand{Name: "Audi AG"}, nil},
+		{"Brand not exists", "Opel", &model.Brand{Name: "Opel AG"}, nil, customErrors.BrandNotExists()},
+		{"DB error", "Audi", &model.Brand{Name: "Audi AG"}, nil, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		brand, err := store.Update(tc.name, tc.input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, brand, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	referenced := &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"}
+
+	query := "delete from brands where name = \\?"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Audi").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Audi").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Tesla").WillReturnError(referenced)
+
+	tests := []struct {
+		desc string
+		name string
+		err  error
+	}{
+		{"Success", "Audi", nil},
+		{"Not exists", "Audi", customErrors.BrandNotExists()},
+		{"Brand of existing cars", "Tesla",
+			customErrors.Conflict{Entity: "Brand", Reason: "referenced by another entity", Err: referenced}},
+	}
+
+	for i, tc := range tests {
+		err := store.Delete(tc.name)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
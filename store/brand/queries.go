@@ -0,0 +1,8 @@
+package brand
+
+const (
+	getAllBrands = "select name from brands order by name"
+	insertBrand  = "insert into brands (name) values (?)"
+	updateBrand  = "update brands set name = ? where name = ?"
+	deleteBrand  = "delete from brands where name = ?"
+)
@@ -2,12 +2,18 @@ package car
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	customErrors "carAPI/custom-errors"
 	"carAPI/model"
+	"carAPI/store/dberr"
+	"carAPI/store/engine"
+	"carAPI/store/sqlutil"
 )
 
 type store struct {
@@ -19,23 +25,14 @@ func New(db *sql.DB) store {
 	return store{db: db}
 }
 
-func (s store) GetByBrand(brand string) ([]model.Car, error) {
-	var (
-		car  model.Car
-		rows *sql.Rows
-		err  error
-	)
-
+func (s store) Get(filter model.CarFilter) ([]model.Car, error) {
 	cars := make([]model.Car, 0)
 
-	if brand == "" {
-		rows, err = s.db.Query(getAllCars)
-	} else {
-		rows, err = s.db.Query(getCarByBrand, brand)
-	}
+	query, args := filterQuery(getCars, filter)
 
+	rows, err := s.db.Query(query, args...)
 	if err != nil {
-		return []model.Car{}, err
+		return []model.Car{}, dberr.Classify(err, customErrors.CarNotExists())
 	}
 
 	defer func() {
@@ -48,48 +45,136 @@ func (s store) GetByBrand(brand string) ([]model.Car, error) {
 	}()
 
 	for rows.Next() {
-		err := rows.Scan(&car.ID, &car.Name, &car.YearOfManufacture, &car.Brand, &car.FuelType, &car.Engine.ID)
+		car, err := scan(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		cars = append(cars, car)
+		cars = append(cars, *car)
 	}
 
 	return cars, nil
 }
 
-func (s store) GetByID(id string) (*model.Car, error) {
-	var car model.Car
+// Page fetches up to limit cars matching filter from offset on, ordered by ID so that pages do not overlap,
+// and counts all cars matching filter
+func (s store) Page(filter model.CarFilter, limit, offset int) ([]model.Car, int, error) {
+	query, args := filterQuery(countCars, filter)
+
+	var total int
+
+	err := s.db.QueryRow(query, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, dberr.Classify(err, customErrors.CarNotExists())
+	}
 
-	row := s.db.QueryRow(getCarByID, id)
-	err := row.Scan(&car.ID, &car.Name, &car.YearOfManufacture, &car.Brand, &car.FuelType, &car.Engine.ID)
+	cars := make([]model.Car, 0, limit)
 
-	if err == sql.ErrNoRows {
-		return nil, customErrors.CarNotExists()
+	// a page past the last car is empty
+	if offset >= total {
+		return cars, total, nil
 	}
 
+	query, args = filterQuery(getCars, filter)
+
+	rows, err := s.db.Query(query+pageCars, append(args, limit, offset)...)
 	if err != nil {
-		return nil, err
+		return nil, 0, dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		car, err := scan(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		cars = append(cars, *car)
+	}
+
+	return cars, total, nil
+}
+
+// Each streams the cars matching filter with their engines from a DB cursor to fn
+func (s store) Each(filter model.CarFilter, fn func(car *model.Car) error) error {
+	query, args := filterQuery(getCarsWithEngines, filter)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		car, err := scanWithEngine(rows)
+		if err != nil {
+			return err
+		}
+
+		err = fn(car)
+		if err != nil {
+			return err
+		}
+	}
+
+	return dberr.Classify(rows.Err(), customErrors.CarNotExists())
+}
+
+func (s store) GetByID(id string) (*model.Car, error) {
+	car, err := scan(s.db.QueryRow(getCarByID, id))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	return car, nil
+}
+
+func (s store) GetByVIN(vin string) (*model.Car, error) {
+	car, err := scan(s.db.QueryRow(getCarByVIN, vin))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
 	}
 
-	return &car, nil
+	return car, nil
 }
 
-func (s store) Create(car *model.Car) (*model.Car, error) {
+// Create creates the car and records its initial status in one transaction, so that no car is missing its status history
+func (s store) Create(car *model.Car, at time.Time) (*model.Car, error) {
 	car.ID = uuid.NewString()
 
-	stmt, err := s.db.Prepare(insertCar)
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	// rolling back a committed transaction is a no-op
+	defer func() {
+		_ = tx.Rollback()
+	}()
 
+	_, err = tx.Exec(insertCar, car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID,
+		nullString(car.TrimID), nullString(car.VIN), car.Status, car.DealershipID)
 	if err != nil {
-		return nil, err
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
 	}
 
-	defer stmt.Close()
+	_, err = tx.Exec(insertStatusChange, car.ID, nullString(""), car.Status, at)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
+	}
 
-	_, err = stmt.Exec(car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID)
+	err = tx.Commit()
 	if err != nil {
-		return nil, err
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
 	}
 
 	return car, nil
@@ -98,14 +183,14 @@ func (s store) Create(car *model.Car) (*model.Car, error) {
 func (s store) Update(car *model.Car) (*model.Car, error) {
 	stmt, err := s.db.Prepare(updateCar)
 	if err != nil {
-		return nil, err
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
 	}
 
 	defer stmt.Close()
 
-	_, err = stmt.Exec(car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.ID)
+	_, err = stmt.Exec(car.Name, car.YearOfManufacture, car.Brand, car.FuelType, nullString(car.TrimID), nullString(car.VIN), car.ID)
 	if err != nil {
-		return nil, err
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
 	}
 
 	return car, nil
@@ -113,20 +198,582 @@ func (s store) Update(car *model.Car) (*model.Car, error) {
 
 func (s store) Delete(id string) error {
 	stmt, err := s.db.Prepare(deleteCar)
-	if err == sql.ErrNoRows {
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	if n == 0 {
 		return customErrors.CarNotExists()
 	}
 
+	return nil
+}
+
+// row is implemented by both *sql.Row and *sql.Rows
+type row interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(r row) (*model.Car, error) {
+	var c nullableCar
+
+	err := r.Scan(c.dest()...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.result(), nil
+}
+
+// scanWithEngine scans a row of getCarsWithEngines
+func scanWithEngine(r row) (*model.Car, error) {
+	var c nullableCar
+
+	e := &c.car.Engine
+
+	err := r.Scan(append(c.dest(), &e.Displacement, &e.NoOfCylinders, &e.Range, &e.BatteryCapacity, &e.ElectricRange,
+		&e.TankCapacity, &e.NoOfMotors, &e.Power, &e.Torque, &e.Transmission, &e.Gears, &e.Drivetrain)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.result(), nil
+}
+
+// nullableCar holds a car while it is scanned, with the nullable columns scanned separately
+type nullableCar struct {
+	car       model.Car
+	trimID    sql.NullString
+	vin       sql.NullString
+	currency  sql.NullString
+	listPrice sql.NullInt64
+	minPrice  sql.NullInt64
+}
+
+// dest returns the scan destinations of the car columns
+func (c *nullableCar) dest() []interface{} {
+	return []interface{}{&c.car.ID, &c.car.Name, &c.car.YearOfManufacture, &c.car.Brand, &c.car.FuelType, &c.car.Engine.ID,
+		&c.trimID, &c.vin, &c.car.Status, &c.currency, &c.listPrice, &c.minPrice, &c.car.DealershipID}
+}
+
+// result returns the scanned car
+func (c *nullableCar) result() *model.Car {
+	car := c.car
+
+	car.TrimID = c.trimID.String
+	car.VIN = c.vin.String
+
+	// cars without a price have no currency
+	if c.currency.Valid {
+		car.Price = &model.Price{Currency: c.currency.String, List: c.listPrice.Int64, Minimum: c.minPrice.Int64}
+	}
+
+	return &car
+}
+
+// nullString maps the empty string to NULL, for optional references and unique optional values
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullInt64 maps zero to NULL, for optional amounts
+func nullInt64(n int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: n, Valid: n != 0}
+}
+
+// Transition changes the status of the car and records the change in one transaction,
+// so that the status history never differs from the status of the car
+func (s store) Transition(id string, change *model.StatusChange) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	// rolling back a committed transaction is a no-op
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = Transition(tx, id, change)
 	if err != nil {
 		return err
 	}
 
-	defer stmt.Close()
+	err = tx.Commit()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	return nil
+}
+
+// Transition changes the status of the car with given ID as given by change and records change on tx,
+// a conflict is returned if the car does not have the status change.From anymore
+func Transition(tx *sql.Tx, id string, change *model.StatusChange) error {
+	res, err := tx.Exec(updateCarStatus, change.To, id, change.From)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	if n == 0 {
+		return customErrors.Conflict{Entity: "Car", Reason: fmt.Sprintf("status is no longer %v", change.From)}
+	}
+
+	_, err = tx.Exec(insertStatusChange, id, nullString(string(change.From)), change.To, change.At)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	return nil
+}
+
+func (s store) GetStatusHistory(id string) ([]model.StatusChange, error) {
+	history := make([]model.StatusChange, 0)
+
+	rows, err := s.db.Query(getStatusHistory, id)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			change model.StatusChange
+			from   sql.NullString
+		)
+
+		err := rows.Scan(&from, &change.To, &change.At)
+		if err != nil {
+			return nil, err
+		}
+
+		change.From = model.Status(from.String)
+
+		history = append(history, change)
+	}
+
+	return history, nil
+}
+
+// SetPrice sets the price of the car and records the change in one transaction,
+// so that the price history never differs from the price of the car
+func (s store) SetPrice(id string, change *model.PriceChange) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	// rolling back a committed transaction is a no-op
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(updateCarPrice, change.Currency, change.List, nullInt64(change.Minimum), id)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
 
-	_, err = stmt.Exec(id)
+	_, err = tx.Exec(insertPriceChange, id, change.Currency, change.List, nullInt64(change.Minimum), change.Actor, change.Reason,
+		change.At)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	return nil
+}
+
+func (s store) GetPriceHistory(id string) ([]model.PriceChange, error) {
+	history := make([]model.PriceChange, 0)
+
+	rows, err := s.db.Query(getPriceHistory, id)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			change   model.PriceChange
+			minPrice sql.NullInt64
+		)
+
+		err := rows.Scan(&change.Currency, &change.List, &minPrice, &change.Actor, &change.Reason, &change.At)
+		if err != nil {
+			return nil, err
+		}
+
+		change.Minimum = minPrice.Int64
+
+		history = append(history, change)
+	}
+
+	return history, nil
+}
+
+// Transfer moves the car to another dealership and records the transfer in one transaction,
+// so that no transfer is missing from the history of a car
+func (s store) Transfer(id string, transfer *model.Transfer) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	// rolling back a committed transaction is a no-op
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.Exec(updateCarDealership, transfer.To, id, transfer.From)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	if n == 0 {
+		return customErrors.Conflict{Entity: "Car", Reason: fmt.Sprintf("dealership is no longer %v", transfer.From)}
+	}
+
+	_, err = tx.Exec(insertTransfer, id, transfer.From, transfer.To, transfer.Actor, transfer.At)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	return nil
+}
+
+func (s store) GetTransfers(id string) ([]model.Transfer, error) {
+	transfers := make([]model.Transfer, 0)
+
+	rows, err := s.db.Query(getTransfers, id)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		var transfer model.Transfer
+
+		err := rows.Scan(&transfer.From, &transfer.To, &transfer.Actor, &transfer.At)
+		if err != nil {
+			return nil, err
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, nil
+}
+
+func (s store) GetByIDs(ids []string) ([]model.Car, error) {
+	return s.getIn(getCarsByIDs, ids)
+}
+
+func (s store) GetByVINs(vins []string) ([]model.Car, error) {
+	return s.getIn(getCarsByVINs, vins)
+}
+
+// getIn fetches the cars of query, getCarsByIDs or getCarsByVINs, completed by the placeholder group of values
+func (s store) getIn(query string, values []string) ([]model.Car, error) {
+	cars := make([]model.Car, 0, len(values))
+
+	if len(values) == 0 {
+		return cars, nil
+	}
+
+	rows, err := s.db.Query(query+"("+sqlutil.Placeholders("?", len(values))+")", idArgs(values)...)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		car, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		cars = append(cars, *car)
+	}
+
+	return cars, nil
+}
+
+func (s store) GetOrderedIDs(ids []string) ([]string, error) {
+	ordered := make([]string, 0)
+
+	if len(ids) == 0 {
+		return ordered, nil
+	}
+
+	rows, err := s.db.Query(getOrderedCars+"("+sqlutil.Placeholders("?", len(ids))+")", idArgs(ids)...)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		var id string
+
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, dberr.Classify(err, customErrors.CarNotExists())
+		}
+
+		ordered = append(ordered, id)
+	}
+
+	return ordered, nil
+}
+
+// CreateMany creates cars, their engines and their initial status changes with one multi-row insert each,
+// all in one transaction
+func (s store) CreateMany(cars []*model.Car, at time.Time) error {
+	engines := make([]*model.Engine, len(cars))
+	for i, car := range cars {
+		engines[i] = &car.Engine
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	// rolling back a committed transaction is a no-op
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = engine.CreateMany(tx, engines)
 	if err != nil {
 		return err
 	}
 
+	carArgs := make([]interface{}, 0, len(cars)*carColumns)
+	changeArgs := make([]interface{}, 0, len(cars)*4)
+
+	for _, car := range cars {
+		car.ID = uuid.NewString()
+
+		carArgs = append(carArgs, car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID,
+			nullString(car.TrimID), nullString(car.VIN), car.Status, car.DealershipID)
+		changeArgs = append(changeArgs, car.ID, nullString(""), car.Status, at)
+	}
+
+	_, err = tx.Exec(insertCars+sqlutil.Placeholders(carValues, len(cars)), carArgs...)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	_, err = tx.Exec(insertStatusChanges+sqlutil.Placeholders(statusChangeValues, len(cars)), changeArgs...)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
 	return nil
 }
+
+// UpdateMany updates existing cars and their engines in one transaction
+func (s store) UpdateMany(cars []*model.Car) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	// rolling back a committed transaction is a no-op
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	engines := make([]*model.Engine, len(cars))
+
+	for i, car := range cars {
+		_, err = tx.Exec(updateCar, car.Name, car.YearOfManufacture, car.Brand, car.FuelType,
+			nullString(car.TrimID), nullString(car.VIN), car.ID)
+		if err != nil {
+			return dberr.Classify(err, customErrors.CarNotExists())
+		}
+
+		engines[i] = &car.Engine
+	}
+
+	err = engine.UpdateMany(tx, engines)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	return nil
+}
+
+// DeleteMany deletes cars and their engines in one transaction,
+// a conflict is returned if any of the cars has been deleted concurrently
+func (s store) DeleteMany(cars []model.Car) error {
+	ids := make([]string, len(cars))
+	engineIDs := make([]string, len(cars))
+
+	for i := range cars {
+		ids[i] = cars[i].ID
+		engineIDs[i] = cars[i].Engine.ID
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	// rolling back a committed transaction is a no-op
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.Exec(deleteCars+"("+sqlutil.Placeholders("?", len(ids))+")", idArgs(ids)...)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	if n != int64(len(ids)) {
+		return customErrors.Conflict{Entity: "Car", Reason: "some of the cars have been deleted concurrently"}
+	}
+
+	err = engine.DeleteMany(tx, engineIDs)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CarNotExists())
+	}
+
+	return nil
+}
+
+// idArgs converts ids to query args
+func idArgs(ids []string) []interface{} {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	return args
+}
+
+// filterQuery completes base, getCars or getCarsWithEngines, with a condition for every field set in filter
+func filterQuery(base string, filter model.CarFilter) (query string, args []interface{}) {
+	var conditions []string
+
+	filters := []struct {
+		condition string
+		value     interface{}
+		set       bool
+	}{
+		{"c.brand = ?", filter.Brand, filter.Brand != ""},
+		{"c.status = ?", filter.Status, filter.Status != ""},
+		{"e.transmission = ?", filter.Transmission, filter.Transmission != ""},
+		{"e.drivetrain = ?", filter.Drivetrain, filter.Drivetrain != ""},
+		{"e.power >= ?", filter.MinPower, filter.MinPower != 0},
+		{"e.power <= ?", filter.MaxPower, filter.MaxPower != 0},
+		{"c.currency = ?", filter.Currency, filter.Currency != ""},
+		{"c.listPrice >= ?", filter.MinPrice, filter.MinPrice != 0},
+		{"c.listPrice <= ?", filter.MaxPrice, filter.MaxPrice != 0},
+		{"c.dealershipId = ?", filter.DealershipID, filter.DealershipID != ""},
+	}
+
+	for _, f := range filters {
+		if f.set {
+			conditions = append(conditions, f.condition)
+			args = append(args, f.value)
+		}
+	}
+
+	if len(conditions) == 0 {
+		return base, nil
+	}
+
+	return base + " where " + strings.Join(conditions, " and "), args
+}
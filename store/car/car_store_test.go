@@ -1,9 +1,11 @@
 package car
 
 import (
+	"database/sql"
 	"errors"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
@@ -23,10 +25,69 @@ func car() model.Car {
 		Engine: model.Engine{
 			ID: uuid.NewString(),
 		},
+		Status:       model.StatusOnLot,
+		DealershipID: "d1",
 	}
 }
 
-func TestStore_GetByBrand(t *testing.T) {
+func columns() []string {
+	return []string{"carID", "name", "yearOfManufacture", "brand", "fuelType", "engineId", "trimId", "vin", "status",
+		"currency", "listPrice", "minPrice", "dealershipId"}
+}
+
+func TestStore_Get(t *testing.T) {
+	car := car()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID, nil, nil, car.Status, nil, nil, nil,
+			car.DealershipID)
+
+	query := "select c.carId, c.name, c.yearOfManufacture, c.brand, c.fuelType, c.engineId, c.trimId, c.vin, c.status,\\s+" +
+		"c.currency, c.listPrice, c.minPrice, c.dealershipId from cars c\\s+" +
+		"join engines e on e.engineId = c.engineId"
+
+	mock.ExpectQuery(query + " where c.brand = \\?$").WithArgs("Tesla").WillReturnRows(rows)
+	mock.ExpectQuery(query + "$").WillReturnRows(rows)
+	mock.ExpectQuery(query+" where e.transmission = \\? and e.drivetrain = \\? and e.power >= \\? and e.power <= \\?$").
+		WithArgs("DCT", "AWD", 300, 600).WillReturnRows(sqlmock.NewRows([]string{"carID"}))
+	mock.ExpectQuery(query+" where c.currency = \\? and c.listPrice >= \\? and c.listPrice <= \\?$").
+		WithArgs("EUR", int64(5000000), int64(9000000)).WillReturnRows(sqlmock.NewRows([]string{"carID"}))
+	mock.ExpectQuery(query+" where c.status = \\? and c.dealershipId = \\?$").
+		WithArgs(model.StatusOnLot, "d1").WillReturnRows(sqlmock.NewRows([]string{"carID"}))
+	mock.ExpectQuery(query).WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc   string
+		filter model.CarFilter
+		cars   []model.Car
+		err    error
+	}{
+		{"Fetch all Tesla cars", model.CarFilter{Brand: "Tesla"}, []model.Car{car}, nil},
+		{"Fetch all cars", model.CarFilter{}, []model.Car{}, nil},
+		{"Filter by engine specs", model.CarFilter{Transmission: "DCT", Drivetrain: "AWD", MinPower: 300, MaxPower: 600}, []model.Car{}, nil},
+		{"Filter by price", model.CarFilter{Currency: "EUR", MinPrice: 5000000, MaxPrice: 9000000}, []model.Car{}, nil},
+		{"Stock of a dealership", model.CarFilter{Status: model.StatusOnLot, DealershipID: "d1"}, []model.Car{}, nil},
+		{"DB error", model.CarFilter{}, []model.Car{}, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		cars, err := store.Get(tc.filter)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.cars, cars, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Page(t *testing.T) {
 	car := car()
 
 	db, mock, err := sqlmock.New()
@@ -37,26 +98,93 @@ func TestStore_GetByBrand(t *testing.T) {
 	defer db.Close()
 
 	store := New(db)
-	rows := sqlmock.NewRows([]string{"carID", "name", "yearOfManufacture", "brand", "fuelType", "engineId"}).
-		AddRow(car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID, nil, nil, car.Status, nil, nil, nil,
+			car.DealershipID)
+
+	count := "select count\\(\\*\\) from cars c join engines e on e.engineId = c.engineId where c.brand = \\?$"
+	query := "select c.carId, .* from cars c\\s+join engines e on e.engineId = c.engineId where c.brand = \\? " +
+		"order by c.carId limit \\? offset \\?$"
 
-	mock.ExpectQuery("select \\* from cars where brand = \\?").WithArgs("Tesla").WillReturnRows(rows)
-	mock.ExpectQuery("select \\* from cars").WillReturnRows(rows)
-	mock.ExpectQuery("select \\* from cars").WillReturnError(errors.New("DB error"))
+	mock.ExpectQuery(count).WithArgs("Tesla").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(query).WithArgs("Tesla", 1, 2).WillReturnRows(rows)
+
+	// a page past the last car is not fetched
+	mock.ExpectQuery(count).WithArgs("Tesla").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	mock.ExpectQuery(count).WithArgs("Tesla").WillReturnError(errors.New("DB error"))
 
 	tests := []struct {
-		desc  string
-		brand string
-		cars  []model.Car
-		err   error
+		desc   string
+		offset int
+		cars   []model.Car
+		total  int
+		err    error
+	}{
+		{"Last page", 2, []model.Car{car}, 3, nil},
+		{"Past the last page", 3, []model.Car{}, 3, nil},
+		{"DB error", 0, nil, 0, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		cars, total, err := store.Page(model.CarFilter{Brand: "Tesla"}, 1, tc.offset)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.cars, cars, "Testcase[%v] (%v)", i, tc.desc)
+		assert.Equalf(t, tc.total, total, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_Each(t *testing.T) {
+	car := car()
+	car.Engine.Range = 500
+	car.Engine.BatteryCapacity = 75.5
+	car.Price = &model.Price{Currency: "EUR", List: 5000000, Minimum: 4500000}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	cols := append(columns(), "displacement", "noOfCylinder", "range", "batteryCapacity", "electricRange", "tankCapacity",
+		"noOfMotors", "power", "torque", "transmission", "gears", "drivetrain")
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(cols).
+			AddRow(car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID, nil, nil, car.Status,
+				"EUR", 5000000, 4500000, car.DealershipID, 0, 0, 500, 75.5, 0, 0, 0, 0, 0, "", 0, "")
+	}
+
+	query := "select c.carId, .+, c.dealershipId, e.displacement, .+, e.drivetrain from cars c join engines e on e.engineId = c.engineId"
+
+	mock.ExpectQuery(query+" where c.brand = \\? and c.dealershipId = \\?$").WithArgs("Tesla", "d1").WillReturnRows(rows())
+	mock.ExpectQuery(query + "$").WillReturnRows(rows())
+	mock.ExpectQuery(query + "$").WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc   string
+		filter model.CarFilter
+		fnErr  error
+		cars   []model.Car
+		err    error
 	}{
-		{"Fetch all Tesla cars", "Tesla", []model.Car{car}, nil},
-		{"Fetch all cars", "", []model.Car{}, nil},
-		{"DB error", "", []model.Car{}, errors.New("DB error")},
+		{"Stream the cars of a dealership", model.CarFilter{Brand: "Tesla", DealershipID: "d1"}, nil, []model.Car{car}, nil},
+		{"Error of fn", model.CarFilter{}, errors.New("write error"), []model.Car{car}, errors.New("write error")},
+		{"DB error", model.CarFilter{}, nil, nil, errors.New("DB error")},
 	}
 
 	for i, tc := range tests {
-		cars, err := store.GetByBrand(tc.brand)
+		var cars []model.Car
+
+		err := store.Each(tc.filter, func(c *model.Car) error {
+			cars = append(cars, *c)
+			return tc.fnErr
+		})
 
 		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
 
@@ -66,6 +194,7 @@ func TestStore_GetByBrand(t *testing.T) {
 
 func TestStore_GetByID(t *testing.T) {
 	car := car()
+	car.Price = &model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000}
 
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -75,11 +204,12 @@ func TestStore_GetByID(t *testing.T) {
 	defer db.Close()
 
 	store := New(db)
-	rows := sqlmock.NewRows([]string{"carID", "name", "yearOfManufacture", "brand", "fuelType", "engineId"}).
-		AddRow(car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID, nil, nil, car.Status, "EUR", 8999900, 8500000,
+			car.DealershipID)
 
 	mock.ExpectQuery("select \\* from cars where carId = \\?").WithArgs(car.ID).WillReturnRows(rows)
-	mock.ExpectQuery("select \\* from cars where carId = \\?").WithArgs("1").WillReturnError(customErrors.CarNotExists())
+	mock.ExpectQuery("select \\* from cars where carId = \\?").WithArgs("1").WillReturnError(sql.ErrNoRows)
 	mock.ExpectQuery("select \\* from cars where carId = \\?").WithArgs("2").WillReturnError(errors.New("DB error"))
 
 	tests := []struct {
@@ -102,6 +232,44 @@ func TestStore_GetByID(t *testing.T) {
 	}
 }
 
+func TestStore_GetByVIN(t *testing.T) {
+	car := car()
+	car.VIN = "5YJ3E1EA6LF000001"
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID, nil, car.VIN, car.Status, nil, nil, nil,
+			car.DealershipID)
+
+	mock.ExpectQuery("select \\* from cars where vin = \\?").WithArgs(car.VIN).WillReturnRows(rows)
+	mock.ExpectQuery("select \\* from cars where vin = \\?").WithArgs("1M8GDM9AXKP042788").WillReturnError(sql.ErrNoRows)
+
+	tests := []struct {
+		desc string
+		vin  string
+		car  *model.Car
+		err  error
+	}{
+		{"Success", car.VIN, &car, nil},
+		{"Not exists", "1M8GDM9AXKP042788", nil, customErrors.CarNotExists()},
+	}
+
+	for i, tc := range tests {
+		car, err := store.GetByVIN(tc.vin)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.car, car, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
 func TestStore_Create(t *testing.T) {
 	car := car()
 	car2 := car
@@ -114,15 +282,26 @@ func TestStore_Create(t *testing.T) {
 	defer db.Close()
 
 	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
 
-	query := "insert into cars \\(carId, name, yearOfManufacture, brand, fuelType, engineId\\) values \\(\\?, \\?, \\?, \\?, \\?, \\?\\)"
+	query := "insert into cars \\(carId, name, yearOfManufacture, brand, fuelType, engineId, trimId, vin, status, dealershipId\\)\\s+" +
+		"values \\(\\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?\\)"
+	history := "insert into car_status_history \\(carId, fromStatus, toStatus, changedAt\\)"
 
-	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(sqlmock.AnyArg(), car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID, nil, nil,
+		car.Status, car.DealershipID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(history).WithArgs(sqlmock.AnyArg(), nil, car.Status, at).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
-	prep = mock.ExpectPrepare(query)
-	prep.ExpectExec().WillReturnError(errors.New("DB error"))
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WillReturnError(errors.New("DB error"))
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(history).WillReturnError(errors.New("DB error"))
+	mock.ExpectRollback()
 
 	tests := []struct {
 		desc     string
@@ -132,10 +311,11 @@ func TestStore_Create(t *testing.T) {
 	}{
 		{"Success", &car, &car2, nil},
 		{"DB error", &model.Car{}, nil, errors.New("DB error")},
+		{"History not recorded", &model.Car{}, nil, errors.New("DB error")},
 	}
 
 	for i, tc := range tests {
-		car, err := store.Create(tc.input)
+		car, err := store.Create(tc.input, at)
 
 		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
 
@@ -145,6 +325,8 @@ func TestStore_Create(t *testing.T) {
 
 		assert.Equalf(t, tc.expected, car, "Testcase[%v] (%v)", i, tc.desc)
 	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
 func TestStore_Update(t *testing.T) {
@@ -159,12 +341,12 @@ func TestStore_Update(t *testing.T) {
 
 	store := New(db)
 
-	query := "update cars set name = \\?, yearOfManufacture = \\?, brand = \\?, fuelType = \\? where carId = \\?"
+	query := "update cars set name = \\?, yearOfManufacture = \\?, brand = \\?, fuelType = \\?, trimId = \\?, vin = \\? where carId = \\?"
 
 	// success case
 
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs("Roadster", 2000, "Tesla", "Electric", car.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.ExpectExec().WithArgs("Roadster", 2000, "Tesla", "Electric", nil, nil, car.ID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	// DB error
 
@@ -208,7 +390,7 @@ func TestStore_Delete(t *testing.T) {
 	prep.ExpectExec().WithArgs(car.ID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	prep = mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(car.ID).WillReturnError(customErrors.CarNotExists())
+	prep.ExpectExec().WithArgs(car.ID).WillReturnResult(sqlmock.NewResult(0, 0))
 
 	prep = mock.ExpectPrepare(query)
 	prep.ExpectExec().WillReturnError(errors.New("DB error"))
@@ -229,3 +411,486 @@ func TestStore_Delete(t *testing.T) {
 		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
 	}
 }
+
+func TestStore_Transition(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	update := "update cars set status = \\? where carId = \\? and status = \\?"
+	history := "insert into car_status_history \\(carId, fromStatus, toStatus, changedAt\\)"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs(model.StatusSold, "1", model.StatusOnLot).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(history).WithArgs("1", "on-lot", model.StatusSold, at).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs(model.StatusSold, "2", model.StatusOnLot).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs(model.StatusSold, "3", model.StatusOnLot).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(history).WillReturnError(errors.New("DB error"))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{"Success", "1", nil},
+		{"Status changed concurrently", "2", customErrors.Conflict{Entity: "Car", Reason: "status is no longer on-lot"}},
+		{"History not recorded", "3", errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		err := store.Transition(tc.id, &model.StatusChange{From: model.StatusOnLot, To: model.StatusSold, At: at})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_GetStatusHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"fromStatus", "toStatus", "changedAt"}).
+		AddRow(nil, "in-transit", at).
+		AddRow("in-transit", "on-lot", at.Add(time.Hour))
+
+	mock.ExpectQuery("select fromStatus, toStatus, changedAt from car_status_history where carId = \\?").
+		WithArgs("1").WillReturnRows(rows)
+
+	history, err := store.GetStatusHistory("1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []model.StatusChange{
+		{To: model.StatusInTransit, At: at},
+		{From: model.StatusInTransit, To: model.StatusOnLot, At: at.Add(time.Hour)},
+	}, history)
+}
+
+func TestStore_SetPrice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	update := "update cars set currency = \\?, listPrice = \\?, minPrice = \\? where carId = \\?"
+	history := "insert into car_prices \\(carId, currency, listPrice, minPrice, actor, reason, changedAt\\)"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs("EUR", int64(8999900), int64(8500000), "1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(history).WithArgs("1", "EUR", int64(8999900), int64(8500000), "admin:1a2b3c4d", "spring sale", at).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs("EUR", int64(8999900), nil, "2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(history).WithArgs("2", "EUR", int64(8999900), nil, "admin:1a2b3c4d", "spring sale", at).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WillReturnError(errors.New("DB error"))
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(history).WillReturnError(errors.New("DB error"))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		desc  string
+		id    string
+		price model.Price
+		err   error
+	}{
+		{"Success", "1", model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000}, nil},
+		{"Without minimum price", "2", model.Price{Currency: "EUR", List: 8999900}, nil},
+		{"DB error", "3", model.Price{Currency: "EUR", List: 8999900}, errors.New("DB error")},
+		{"History not recorded", "4", model.Price{Currency: "EUR", List: 8999900}, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		err := store.SetPrice(tc.id, &model.PriceChange{Price: tc.price, Actor: "admin:1a2b3c4d", Reason: "spring sale", At: at})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_GetPriceHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"currency", "listPrice", "minPrice", "actor", "reason", "changedAt"}).
+		AddRow("EUR", 9499900, 9000000, "admin:1a2b3c4d", "initial price", at).
+		AddRow("EUR", 8999900, nil, "admin:1a2b3c4d", "spring sale", at.Add(time.Hour))
+
+	mock.ExpectQuery("select currency, listPrice, minPrice, actor, reason, changedAt from car_prices where carId = \\?").
+		WithArgs("1").WillReturnRows(rows)
+
+	history, err := store.GetPriceHistory("1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []model.PriceChange{
+		{Price: model.Price{Currency: "EUR", List: 9499900, Minimum: 9000000}, Actor: "admin:1a2b3c4d", Reason: "initial price", At: at},
+		{Price: model.Price{Currency: "EUR", List: 8999900}, Actor: "admin:1a2b3c4d", Reason: "spring sale", At: at.Add(time.Hour)},
+	}, history)
+}
+
+func TestStore_Transfer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	update := "update cars set dealershipId = \\? where carId = \\? and dealershipId = \\?"
+	insert := "insert into car_transfers \\(carId, fromDealershipId, toDealershipId, actor, transferredAt\\)"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs("d2", "1", "d1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insert).WithArgs("1", "d1", "d2", "admin:1a2b3c4d", at).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs("d2", "2", "d1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs("d2", "3", "d1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insert).WillReturnError(errors.New("DB error"))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{"Success", "1", nil},
+		{"Transferred concurrently", "2", customErrors.Conflict{Entity: "Car", Reason: "dealership is no longer d1"}},
+		{"History not recorded", "3", errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		err := store.Transfer(tc.id, &model.Transfer{From: "d1", To: "d2", Actor: "admin:1a2b3c4d", At: at})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_GetTransfers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"fromDealershipId", "toDealershipId", "actor", "transferredAt"}).
+		AddRow("d1", "d2", "admin:1a2b3c4d", at).
+		AddRow("d2", "d1", "admin:1a2b3c4d", at.Add(time.Hour))
+
+	mock.ExpectQuery("select fromDealershipId, toDealershipId, actor, transferredAt from car_transfers\\s+where carId = \\?").
+		WithArgs("1").WillReturnRows(rows)
+
+	transfers, err := store.GetTransfers("1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Transfer{
+		{From: "d1", To: "d2", Actor: "admin:1a2b3c4d", At: at},
+		{From: "d2", To: "d1", Actor: "admin:1a2b3c4d", At: at.Add(time.Hour)},
+	}, transfers)
+}
+
+func TestStore_GetByIDs(t *testing.T) {
+	car := car()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID, nil, nil, car.Status, nil, nil, nil,
+			car.DealershipID)
+
+	mock.ExpectQuery("select \\* from cars where carId in \\(\\?, \\?\\)").WithArgs(car.ID, "1").WillReturnRows(rows)
+	mock.ExpectQuery("select \\* from cars where carId in \\(\\?\\)").WithArgs("2").WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc string
+		ids  []string
+		cars []model.Car
+		err  error
+	}{
+		{"Missing cars are skipped", []string{car.ID, "1"}, []model.Car{car}, nil},
+		{"DB error", []string{"2"}, nil, errors.New("DB error")},
+		{"No IDs", []string{}, []model.Car{}, nil},
+	}
+
+	for i, tc := range tests {
+		cars, err := store.GetByIDs(tc.ids)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.cars, cars, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_GetByVINs(t *testing.T) {
+	car := car()
+	car.VIN = "XP7YGCEL0YB000001"
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(car.ID, car.Name, car.YearOfManufacture, car.Brand, car.FuelType, car.Engine.ID, nil, car.VIN, car.Status, nil, nil, nil,
+			car.DealershipID)
+
+	mock.ExpectQuery("select \\* from cars where vin in \\(\\?, \\?\\)").WithArgs(car.VIN, "XP7YGCEL0YB000002").WillReturnRows(rows)
+	mock.ExpectQuery("select \\* from cars where vin in \\(\\?\\)").WithArgs("1").WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc string
+		vins []string
+		cars []model.Car
+		err  error
+	}{
+		{"VINs without a car are skipped", []string{car.VIN, "XP7YGCEL0YB000002"}, []model.Car{car}, nil},
+		{"DB error", []string{"1"}, nil, errors.New("DB error")},
+		{"No VINs", []string{}, []model.Car{}, nil},
+	}
+
+	for i, tc := range tests {
+		cars, err := store.GetByVINs(tc.vins)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.cars, cars, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_GetOrderedIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	mock.ExpectQuery("select distinct carId from orders where carId in \\(\\?, \\?\\)").WithArgs("1", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"carId"}).AddRow("2"))
+	mock.ExpectQuery("select distinct carId from orders where carId in \\(\\?\\)").WithArgs("3").WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc string
+		ids  []string
+		want []string
+		err  error
+	}{
+		{"Cars without orders are skipped", []string{"1", "2"}, []string{"2"}, nil},
+		{"DB error", []string{"3"}, nil, errors.New("DB error")},
+		{"No IDs", []string{}, []string{}, nil},
+	}
+
+	for i, tc := range tests {
+		ids, err := store.GetOrderedIDs(tc.ids)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.want, ids, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_CreateMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	insertEngines := "insert into engines .* values \\(\\?(, \\?){12}\\), \\(\\?(, \\?){12}\\)$"
+	insertCars := "insert into cars .* values \\(\\?(, \\?){9}\\), \\(\\?(, \\?){9}\\)$"
+	insertChanges := "insert into car_status_history .* values \\(\\?, \\?, \\?, \\?\\), \\(\\?, \\?, \\?, \\?\\)$"
+	anyArg := sqlmock.AnyArg()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertEngines).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(insertCars).
+		WithArgs(anyArg, "Roadster", 2000, "Tesla", "Electric", anyArg, nil, nil, model.StatusInTransit, "d1",
+			anyArg, "Model S", 2012, "Tesla", "Electric", anyArg, nil, nil, model.StatusOnLot, "d1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(insertChanges).
+		WithArgs(anyArg, nil, model.StatusInTransit, at, anyArg, nil, model.StatusOnLot, at).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertEngines).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(insertCars).WillReturnError(errors.New("DB error"))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		desc string
+		err  error
+	}{
+		{"Success", nil},
+		{"Cars not inserted", errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		cars := []*model.Car{
+			{Name: "Roadster", YearOfManufacture: 2000, Brand: "Tesla", FuelType: "Electric", Status: model.StatusInTransit, DealershipID: "d1"},
+			{Name: "Model S", YearOfManufacture: 2012, Brand: "Tesla", FuelType: "Electric", Status: model.StatusOnLot, DealershipID: "d1"},
+		}
+
+		err := store.CreateMany(cars, at)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		// cars and engines are given new IDs
+		assert.NotEqualf(t, "", cars[1].ID, "Testcase[%v] (%v)", i, tc.desc)
+		assert.NotEqualf(t, "", cars[1].Engine.ID, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_UpdateMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	car := car()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("update cars set").
+		WithArgs(car.Name, car.YearOfManufacture, car.Brand, car.FuelType, nil, nil, car.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("update engines set").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("update cars set").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("update engines set").WillReturnError(errors.New("DB error"))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		desc string
+		err  error
+	}{
+		{"Success", nil},
+		{"Engine not updated", errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		err := store.UpdateMany([]*model.Car{&car})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_DeleteMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	cars := []model.Car{car(), car()}
+
+	deleteCars := "delete from cars where carId in \\(\\?, \\?\\)"
+	deleteEngines := "delete from engines where engineId in \\(\\?, \\?\\)"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteCars).WithArgs(cars[0].ID, cars[1].ID).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(deleteEngines).WithArgs(cars[0].Engine.ID, cars[1].Engine.ID).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteCars).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		desc string
+		err  error
+	}{
+		{"Success", nil},
+		{"Deleted concurrently", customErrors.Conflict{Entity: "Car", Reason: "some of the cars have been deleted concurrently"}},
+	}
+
+	for i, tc := range tests {
+		err := store.DeleteMany(cars)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
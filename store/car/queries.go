@@ -1,11 +1,57 @@
 package car
 
 const (
-	getAllCars    = "select * from cars"
-	getCarByBrand = "select * from cars where brand = ?"
-	getCarByID    = "select * from cars where carId = ?"
-	insertCar     = `insert into cars (carId, name, yearOfManufacture, brand, fuelType, engineId)
-					values (?, ?, ?, ?, ?, ?)`
-	updateCar = `update cars set name = ?, yearOfManufacture = ?, brand = ?, fuelType = ? where carId = ?`
-	deleteCar = `delete from cars where carId = ?`
+	// getCars is completed by the conditions of a car filter, engines are joined to filter on their specs
+	getCars = `select c.carId, c.name, c.yearOfManufacture, c.brand, c.fuelType, c.engineId, c.trimId, c.vin, c.status,
+					c.currency, c.listPrice, c.minPrice, c.dealershipId from cars c
+					join engines e on e.engineId = c.engineId`
+	// getCarsWithEngines is completed like getCars, every car is selected with the specs of its engine
+	getCarsWithEngines = "select c.carId, c.name, c.yearOfManufacture, c.brand, c.fuelType, c.engineId, c.trimId, c.vin, c.status, " +
+		"c.currency, c.listPrice, c.minPrice, c.dealershipId, e.displacement, e.noOfCylinder, e.`range`, e.batteryCapacity, " +
+		"e.electricRange, e.tankCapacity, e.noOfMotors, e.power, e.torque, e.transmission, e.gears, e.drivetrain " +
+		"from cars c join engines e on e.engineId = c.engineId"
+
+	// countCars is completed like getCars, pageCars completes getCars by the limit and offset of a page
+	countCars = "select count(*) from cars c join engines e on e.engineId = c.engineId"
+	pageCars  = " order by c.carId limit ? offset ?"
+
+	getCarByID  = "select * from cars where carId = ?"
+	getCarByVIN = "select * from cars where vin = ?"
+	insertCar   = insertCars + carValues
+	updateCar   = `update cars set name = ?, yearOfManufacture = ?, brand = ?, fuelType = ?, trimId = ?, vin = ? where carId = ?`
+	deleteCar   = `delete from cars where carId = ?`
+
+	// insertCars is completed by a carValues group for every car
+	insertCars = `insert into cars (carId, name, yearOfManufacture, brand, fuelType, engineId, trimId, vin, status, dealershipId)
+					values `
+	carValues  = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	carColumns = 10
+
+	// getCarsByIDs, deleteCars and getOrderedCars are completed by the placeholder group of the car IDs,
+	// getCarsByVINs by the one of the VINs
+	getCarsByIDs   = "select * from cars where carId in "
+	deleteCars     = "delete from cars where carId in "
+	getCarsByVINs  = "select * from cars where vin in "
+	getOrderedCars = "select distinct carId from orders where carId in "
+
+	// updateCarStatus only changes the status if it was not changed concurrently
+	updateCarStatus    = "update cars set status = ? where carId = ? and status = ?"
+	insertStatusChange = insertStatusChanges + statusChangeValues
+	getStatusHistory   = "select fromStatus, toStatus, changedAt from car_status_history where carId = ? order by changedAt, id"
+
+	// insertStatusChanges is completed by a statusChangeValues group for every change
+	insertStatusChanges = "insert into car_status_history (carId, fromStatus, toStatus, changedAt) values "
+	statusChangeValues  = "(?, ?, ?, ?)"
+
+	updateCarPrice    = "update cars set currency = ?, listPrice = ?, minPrice = ? where carId = ?"
+	insertPriceChange = `insert into car_prices (carId, currency, listPrice, minPrice, actor, reason, changedAt)
+					values (?, ?, ?, ?, ?, ?, ?)`
+	getPriceHistory = "select currency, listPrice, minPrice, actor, reason, changedAt from car_prices where carId = ? order by changedAt, id"
+
+	// updateCarDealership only moves the car if it was not moved concurrently
+	updateCarDealership = "update cars set dealershipId = ? where carId = ? and dealershipId = ?"
+	insertTransfer      = `insert into car_transfers (carId, fromDealershipId, toDealershipId, actor, transferredAt)
+					values (?, ?, ?, ?, ?)`
+	getTransfers = `select fromDealershipId, toDealershipId, actor, transferredAt from car_transfers
+					where carId = ? order by transferredAt, id`
 )
@@ -0,0 +1,124 @@
+package carmodel
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/google/uuid"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store/dberr"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+//nolint:revive //store should not be exported
+func New(db *sql.DB) store {
+	return store{db: db}
+}
+
+func (s store) GetByBrand(brand string) ([]model.CarModel, error) {
+	var carModel model.CarModel
+
+	carModels := make([]model.CarModel, 0)
+
+	rows, err := s.db.Query(getModelsByBrand, brand)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.ModelNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		err := rows.Scan(&carModel.ID, &carModel.Brand, &carModel.Name, &carModel.CarCount)
+		if err != nil {
+			return nil, err
+		}
+
+		carModels = append(carModels, carModel)
+	}
+
+	return carModels, nil
+}
+
+func (s store) GetByID(id string) (*model.CarModel, error) {
+	var carModel model.CarModel
+
+	row := s.db.QueryRow(getModelByID, id)
+
+	err := row.Scan(&carModel.ID, &carModel.Brand, &carModel.Name, &carModel.CarCount)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.ModelNotExists())
+	}
+
+	return &carModel, nil
+}
+
+func (s store) Create(carModel *model.CarModel) (*model.CarModel, error) {
+	carModel.ID = uuid.NewString()
+
+	stmt, err := s.db.Prepare(insertModel)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.ModelNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(carModel.ID, carModel.Brand, carModel.Name)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.ModelNotExists())
+	}
+
+	return carModel, nil
+}
+
+func (s store) Update(carModel *model.CarModel) (*model.CarModel, error) {
+	stmt, err := s.db.Prepare(updateModel)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.ModelNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(carModel.Name, carModel.ID)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.ModelNotExists())
+	}
+
+	return carModel, nil
+}
+
+func (s store) Delete(id string) error {
+	stmt, err := s.db.Prepare(deleteModel)
+	if err != nil {
+		return dberr.Classify(err, customErrors.ModelNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return dberr.Classify(err, customErrors.ModelNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.ModelNotExists())
+	}
+
+	if n == 0 {
+		return customErrors.ModelNotExists()
+	}
+
+	return nil
+}
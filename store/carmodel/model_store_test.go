@@ -0,0 +1,169 @@
+package carmodel
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func TestStore_GetByBrand(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows([]string{"modelId", "brand", "name", "count"}).
+		AddRow("m1", "Tesla", "Model 3", 2).
+		AddRow("m2", "Tesla", "Model S", 0)
+
+	query := "select m.modelId, m.brand, m.name, count\\(c.carId\\) from models m"
+
+	mock.ExpectQuery(query).WithArgs("Tesla").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("BMW").WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc   string
+		brand  string
+		models []model.CarModel
+		err    error
+	}{
+		{"Success", "Tesla", []model.CarModel{
+			{ID: "m1", Brand: "Tesla", Name: "Model 3", CarCount: 2},
+			{ID: "m2", Brand: "Tesla", Name: "Model S", CarCount: 0},
+		}, nil},
+		{"DB error", "BMW", nil, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		models, err := store.GetByBrand(tc.brand)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.models, models, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows([]string{"modelId", "brand", "name", "count"}).AddRow("m1", "Tesla", "Model 3", 2)
+
+	query := "select m.modelId, m.brand, m.name, count\\(c.carId\\) from models m"
+
+	mock.ExpectQuery(query).WithArgs("m1").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("m2").WillReturnError(sql.ErrNoRows)
+
+	tests := []struct {
+		desc     string
+		id       string
+		expected *model.CarModel
+		err      error
+	}{
+		{"Success", "m1", &model.CarModel{ID: "m1", Brand: "Tesla", Name: "Model 3", CarCount: 2}, nil},
+		{"Model not exists", "m2", nil, customErrors.ModelNotExists()},
+	}
+
+	for i, tc := range tests {
+		carModel, err := store.GetByID(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, carModel, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	missingBrand := &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"}
+
+	query := "insert into models \\(modelId, brand, name\\) values \\(\\?, \\?, \\?\\)"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(sqlmock.AnyArg(), "Tesla", "Model 3").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(sqlmock.AnyArg(), "Audi", "A4").WillReturnError(missingBrand)
+
+	tests := []struct {
+		desc  string
+		input *model.CarModel
+		err   error
+	}{
+		{"Success", &model.CarModel{Brand: "Tesla", Name: "Model 3"}, nil},
+		{"Brand not exists", &model.CarModel{Brand: "Audi", Name: "A4"},
+			customErrors.Conflict{Entity: "Model", Reason: "references a missing entity", Err: missingBrand}},
+	}
+
+	for i, tc := range tests {
+		carModel, err := store.Create(tc.input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		if err == nil {
+			assert.NotEmptyf(t, carModel.ID, "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	hasTrims := &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"}
+
+	query := "delete from models where modelId = \\?"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("m1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("m2").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("m3").WillReturnError(hasTrims)
+
+	tests := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{"Success", "m1", nil},
+		{"Model not exists", "m2", customErrors.ModelNotExists()},
+		{"Model with trims", "m3", customErrors.Conflict{Entity: "Model", Reason: "referenced by another entity", Err: hasTrims}},
+	}
+
+	for i, tc := range tests {
+		err := store.Delete(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
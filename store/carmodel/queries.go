@@ -0,0 +1,16 @@
+package carmodel
+
+// cars on the lot and reserved cars are counted as in stock
+const (
+	getModelsByBrand = `select m.modelId, m.brand, m.name, count(c.carId) from models m
+						left join trims t on t.modelId = m.modelId
+						left join cars c on c.trimId = t.trimId and c.status in ('on-lot', 'reserved')
+						where m.brand = ? group by m.modelId, m.brand, m.name order by m.name`
+	getModelByID = `select m.modelId, m.brand, m.name, count(c.carId) from models m
+						left join trims t on t.modelId = m.modelId
+						left join cars c on c.trimId = t.trimId and c.status in ('on-lot', 'reserved')
+						where m.modelId = ? group by m.modelId, m.brand, m.name`
+	insertModel = "insert into models (modelId, brand, name) values (?, ?, ?)"
+	updateModel = "update models set name = ? where modelId = ?"
+	deleteModel = "delete from models where modelId = ?"
+)
@@ -0,0 +1,144 @@
+package customer
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/google/uuid"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store/dberr"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+//nolint:revive //store should not be exported
+func New(db *sql.DB) store {
+	return store{db: db}
+}
+
+func (s store) GetAll() ([]model.Customer, error) {
+	customers := make([]model.Customer, 0)
+
+	rows, err := s.db.Query(getCustomers)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CustomerNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		customer, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		customers = append(customers, *customer)
+	}
+
+	return customers, nil
+}
+
+func (s store) GetByID(id string) (*model.Customer, error) {
+	customer, err := scan(s.db.QueryRow(getCustomerByID, id))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CustomerNotExists())
+	}
+
+	return customer, nil
+}
+
+func (s store) Create(customer *model.Customer) (*model.Customer, error) {
+	customer.ID = uuid.NewString()
+
+	stmt, err := s.db.Prepare(insertCustomer)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CustomerNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(customer.ID, customer.Name, customer.Email, nullString(customer.Phone))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CustomerNotExists())
+	}
+
+	return customer, nil
+}
+
+func (s store) Update(customer *model.Customer) (*model.Customer, error) {
+	stmt, err := s.db.Prepare(updateCustomer)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CustomerNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(customer.Name, customer.Email, nullString(customer.Phone), customer.ID)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.CustomerNotExists())
+	}
+
+	return customer, nil
+}
+
+func (s store) Delete(id string) error {
+	stmt, err := s.db.Prepare(deleteCustomer)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CustomerNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return dberr.Classify(err, customErrors.CustomerNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.CustomerNotExists())
+	}
+
+	if n == 0 {
+		return customErrors.CustomerNotExists()
+	}
+
+	return nil
+}
+
+// row is implemented by both *sql.Row and *sql.Rows
+type row interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(r row) (*model.Customer, error) {
+	var (
+		customer model.Customer
+		phone    sql.NullString
+	)
+
+	err := r.Scan(&customer.ID, &customer.Name, &customer.Email, &phone)
+	if err != nil {
+		return nil, err
+	}
+
+	customer.Phone = phone.String
+
+	return &customer, nil
+}
+
+// nullString maps the empty string to NULL, for optional values
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,160 @@
+package customer
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func TestStore_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows([]string{"customerId", "name", "email", "phone"}).
+		AddRow("c1", "Jane Doe", "jane@example.com", "+49 30 1234").
+		AddRow("c2", "John Roe", "john@example.com", nil)
+
+	query := "select customerId, name, email, phone from customers order by name"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery(query).WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc      string
+		customers []model.Customer
+		err       error
+	}{
+		{"Success", []model.Customer{
+			{ID: "c1", Name: "Jane Doe", Email: "jane@example.com", Phone: "+49 30 1234"},
+			{ID: "c2", Name: "John Roe", Email: "john@example.com"},
+		}, nil},
+		{"DB error", nil, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		customers, err := store.GetAll()
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.customers, customers, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows([]string{"customerId", "name", "email", "phone"}).AddRow("c1", "Jane Doe", "jane@example.com", nil)
+
+	query := "select customerId, name, email, phone from customers where customerId = \\?"
+
+	mock.ExpectQuery(query).WithArgs("c1").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("c2").WillReturnError(sql.ErrNoRows)
+
+	tests := []struct {
+		desc     string
+		id       string
+		customer *model.Customer
+		err      error
+	}{
+		{"Success", "c1", &model.Customer{ID: "c1", Name: "Jane Doe", Email: "jane@example.com"}, nil},
+		{"Customer not exists", "c2", nil, customErrors.CustomerNotExists()},
+	}
+
+	for i, tc := range tests {
+		customer, err := store.GetByID(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.customer, customer, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+
+	query := "insert into customers \\(customerId, name, email, phone\\) values \\(\\?, \\?, \\?, \\?\\)"
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(sqlmock.AnyArg(), "Jane Doe", "jane@example.com", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(sqlmock.AnyArg(), "Jane Doe", "jane@example.com", nil).
+		WillReturnError(duplicate)
+
+	tests := []struct {
+		desc string
+		err  error
+	}{
+		{"Success", nil},
+		{"Email exists", customErrors.Conflict{Entity: "Customer", Reason: "duplicate entry", Err: duplicate}},
+	}
+
+	for i, tc := range tests {
+		customer, err := store.Create(&model.Customer{Name: "Jane Doe", Email: "jane@example.com"})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		if err == nil {
+			assert.NotEmptyf(t, customer.ID, "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	referenced := &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"}
+
+	query := "delete from customers where customerId = \\?"
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs("c1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(query).ExpectExec().WithArgs("c2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectPrepare(query).ExpectExec().WithArgs("c3").WillReturnError(referenced)
+
+	tests := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{"Success", "c1", nil},
+		{"Customer not exists", "c2", customErrors.CustomerNotExists()},
+		{"Customer with orders", "c3", customErrors.Conflict{Entity: "Customer", Reason: "referenced by another entity", Err: referenced}},
+	}
+
+	for i, tc := range tests {
+		err := store.Delete(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
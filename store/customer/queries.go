@@ -0,0 +1,9 @@
+package customer
+
+const (
+	getCustomers    = "select customerId, name, email, phone from customers order by name, customerId"
+	getCustomerByID = "select customerId, name, email, phone from customers where customerId = ?"
+	insertCustomer  = "insert into customers (customerId, name, email, phone) values (?, ?, ?, ?)"
+	updateCustomer  = "update customers set name = ?, email = ?, phone = ? where customerId = ?"
+	deleteCustomer  = "delete from customers where customerId = ?"
+)
@@ -0,0 +1,59 @@
+package dberr
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"syscall"
+
+	"github.com/go-sql-driver/mysql"
+
+	customErrors "carAPI/custom-errors"
+)
+
+// MySQL server error numbers which are classified by Classify
+const (
+	errDuplicateEntry    = 1062
+	errRowIsReferenced   = 1451
+	errNoReferencedRow   = 1452
+	errLockWaitTimeout   = 1205
+	errLockDeadlock      = 1213
+	errTooManyConnection = 1040
+)
+
+// Classify classifies an error returned by the DB while operating on entity into one of the custom errors,
+// errors which cannot be classified are returned as is
+func Classify(err error, entity customErrors.EntityNotExists) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case errDuplicateEntry:
+			return customErrors.Conflict{Entity: string(entity), Reason: "duplicate entry", Err: err}
+		case errRowIsReferenced:
+			return customErrors.Conflict{Entity: string(entity), Reason: "referenced by another entity", Err: err}
+		case errNoReferencedRow:
+			return customErrors.Conflict{Entity: string(entity), Reason: "references a missing entity", Err: err}
+		case errLockWaitTimeout, errLockDeadlock, errTooManyConnection:
+			return customErrors.Unavailable{Err: err}
+		}
+
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) || errors.As(err, &netErr) {
+		return customErrors.Unavailable{Err: err}
+	}
+
+	return err
+}
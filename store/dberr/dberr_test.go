@@ -0,0 +1,47 @@
+package dberr
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+)
+
+func TestClassify(t *testing.T) {
+	dbErr := errors.New("DB error")
+
+	tests := []struct {
+		desc     string
+		err      error
+		sentinel error
+	}{
+		{"No rows", sql.ErrNoRows, customErrors.ErrNotFound},
+		{"Wrapped no rows", fmt.Errorf("scan: %w", sql.ErrNoRows), customErrors.ErrNotFound},
+		{"Duplicate entry", &mysql.MySQLError{Number: errDuplicateEntry}, customErrors.ErrConflict},
+		{"Row is referenced", &mysql.MySQLError{Number: errRowIsReferenced}, customErrors.ErrConflict},
+		{"No referenced row", &mysql.MySQLError{Number: errNoReferencedRow}, customErrors.ErrConflict},
+		{"Deadlock", &mysql.MySQLError{Number: errLockDeadlock}, customErrors.ErrUnavailable},
+		{"Bad connection", driver.ErrBadConn, customErrors.ErrUnavailable},
+		{"Connection refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, customErrors.ErrUnavailable},
+		{"Unknown error", dbErr, dbErr},
+	}
+
+	for i, tc := range tests {
+		err := Classify(tc.err, customErrors.CarNotExists())
+
+		assert.Truef(t, errors.Is(err, tc.sentinel), "Testcase[%v] (%v)", i, tc.desc)
+
+		// the original error must stay reachable for logging
+		assert.Truef(t, errors.Is(err, tc.err) || errors.Is(tc.err, sql.ErrNoRows), "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, Classify(nil, customErrors.CarNotExists()))
+}
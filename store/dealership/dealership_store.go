@@ -0,0 +1,119 @@
+package dealership
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/google/uuid"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store/dberr"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+//nolint:revive //store should not be exported
+func New(db *sql.DB) store {
+	return store{db: db}
+}
+
+func (s store) GetAll() ([]model.Dealership, error) {
+	dealerships := make([]model.Dealership, 0)
+
+	rows, err := s.db.Query(getDealerships)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.DealershipNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		dealership, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		dealerships = append(dealerships, *dealership)
+	}
+
+	return dealerships, nil
+}
+
+func (s store) GetByID(id string) (*model.Dealership, error) {
+	dealership, err := scan(s.db.QueryRow(getDealershipByID, id))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.DealershipNotExists())
+	}
+
+	return dealership, nil
+}
+
+func (s store) Create(dealership *model.Dealership) (*model.Dealership, error) {
+	dealership.ID = uuid.NewString()
+
+	stmt, err := s.db.Prepare(insertDealership)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.DealershipNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(dealership.ID, dealership.Name, nullString(dealership.City))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.DealershipNotExists())
+	}
+
+	return dealership, nil
+}
+
+func (s store) Update(dealership *model.Dealership) (*model.Dealership, error) {
+	stmt, err := s.db.Prepare(updateDealership)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.DealershipNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(dealership.Name, nullString(dealership.City), dealership.ID)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.DealershipNotExists())
+	}
+
+	return dealership, nil
+}
+
+// row is implemented by both *sql.Row and *sql.Rows
+type row interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(r row) (*model.Dealership, error) {
+	var (
+		dealership model.Dealership
+		city       sql.NullString
+	)
+
+	err := r.Scan(&dealership.ID, &dealership.Name, &city)
+	if err != nil {
+		return nil, err
+	}
+
+	dealership.City = city.String
+
+	return &dealership, nil
+}
+
+// nullString maps the empty string to NULL, for optional values
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,146 @@
+package dealership
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func TestStore_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows([]string{"dealershipId", "name", "city"}).
+		AddRow("d1", "Main showroom", nil).
+		AddRow("d2", "North", "Hamburg")
+
+	query := "select dealershipId, name, city from dealerships order by name"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery(query).WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc        string
+		dealerships []model.Dealership
+		err         error
+	}{
+		{"Success", []model.Dealership{
+			{ID: "d1", Name: "Main showroom"},
+			{ID: "d2", Name: "North", City: "Hamburg"},
+		}, nil},
+		{"DB error", nil, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		dealerships, err := store.GetAll()
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.dealerships, dealerships, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows([]string{"dealershipId", "name", "city"}).AddRow("d2", "North", "Hamburg")
+
+	query := "select dealershipId, name, city from dealerships where dealershipId = \\?"
+
+	mock.ExpectQuery(query).WithArgs("d2").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("d3").WillReturnError(sql.ErrNoRows)
+
+	tests := []struct {
+		desc       string
+		id         string
+		dealership *model.Dealership
+		err        error
+	}{
+		{"Success", "d2", &model.Dealership{ID: "d2", Name: "North", City: "Hamburg"}, nil},
+		{"Dealership not exists", "d3", nil, customErrors.DealershipNotExists()},
+	}
+
+	for i, tc := range tests {
+		dealership, err := store.GetByID(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.dealership, dealership, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+
+	query := "insert into dealerships \\(dealershipId, name, city\\) values \\(\\?, \\?, \\?\\)"
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(sqlmock.AnyArg(), "North", "Hamburg").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(sqlmock.AnyArg(), "North", "Hamburg").
+		WillReturnError(duplicate)
+
+	tests := []struct {
+		desc string
+		err  error
+	}{
+		{"Success", nil},
+		{"Name exists", customErrors.Conflict{Entity: "Dealership", Reason: "duplicate entry", Err: duplicate}},
+	}
+
+	for i, tc := range tests {
+		dealership, err := store.Create(&model.Dealership{Name: "North", City: "Hamburg"})
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		if tc.err == nil {
+			assert.NotEmptyf(t, dealership.ID, "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
+
+func TestStore_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	query := "update dealerships set name = \\?, city = \\? where dealershipId = \\?"
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs("North", nil, "d2").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	dealership, err := store.Update(&model.Dealership{ID: "d2", Name: "North"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &model.Dealership{ID: "d2", Name: "North"}, dealership)
+}
@@ -0,0 +1,8 @@
+package dealership
+
+const (
+	getDealerships    = "select dealershipId, name, city from dealerships order by name, dealershipId"
+	getDealershipByID = "select dealershipId, name, city from dealerships where dealershipId = ?"
+	insertDealership  = "insert into dealerships (dealershipId, name, city) values (?, ?, ?)"
+	updateDealership  = "update dealerships set name = ?, city = ? where dealershipId = ?"
+)
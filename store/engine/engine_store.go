@@ -8,6 +8,8 @@ import (
 
 	customErrors "carAPI/custom-errors"
 	"carAPI/model"
+	"carAPI/store/dberr"
+	"carAPI/store/sqlutil"
 )
 
 type engineStore struct {
@@ -20,13 +22,11 @@ func NewEngineStore(db *sql.DB) engineStore {
 }
 
 func (s engineStore) GetAll() (map[string]model.Engine, error) {
-	var engine model.Engine
-
 	engines := make(map[string]model.Engine)
 
 	rows, err := s.db.Query(getAllEngines)
 	if err != nil {
-		return nil, err
+		return nil, dberr.Classify(err, customErrors.EngineNotExists())
 	}
 
 	defer func() {
@@ -39,32 +39,62 @@ func (s engineStore) GetAll() (map[string]model.Engine, error) {
 	}()
 
 	for rows.Next() {
-		err := rows.Scan(&engine.ID, &engine.Displacement, &engine.NoOfCylinders, &engine.Range)
+		engine, err := scan(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		engines[engine.ID] = engine
+		engines[engine.ID] = *engine
 	}
 
 	return engines, nil
 }
 
 func (s engineStore) GetByID(id string) (*model.Engine, error) {
-	var engine model.Engine
+	engine, err := scan(s.db.QueryRow(getEngineByID, id))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.EngineNotExists())
+	}
 
-	row := s.db.QueryRow(getEngineByID, id)
-	err := row.Scan(&engine.ID, &engine.Displacement, &engine.NoOfCylinders, &engine.Range)
+	return engine, nil
+}
+
+func (s engineStore) GetByIDs(ids []string) (map[string]model.Engine, error) {
+	engines := make(map[string]model.Engine, len(ids))
+
+	if len(ids) == 0 {
+		return engines, nil
+	}
 
-	if err == sql.ErrNoRows {
-		return nil, customErrors.CarNotExists()
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
 	}
 
+	rows, err := s.db.Query(getEnginesByIDs+"("+sqlutil.Placeholders("?", len(ids))+")", args...)
 	if err != nil {
-		return nil, err
+		return nil, dberr.Classify(err, customErrors.EngineNotExists())
 	}
 
-	return &engine, nil
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		engine, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		engines[engine.ID] = *engine
+	}
+
+	return engines, nil
 }
 
 func (s engineStore) Create(engine *model.Engine) (*model.Engine, error) {
@@ -72,14 +102,16 @@ func (s engineStore) Create(engine *model.Engine) (*model.Engine, error) {
 	stmt, err := s.db.Prepare(insertEngine)
 
 	if err != nil {
-		return nil, err
+		return nil, dberr.Classify(err, customErrors.EngineNotExists())
 	}
 
 	defer stmt.Close()
 
-	_, err = stmt.Exec(engine.ID, engine.Displacement, engine.NoOfCylinders, engine.Range)
+	_, err = stmt.Exec(engine.ID, engine.Displacement, engine.NoOfCylinders, engine.Range,
+		engine.BatteryCapacity, engine.ElectricRange, engine.TankCapacity, engine.NoOfMotors,
+		engine.Power, engine.Torque, engine.Transmission, engine.Gears, engine.Drivetrain)
 	if err != nil {
-		return nil, err
+		return nil, dberr.Classify(err, customErrors.EngineNotExists())
 	}
 
 	return engine, nil
@@ -88,14 +120,16 @@ func (s engineStore) Create(engine *model.Engine) (*model.Engine, error) {
 func (s engineStore) Update(engine *model.Engine) (*model.Engine, error) {
 	stmt, err := s.db.Prepare(updateEngine)
 	if err != nil {
-		return nil, err
+		return nil, dberr.Classify(err, customErrors.EngineNotExists())
 	}
 
 	defer stmt.Close()
 
-	_, err = stmt.Exec(engine.Displacement, engine.NoOfCylinders, engine.Range, engine.ID)
+	_, err = stmt.Exec(engine.Displacement, engine.NoOfCylinders, engine.Range,
+		engine.BatteryCapacity, engine.ElectricRange, engine.TankCapacity, engine.NoOfMotors,
+		engine.Power, engine.Torque, engine.Transmission, engine.Gears, engine.Drivetrain, engine.ID)
 	if err != nil {
-		return nil, err
+		return nil, dberr.Classify(err, customErrors.EngineNotExists())
 	}
 
 	return engine, nil
@@ -103,20 +137,106 @@ func (s engineStore) Update(engine *model.Engine) (*model.Engine, error) {
 
 func (s engineStore) Delete(id string) error {
 	stmt, err := s.db.Prepare(deleteEngine)
-	if err == sql.ErrNoRows {
-		return customErrors.CarNotExists()
+	if err != nil {
+		return dberr.Classify(err, customErrors.EngineNotExists())
 	}
 
+	defer stmt.Close()
+
+	res, err := stmt.Exec(id)
 	if err != nil {
-		return err
+		return dberr.Classify(err, customErrors.EngineNotExists())
 	}
 
-	defer stmt.Close()
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.EngineNotExists())
+	}
 
-	_, err = stmt.Exec(id)
+	if n == 0 {
+		return customErrors.EngineNotExists()
+	}
+
+	return nil
+}
+
+// Execer is implemented by both *sql.DB and *sql.Tx,
+// the bulk functions take it so that other stores can run them in their transactions
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// CreateMany creates engines with one multi-row insert on ex, the engines are given new IDs
+func CreateMany(ex Execer, engines []*model.Engine) error {
+	if len(engines) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(engines)*engineColumns)
+
+	for _, engine := range engines {
+		engine.ID = uuid.NewString()
+
+		args = append(args, engine.ID, engine.Displacement, engine.NoOfCylinders, engine.Range,
+			engine.BatteryCapacity, engine.ElectricRange, engine.TankCapacity, engine.NoOfMotors,
+			engine.Power, engine.Torque, engine.Transmission, engine.Gears, engine.Drivetrain)
+	}
+
+	_, err := ex.Exec(insertEngines+sqlutil.Placeholders(engineValues, len(engines)), args...)
+	if err != nil {
+		return dberr.Classify(err, customErrors.EngineNotExists())
+	}
+
+	return nil
+}
+
+// UpdateMany updates existing engines on ex, one statement per engine
+func UpdateMany(ex Execer, engines []*model.Engine) error {
+	for _, engine := range engines {
+		_, err := ex.Exec(updateEngine, engine.Displacement, engine.NoOfCylinders, engine.Range,
+			engine.BatteryCapacity, engine.ElectricRange, engine.TankCapacity, engine.NoOfMotors,
+			engine.Power, engine.Torque, engine.Transmission, engine.Gears, engine.Drivetrain, engine.ID)
+		if err != nil {
+			return dberr.Classify(err, customErrors.EngineNotExists())
+		}
+	}
+
+	return nil
+}
+
+// DeleteMany deletes the engines with given IDs with one statement on ex
+func DeleteMany(ex Execer, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	_, err := ex.Exec(deleteEngines+"("+sqlutil.Placeholders("?", len(ids))+")", args...)
 	if err != nil {
-		return err
+		return dberr.Classify(err, customErrors.EngineNotExists())
 	}
 
 	return nil
 }
+
+// row is implemented by both *sql.Row and *sql.Rows
+type row interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(r row) (*model.Engine, error) {
+	var engine model.Engine
+
+	err := r.Scan(&engine.ID, &engine.Displacement, &engine.NoOfCylinders, &engine.Range,
+		&engine.BatteryCapacity, &engine.ElectricRange, &engine.TankCapacity, &engine.NoOfMotors,
+		&engine.Power, &engine.Torque, &engine.Transmission, &engine.Gears, &engine.Drivetrain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &engine, nil
+}
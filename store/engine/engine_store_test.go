@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"database/sql"
 	"errors"
 	"log"
 	"testing"
@@ -22,6 +23,11 @@ func engine() model.Engine {
 	}
 }
 
+func columns() []string {
+	return []string{"engineId", "displacement", "noOfCylinder", "range", "batteryCapacity", "electricRange", "tankCapacity", "noOfMotors",
+		"power", "torque", "transmission", "gears", "drivetrain"}
+}
+
 func TestEngineStore_GetAll(t *testing.T) {
 	engine := engine()
 
@@ -33,8 +39,8 @@ func TestEngineStore_GetAll(t *testing.T) {
 	defer db.Close()
 
 	store := NewEngineStore(db)
-	rows := sqlmock.NewRows([]string{"engineId", "displacement", "noOfCylinder", "range"}).
-		AddRow(engine.ID, engine.Displacement, engine.NoOfCylinders, engine.Range)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(engine.ID, engine.Displacement, engine.NoOfCylinders, engine.Range, 0, 0, 0, 0, 0, 0, "", 0, "")
 
 	mock.ExpectQuery("select \\* from engines").WillReturnRows(rows)
 	mock.ExpectQuery("select \\* from engines").WillReturnError(errors.New("DB error"))
@@ -68,11 +74,11 @@ func TestEngineStore_GetByID(t *testing.T) {
 	defer db.Close()
 
 	store := NewEngineStore(db)
-	rows := sqlmock.NewRows([]string{"engineId", "displacement", "noOfCylinder", "range"}).
-		AddRow(engine.ID, engine.Displacement, engine.NoOfCylinders, engine.Range)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(engine.ID, engine.Displacement, engine.NoOfCylinders, engine.Range, 0, 0, 0, 0, 0, 0, "", 0, "")
 
 	mock.ExpectQuery("select \\* from engines where engineId = \\?").WithArgs(engine.ID).WillReturnRows(rows)
-	mock.ExpectQuery("select \\* from engines where engineId = \\?").WithArgs("1").WillReturnError(customErrors.EngineNotExists())
+	mock.ExpectQuery("select \\* from engines where engineId = \\?").WithArgs("1").WillReturnError(sql.ErrNoRows)
 	mock.ExpectQuery("select \\* from engines where engineId = \\?").WithArgs("2").WillReturnError(errors.New("DB error"))
 
 	tests := []struct {
@@ -95,6 +101,45 @@ func TestEngineStore_GetByID(t *testing.T) {
 	}
 }
 
+func TestEngineStore_GetByIDs(t *testing.T) {
+	engine := engine()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := NewEngineStore(db)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(engine.ID, engine.Displacement, engine.NoOfCylinders, engine.Range, 0, 0, 0, 0, 0, 0, "", 0, "")
+
+	mock.ExpectQuery("select \\* from engines where engineId in \\(\\?, \\?\\)").WithArgs(engine.ID, "1").WillReturnRows(rows)
+	mock.ExpectQuery("select \\* from engines where engineId in \\(\\?\\)").WithArgs("2").WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc    string
+		ids     []string
+		engines map[string]model.Engine
+		err     error
+	}{
+		{"Missing engines are left out", []string{engine.ID, "1"}, map[string]model.Engine{engine.ID: engine}, nil},
+		{"No IDs", nil, map[string]model.Engine{}, nil},
+		{"DB error", []string{"2"}, nil, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		engines, err := store.GetByIDs(tc.ids)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.engines, engines, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
 func TestEngineStore_Create(t *testing.T) {
 	engine := engine()
 
@@ -107,10 +152,11 @@ func TestEngineStore_Create(t *testing.T) {
 
 	store := NewEngineStore(db)
 
-	query := "insert into engines \\(engineId, displacement, noOfCylinder, `range`\\) values \\(\\?, \\?, \\?, \\?\\)"
+	query := "insert into engines \\(engineId, displacement, noOfCylinder, `range`, batteryCapacity, electricRange, tankCapacity, noOfMotors, " +
+		"power, torque, transmission, gears, drivetrain\\) values \\(\\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?\\)"
 
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(sqlmock.AnyArg(), engine.Displacement, engine.NoOfCylinders, engine.Range).
+	prep.ExpectExec().WithArgs(sqlmock.AnyArg(), engine.Displacement, engine.NoOfCylinders, engine.Range, 0.0, 0, 0.0, 0, 0, 0, "", 0, "").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	prep = mock.ExpectPrepare(query)
@@ -151,11 +197,13 @@ func TestEngineStore_Update(t *testing.T) {
 
 	store := NewEngineStore(db)
 
-	query := "update engines set displacement = \\?, noOfCylinder = \\?, `range` = \\? where engineId = \\?"
+	query := "update engines set displacement = \\?, noOfCylinder = \\?, `range` = \\?, " +
+		"batteryCapacity = \\?, electricRange = \\?, tankCapacity = \\?, noOfMotors = \\?, " +
+		"power = \\?, torque = \\?, transmission = \\?, gears = \\?, drivetrain = \\? where engineId = \\?"
 
 	// Success case
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(0, 0, 400, engine.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.ExpectExec().WithArgs(0, 0, 400, 0.0, 0, 0.0, 0, 0, 0, "", 0, "", engine.ID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	// DB error
 	prep = mock.ExpectPrepare(query)
@@ -198,7 +246,7 @@ func TestEngineStore_Delete(t *testing.T) {
 	prep.ExpectExec().WithArgs(engine.ID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	prep = mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(engine.ID).WillReturnError(customErrors.EngineNotExists())
+	prep.ExpectExec().WithArgs(engine.ID).WillReturnResult(sqlmock.NewResult(0, 0))
 
 	prep = mock.ExpectPrepare(query)
 	prep.ExpectExec().WillReturnError(errors.New("DB error"))
@@ -219,3 +267,54 @@ func TestEngineStore_Delete(t *testing.T) {
 		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
 	}
 }
+
+func TestCreateMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	first, second := engine(), engine()
+
+	mock.ExpectExec("insert into engines .* values \\(\\?(, \\?){12}\\), \\(\\?(, \\?){12}\\)$").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("insert into engines").WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc    string
+		engines []*model.Engine
+		err     error
+	}{
+		{"Success", []*model.Engine{&first, &second}, nil},
+		{"DB error", []*model.Engine{&first}, errors.New("DB error")},
+		{"No engines", nil, nil},
+	}
+
+	for i, tc := range tests {
+		err := CreateMany(db, tc.engines)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	// the engines are given new IDs
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	mock.ExpectExec("delete from engines where engineId in \\(\\?, \\?\\)").WithArgs("e1", "e2").WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = DeleteMany(db, []string{"e1", "e2"})
+
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
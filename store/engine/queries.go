@@ -3,7 +3,22 @@ package engine
 const (
 	getAllEngines = "select * from engines"
 	getEngineByID = "select * from engines where engineId = ?"
-	insertEngine  = "insert into engines (engineId, displacement, noOfCylinder, `range`) values (?, ?, ?, ?)"
-	updateEngine  = "update engines set displacement = ?, noOfCylinder = ?, `range` = ? where engineId = ?"
-	deleteEngine  = "delete from engines where engineId = ?"
+	insertEngine  = insertEngines + engineValues
+
+	// insertEngines is completed by an engineValues group for every engine
+	insertEngines = "insert into engines (engineId, displacement, noOfCylinder, `range`, " +
+		"batteryCapacity, electricRange, tankCapacity, noOfMotors, power, torque, transmission, gears, drivetrain) values "
+	engineValues  = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	engineColumns = 13
+
+	updateEngine = "update engines set displacement = ?, noOfCylinder = ?, `range` = ?, " +
+		"batteryCapacity = ?, electricRange = ?, tankCapacity = ?, noOfMotors = ?, " +
+		"power = ?, torque = ?, transmission = ?, gears = ?, drivetrain = ? where engineId = ?"
+	deleteEngine = "delete from engines where engineId = ?"
+
+	// deleteEngines is completed by the placeholder group of the engine IDs
+	deleteEngines = "delete from engines where engineId in "
+
+	// getEnginesByIDs is completed by the placeholder group of the engine IDs
+	getEnginesByIDs = "select * from engines where engineId in "
 )
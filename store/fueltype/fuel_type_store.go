@@ -0,0 +1,147 @@
+package fueltype
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store/dberr"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+//nolint:revive //store should not be exported
+func New(db *sql.DB) store {
+	return store{db: db}
+}
+
+func (s store) GetAll() ([]model.FuelType, error) {
+	var fuelType model.FuelType
+
+	fuelTypes := make([]model.FuelType, 0)
+
+	rows, err := s.db.Query(getAllFuelTypes)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.FuelTypeNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		var required, forbidden string
+
+		err := rows.Scan(&fuelType.Name, &required, &forbidden)
+		if err != nil {
+			return nil, err
+		}
+
+		fuelType.Engine = &model.EngineRule{Required: splitParams(required), Forbidden: splitParams(forbidden)}
+
+		fuelTypes = append(fuelTypes, fuelType)
+	}
+
+	return fuelTypes, nil
+}
+
+func (s store) Create(fuelType *model.FuelType) (*model.FuelType, error) {
+	stmt, err := s.db.Prepare(insertFuelType)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.FuelTypeNotExists())
+	}
+
+	defer stmt.Close()
+
+	rule := fuelType.Engine
+	if rule == nil {
+		rule = &model.EngineRule{}
+	}
+
+	_, err = stmt.Exec(fuelType.Name, joinParams(rule.Required), joinParams(rule.Forbidden))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.FuelTypeNotExists())
+	}
+
+	return fuelType, nil
+}
+
+func (s store) Update(name string, fuelType *model.FuelType) (*model.FuelType, error) {
+	stmt, err := s.db.Prepare(updateFuelType)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.FuelTypeNotExists())
+	}
+
+	defer stmt.Close()
+
+	// the engine rule is kept if it is left out
+	var required, forbidden interface{}
+
+	if fuelType.Engine != nil {
+		required, forbidden = joinParams(fuelType.Engine.Required), joinParams(fuelType.Engine.Forbidden)
+	}
+
+	res, err := stmt.Exec(fuelType.Name, required, forbidden, name)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.FuelTypeNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.FuelTypeNotExists())
+	}
+
+	if n == 0 {
+		return nil, customErrors.FuelTypeNotExists()
+	}
+
+	return fuelType, nil
+}
+
+func (s store) Delete(name string) error {
+	stmt, err := s.db.Prepare(deleteFuelType)
+	if err != nil {
+		return dberr.Classify(err, customErrors.FuelTypeNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(name)
+	if err != nil {
+		return dberr.Classify(err, customErrors.FuelTypeNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.FuelTypeNotExists())
+	}
+
+	if n == 0 {
+		return customErrors.FuelTypeNotExists()
+	}
+
+	return nil
+}
+
+// splitParams returns the engine params of a comma separated column
+func splitParams(params string) []string {
+	if params == "" {
+		return []string{}
+	}
+
+	return strings.Split(params, ",")
+}
+
+// joinParams returns the comma separated column of engine params
+func joinParams(params []string) string {
+	return strings.Join(params, ",")
+}
@@ -0,0 +1,184 @@
+package fueltype
+
+import (
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func TestStore_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows([]string{"name", "requiredEngineParams", "forbiddenEngineParams"}).
+		AddRow("Diesel", "displacement,noOfCylinders", "batteryCapacity,electricRange,noOfMotors").
+		AddRow("Other", "", "")
+
+	query := "select name, requiredEngineParams, forbiddenEngineParams from fuel_types order by name"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery(query).WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc      string
+		fuelTypes []model.FuelType
+		err       error
+	}{
+		{"Success", []model.FuelType{
+			{Name: "Diesel", Engine: &model.EngineRule{Required: []string{"displacement", "noOfCylinders"},
+				Forbidden: []string{"batteryCapacity", "electricRange", "noOfMotors"}}},
+			{Name: "Other", Engine: &model.EngineRule{Required: []string{}, Forbidden: []string{}}},
+		}, nil},
+		{"DB error", nil, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		fuelTypes, err := store.GetAll()
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.fuelTypes, fuelTypes, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+
+	query := "insert into fuel_types \\(name, requiredEngineParams, forbiddenEngineParams\\) values \\(\\?, \\?, \\?\\)"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Hydrogen", "range,tankCapacity", "displacement").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Electric", "", "").WillReturnError(duplicate)
+
+	hydrogen := &model.FuelType{Name: "Hydrogen",
+		Engine: &model.EngineRule{Required: []string{"range", "tankCapacity"}, Forbidden: []string{"displacement"}}}
+
+	tests := []struct {
+		desc     string
+		input    *model.FuelType
+		expected *model.FuelType
+		err      error
+	}{
+		{"Success", hydrogen, hydrogen, nil},
+		{"Duplicate", &model.FuelType{Name: "Electric"}, nil,
+			customErrors.Conflict{Entity: "FuelType", Reason: "duplicate entry", Err: duplicate}},
+	}
+
+	for i, tc := range tests {
+		fuelType, err := store.Create(tc.input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, fuelType, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	query := "update fuel_types set name = \\?, requiredEngineParams = coalesce\\(\\?, requiredEngineParams\\)"
+
+	// the engine rule is kept if it is left out
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Liquid hydrogen", nil, nil, "Hydrogen").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Hydrogen", "range", "", "Hydrogen").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("CNG", nil, nil, "LNG").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc     string
+		name     string
+		input    *model.FuelType
+		expected *model.FuelType
+		err      error
+	}{
+		{"Success", "Hydrogen", &model.FuelType{Name: "Liquid hydrogen"}, &model.FuelType{Name: "Liquid hydrogen"}, nil},
+		{"Engine rule", "Hydrogen", &model.FuelType{Name: "Hydrogen", Engine: &model.EngineRule{Required: []string{"range"}}},
+			&model.FuelType{Name: "Hydrogen", Engine: &model.EngineRule{Required: []string{"range"}}}, nil},
+		{"Fuel type not exists", "LNG", &model.FuelType{Name: "CNG"}, nil, customErrors.FuelTypeNotExists()},
+		{"DB error", "Hydrogen", &model.FuelType{Name: "Liquid hydrogen"}, nil, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		fuelType, err := store.Update(tc.name, tc.input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, fuelType, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	referenced := &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"}
+
+	query := "delete from fuel_types where name = \\?"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Hydrogen").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Hydrogen").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("Electric").WillReturnError(referenced)
+
+	tests := []struct {
+		desc string
+		name string
+		err  error
+	}{
+		{"Success", "Hydrogen", nil},
+		{"Not exists", "Hydrogen", customErrors.FuelTypeNotExists()},
+		{"Fuel type of existing cars", "Electric",
+			customErrors.Conflict{Entity: "FuelType", Reason: "referenced by another entity", Err: referenced}},
+	}
+
+	for i, tc := range tests {
+		err := store.Delete(tc.name)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
@@ -0,0 +1,11 @@
+package fueltype
+
+const (
+	getAllFuelTypes = "select name, requiredEngineParams, forbiddenEngineParams from fuel_types order by name"
+	insertFuelType  = "insert into fuel_types (name, requiredEngineParams, forbiddenEngineParams) values (?, ?, ?)"
+
+	// updateFuelType keeps the engine rule if null is passed for its params
+	updateFuelType = `update fuel_types set name = ?, requiredEngineParams = coalesce(?, requiredEngineParams),
+					forbiddenEngineParams = coalesce(?, forbiddenEngineParams) where name = ?`
+	deleteFuelType = "delete from fuel_types where name = ?"
+)
@@ -1,23 +1,77 @@
 package store
 
-import "carAPI/model"
+import (
+	"time"
+
+	"carAPI/model"
+)
 
 type CarStore interface {
-	// GetByBrand gives all the cars of a given brand,
-	// if empty string is passed as brand, then cars of all brands should be fetched
-	GetByBrand(brand string) ([]model.Car, error)
+	// Get gives all the cars matching filter,
+	// if a zero filter is passed, then all cars should be fetched
+	Get(filter model.CarFilter) ([]model.Car, error)
+
+	// Each passes the cars matching filter with their engines to fn one at a time, as they are read from the DB.
+	// It stops at the first error of fn and returns it
+	Each(filter model.CarFilter, fn func(car *model.Car) error) error
+
+	// Page fetches up to limit cars matching filter from offset on, without their engines, and counts all cars matching filter
+	Page(filter model.CarFilter, limit, offset int) ([]model.Car, int, error)
 
 	// GetByID fetches a car with given ID from DB
 	GetByID(id string) (*model.Car, error)
 
-	// Create creates a new car in DB
-	Create(car *model.Car) (*model.Car, error)
+	// GetByVIN fetches the car with given VIN from DB
+	GetByVIN(vin string) (*model.Car, error)
+
+	// GetByIDs fetches the cars with given IDs from DB, IDs of missing cars are skipped
+	GetByIDs(ids []string) ([]model.Car, error)
+
+	// GetByVINs fetches the cars with given VINs from DB, VINs without a car are skipped
+	GetByVINs(vins []string) ([]model.Car, error)
+
+	// GetOrderedIDs fetches which of the cars with given IDs are referenced by orders, such cars cannot be deleted
+	GetOrderedIDs(ids []string) ([]string, error)
+
+	// Create creates a new car in DB and records its initial status as changed at at
+	Create(car *model.Car, at time.Time) (*model.Car, error)
 
 	// Update updates an existing car in DB
 	Update(car *model.Car) (*model.Car, error)
 
 	// Delete deletes a car with given ID from DB
 	Delete(id string) error
+
+	// Transition changes the status of the car with given ID as given by change and records change in one transaction,
+	// a conflict is returned and nothing is changed if the car does not have the status change.From anymore
+	Transition(id string, change *model.StatusChange) error
+
+	// GetStatusHistory fetches all status changes of the car with given ID, oldest first
+	GetStatusHistory(id string) ([]model.StatusChange, error)
+
+	// SetPrice sets the price of the car with given ID to the one of change and records change in one transaction
+	SetPrice(id string, change *model.PriceChange) error
+
+	// GetPriceHistory fetches all price changes of the car with given ID, oldest first
+	GetPriceHistory(id string) ([]model.PriceChange, error)
+
+	// Transfer moves the car with given ID between the dealerships of transfer and records it in one transaction,
+	// a conflict is returned if the car is not at the dealership transfer.From anymore
+	Transfer(id string, transfer *model.Transfer) error
+
+	// GetTransfers fetches all transfers of the car with given ID, oldest first
+	GetTransfers(id string) ([]model.Transfer, error)
+
+	// CreateMany creates cars, their engines and their initial status changes at the given time in one transaction,
+	// the cars and engines are given new IDs
+	CreateMany(cars []*model.Car, at time.Time) error
+
+	// UpdateMany updates existing cars and their engines in one transaction
+	UpdateMany(cars []*model.Car) error
+
+	// DeleteMany deletes cars and their engines in one transaction,
+	// a conflict is returned if any of the cars does not exist anymore
+	DeleteMany(cars []model.Car) error
 }
 
 type EngineStore interface {
@@ -27,6 +81,10 @@ type EngineStore interface {
 	// GetByID fetches an engine with given ID from DB
 	GetByID(id string) (*model.Engine, error)
 
+	// GetByIDs fetches the engines with given IDs from DB in one query, mapped to their IDs.
+	// IDs of engines which do not exist are left out of the mapping
+	GetByIDs(ids []string) (map[string]model.Engine, error)
+
 	// Create creates a new engine in DB
 	Create(engine *model.Engine) (*model.Engine, error)
 
@@ -36,3 +94,153 @@ type EngineStore interface {
 	// Delete deletes the engine with given ID from DB
 	Delete(id string) error
 }
+
+type BrandStore interface {
+	// GetAll fetches all brands from DB, ordered by name
+	GetAll() ([]model.Brand, error)
+
+	// Create creates a new brand in DB
+	Create(brand *model.Brand) (*model.Brand, error)
+
+	// Update renames the brand with given name, cars of the brand are renamed with it
+	Update(name string, brand *model.Brand) (*model.Brand, error)
+
+	// Delete deletes the brand with given name from DB
+	Delete(name string) error
+}
+
+type FuelTypeStore interface {
+	// GetAll fetches all fuel types from DB, ordered by name
+	GetAll() ([]model.FuelType, error)
+
+	// Create creates a new fuel type in DB
+	Create(fuelType *model.FuelType) (*model.FuelType, error)
+
+	// Update renames the fuel type with given name, cars of the fuel type are renamed with it
+	Update(name string, fuelType *model.FuelType) (*model.FuelType, error)
+
+	// Delete deletes the fuel type with given name from DB
+	Delete(name string) error
+}
+
+type CarModelStore interface {
+	// GetByBrand fetches all models of the given brand with the number of their cars, ordered by name
+	GetByBrand(brand string) ([]model.CarModel, error)
+
+	// GetByID fetches a model with given ID from DB
+	GetByID(id string) (*model.CarModel, error)
+
+	// Create creates a new model in DB
+	Create(carModel *model.CarModel) (*model.CarModel, error)
+
+	// Update updates the name of an existing model in DB
+	Update(carModel *model.CarModel) (*model.CarModel, error)
+
+	// Delete deletes the model with given ID from DB
+	Delete(id string) error
+}
+
+type TrimStore interface {
+	// GetByModel fetches all trims of the given model with their engine and the number of their cars, ordered by name
+	GetByModel(modelID string) ([]model.Trim, error)
+
+	// GetByID fetches a trim with given ID and its engine from DB
+	GetByID(id string) (*model.Trim, error)
+
+	// Create creates a new trim in DB, its engine must already exist
+	Create(trim *model.Trim) (*model.Trim, error)
+
+	// Update updates the name of an existing trim in DB
+	Update(trim *model.Trim) (*model.Trim, error)
+
+	// Delete deletes the trim with given ID from DB
+	Delete(id string) error
+}
+
+type CustomerStore interface {
+	// GetAll fetches all customers from DB, ordered by name
+	GetAll() ([]model.Customer, error)
+
+	// GetByID fetches a customer with given ID from DB
+	GetByID(id string) (*model.Customer, error)
+
+	// Create creates a new customer in DB
+	Create(customer *model.Customer) (*model.Customer, error)
+
+	// Update updates an existing customer in DB
+	Update(customer *model.Customer) (*model.Customer, error)
+
+	// Delete deletes the customer with given ID from DB, customers with orders cannot be deleted
+	Delete(id string) error
+}
+
+type OrderStore interface {
+	// Get gives all the orders matching filter, newest first
+	Get(filter model.OrderFilter) ([]model.Order, error)
+
+	// GetByID fetches an order with given ID from DB
+	GetByID(id string) (*model.Order, error)
+
+	// Create creates a new order in DB
+	Create(order *model.Order) (*model.Order, error)
+
+	// Update updates the amounts and payment status of an open order in DB,
+	// a conflict is returned if the order is not open anymore
+	Update(order *model.Order) (*model.Order, error)
+
+	// Cancel cancels an open order, a conflict is returned if the order is not open anymore
+	Cancel(id string) error
+
+	// Complete completes an open order and changes the status of its car from carStatus to sold in one transaction,
+	// a conflict is returned and nothing is changed if the order is not open or the car does not have carStatus anymore
+	Complete(order *model.Order, carStatus model.Status, at time.Time) error
+}
+
+type BookingStore interface {
+	// GetByCar fetches all bookings of the car with given ID, ordered by start
+	GetByCar(carID string) ([]model.Booking, error)
+
+	// GetByID fetches a booking with given ID from DB
+	GetByID(id string) (*model.Booking, error)
+
+	// GetOverlapping fetches the active bookings of the car with given ID which overlap slot, ordered by start,
+	// holds which have expired at now are left out
+	GetOverlapping(carID string, slot model.Slot, now time.Time) ([]model.Booking, error)
+
+	// Create creates a new booking in DB, a conflict is returned if an active booking of the car overlaps it
+	Create(booking *model.Booking) (*model.Booking, error)
+
+	// Cancel cancels an active booking, a conflict is returned if the booking is not active anymore
+	Cancel(id string) error
+
+	// ExpireHolds expires the active holds which have expired at now, the number of expired holds is returned
+	ExpireHolds(now time.Time) (int64, error)
+}
+
+type DealershipStore interface {
+	// GetAll fetches all dealerships from DB, ordered by name
+	GetAll() ([]model.Dealership, error)
+
+	// GetByID fetches a dealership with given ID from DB
+	GetByID(id string) (*model.Dealership, error)
+
+	// Create creates a new dealership in DB
+	Create(dealership *model.Dealership) (*model.Dealership, error)
+
+	// Update updates an existing dealership in DB
+	Update(dealership *model.Dealership) (*model.Dealership, error)
+}
+
+type AttachmentStore interface {
+	// GetByCar fetches all attachments of the car with given ID, oldest first
+	GetByCar(carID string) ([]model.Attachment, error)
+
+	// GetByID fetches an attachment with given ID from DB
+	GetByID(id string) (*model.Attachment, error)
+
+	// Create creates a new attachment in DB, its content is stored separately
+	Create(attachment *model.Attachment) (*model.Attachment, error)
+
+	// Delete deletes the attachment with given ID from DB
+	Delete(id string) error
+}
@@ -0,0 +1,227 @@
+package order
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store/car"
+	"carAPI/store/dberr"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+//nolint:revive //store should not be exported
+func New(db *sql.DB) store {
+	return store{db: db}
+}
+
+func (s store) Get(filter model.OrderFilter) ([]model.Order, error) {
+	orders := make([]model.Order, 0)
+
+	query, args := filterQuery(filter)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		order, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, *order)
+	}
+
+	return orders, nil
+}
+
+func (s store) GetByID(id string) (*model.Order, error) {
+	order, err := scan(s.db.QueryRow(getOrderByID, id))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	return order, nil
+}
+
+func (s store) Create(order *model.Order) (*model.Order, error) {
+	order.ID = uuid.NewString()
+
+	stmt, err := s.db.Prepare(insertOrder)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(order.ID, order.CustomerID, order.CarID, order.Currency, order.AgreedPrice, order.Discount, order.Tax,
+		order.Total, order.PaymentStatus, order.Status, order.CreatedAt)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	return order, nil
+}
+
+func (s store) Update(order *model.Order) (*model.Order, error) {
+	stmt, err := s.db.Prepare(updateOrder)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(order.Currency, order.AgreedPrice, order.Discount, order.Tax, order.Total, order.PaymentStatus, order.ID)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	err = checkOpen(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (s store) Cancel(id string) error {
+	stmt, err := s.db.Prepare(cancelOrder)
+	if err != nil {
+		return dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	return checkOpen(res)
+}
+
+// Complete completes the order and sells its car in one transaction, so that an order is never completed
+// without its car being sold, nor a car sold twice
+func (s store) Complete(order *model.Order, carStatus model.Status, at time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	// rolling back a committed transaction is a no-op
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.Exec(completeOrder, at, order.ID)
+	if err != nil {
+		return dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	err = checkOpen(res)
+	if err != nil {
+		return err
+	}
+
+	// the car is sold like by a status transition of the car store
+	err = car.Transition(tx, order.CarID, &model.StatusChange{From: carStatus, To: model.StatusSold, At: at})
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	return nil
+}
+
+// checkOpen returns a conflict if the update of an open order did not affect any row,
+// the service has checked the order existed, so it was completed or cancelled concurrently
+func checkOpen(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.OrderNotExists())
+	}
+
+	if n == 0 {
+		return customErrors.Conflict{Entity: "Order", Reason: "order is no longer open"}
+	}
+
+	return nil
+}
+
+// row is implemented by both *sql.Row and *sql.Rows
+type row interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(r row) (*model.Order, error) {
+	var (
+		order       model.Order
+		completedAt sql.NullTime
+	)
+
+	err := r.Scan(&order.ID, &order.CustomerID, &order.CarID, &order.Currency, &order.AgreedPrice, &order.Discount, &order.Tax,
+		&order.Total, &order.PaymentStatus, &order.Status, &order.CreatedAt, &completedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		order.CompletedAt = &completedAt.Time
+	}
+
+	return &order, nil
+}
+
+// filterQuery completes getOrders with a condition for every field set in filter, newest orders first
+func filterQuery(filter model.OrderFilter) (query string, args []interface{}) {
+	var conditions []string
+
+	filters := []struct {
+		condition string
+		value     interface{}
+		set       bool
+	}{
+		{"customerId = ?", filter.CustomerID, filter.CustomerID != ""},
+		{"carId = ?", filter.CarID, filter.CarID != ""},
+		{"status = ?", filter.Status, filter.Status != ""},
+		{"carId in (select carId from cars where dealershipId = ?)", filter.DealershipID, filter.DealershipID != ""},
+	}
+
+	for _, f := range filters {
+		if f.set {
+			conditions = append(conditions, f.condition)
+			args = append(args, f.value)
+		}
+	}
+
+	query = getOrders
+
+	if len(conditions) != 0 {
+		query += " where " + strings.Join(conditions, " and ")
+	}
+
+	return query + " order by createdAt desc, orderId", args
+}
@@ -0,0 +1,221 @@
+package order
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func columns() []string {
+	return []string{"orderId", "customerId", "carId", "currency", "agreedPrice", "discount", "tax", "total", "paymentStatus", "status",
+		"createdAt", "completedAt"}
+}
+
+func order() model.Order {
+	return model.Order{
+		ID:            "o1",
+		CustomerID:    "c1",
+		CarID:         "car1",
+		Currency:      "EUR",
+		AgreedPrice:   8999900,
+		Discount:      99900,
+		Tax:           1691000,
+		Total:         10591000,
+		PaymentStatus: model.PaymentPaid,
+		Status:        model.OrderOpen,
+		CreatedAt:     time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestStore_Get(t *testing.T) {
+	o := order()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(o.ID, o.CustomerID, o.CarID, o.Currency, o.AgreedPrice, o.Discount, o.Tax, o.Total, o.PaymentStatus, o.Status, o.CreatedAt, nil)
+
+	query := "select orderId, customerId, carId, currency, agreedPrice, discount, tax, total, paymentStatus, status,\\s+" +
+		"createdAt, completedAt from orders"
+
+	mock.ExpectQuery(query+" where customerId = \\? and status = \\? order by createdAt desc, orderId$").
+		WithArgs("c1", model.OrderOpen).WillReturnRows(rows)
+	mock.ExpectQuery(query + " order by createdAt desc, orderId$").WillReturnError(errors.New("DB error"))
+	mock.ExpectQuery(query + " where carId in \\(select carId from cars where dealershipId = \\?\\) order by createdAt desc, orderId$").
+		WithArgs("d1").WillReturnRows(sqlmock.NewRows(columns()))
+
+	tests := []struct {
+		desc   string
+		filter model.OrderFilter
+		orders []model.Order
+		err    error
+	}{
+		{"Open orders of a customer", model.OrderFilter{CustomerID: "c1", Status: model.OrderOpen}, []model.Order{o}, nil},
+		{"DB error", model.OrderFilter{}, nil, errors.New("DB error")},
+		{"Orders of the cars of a dealership", model.OrderFilter{DealershipID: "d1"}, []model.Order{}, nil},
+	}
+
+	for i, tc := range tests {
+		orders, err := store.Get(tc.filter)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.orders, orders, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_GetByID(t *testing.T) {
+	o := order()
+	completedAt := o.CreatedAt.Add(time.Hour)
+	o.Status = model.OrderCompleted
+	o.CompletedAt = &completedAt
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	rows := sqlmock.NewRows(columns()).
+		AddRow(o.ID, o.CustomerID, o.CarID, o.Currency, o.AgreedPrice, o.Discount, o.Tax, o.Total, o.PaymentStatus, o.Status,
+			o.CreatedAt, completedAt)
+
+	query := "select .* from orders where orderId = \\?"
+
+	mock.ExpectQuery(query).WithArgs("o1").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("o2").WillReturnError(sql.ErrNoRows)
+
+	tests := []struct {
+		desc  string
+		id    string
+		order *model.Order
+		err   error
+	}{
+		{"Success", "o1", &o, nil},
+		{"Order not exists", "o2", nil, customErrors.OrderNotExists()},
+	}
+
+	for i, tc := range tests {
+		order, err := store.GetByID(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.order, order, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Update(t *testing.T) {
+	o := order()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	query := "update orders set currency = \\?, agreedPrice = \\?, discount = \\?, tax = \\?, total = \\?, paymentStatus = \\?\\s+" +
+		"where orderId = \\? and status = 'open'"
+
+	mock.ExpectPrepare(query).ExpectExec().
+		WithArgs(o.Currency, o.AgreedPrice, o.Discount, o.Tax, o.Total, o.PaymentStatus, o.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(query).ExpectExec().
+		WithArgs(o.Currency, o.AgreedPrice, o.Discount, o.Tax, o.Total, o.PaymentStatus, o.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tests := []struct {
+		desc     string
+		expected *model.Order
+		err      error
+	}{
+		{"Success", &o, nil},
+		{"Order completed concurrently", nil, customErrors.Conflict{Entity: "Order", Reason: "order is no longer open"}},
+	}
+
+	for i, tc := range tests {
+		order, err := store.Update(&o)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, order, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Complete(t *testing.T) {
+	o := order()
+	at := time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	completeOrder := "update orders set status = 'completed', completedAt = \\? where orderId = \\? and status = 'open'"
+	updateCarStatus := "update cars set status = \\? where carId = \\? and status = \\?"
+	insertStatusChange := "insert into car_status_history \\(carId, fromStatus, toStatus, changedAt\\)"
+
+	// success, all changes are committed
+	mock.ExpectBegin()
+	mock.ExpectExec(completeOrder).WithArgs(at, o.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(updateCarStatus).WithArgs(model.StatusSold, o.CarID, model.StatusReserved).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertStatusChange).WithArgs(o.CarID, model.StatusReserved, model.StatusSold, at).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	// the car was sold concurrently, completing the order is rolled back
+	mock.ExpectBegin()
+	mock.ExpectExec(completeOrder).WithArgs(at, o.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(updateCarStatus).WithArgs(model.StatusSold, o.CarID, model.StatusReserved).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	// the history cannot be written, the order and car status are rolled back
+	mock.ExpectBegin()
+	mock.ExpectExec(completeOrder).WithArgs(at, o.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(updateCarStatus).WithArgs(model.StatusSold, o.CarID, model.StatusReserved).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertStatusChange).WillReturnError(errors.New("DB error"))
+	mock.ExpectRollback()
+
+	// the order was cancelled concurrently
+	mock.ExpectBegin()
+	mock.ExpectExec(completeOrder).WithArgs(at, o.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		desc string
+		err  error
+	}{
+		{"Success", nil},
+		{"Car sold concurrently", customErrors.Conflict{Entity: "Car", Reason: "status is no longer reserved"}},
+		{"DB error", errors.New("DB error")},
+		{"Order cancelled concurrently", customErrors.Conflict{Entity: "Order", Reason: "order is no longer open"}},
+	}
+
+	for i, tc := range tests {
+		err := store.Complete(&o, model.StatusReserved, at)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
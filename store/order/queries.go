@@ -0,0 +1,19 @@
+package order
+
+const (
+	// getOrders is completed by the conditions of an order filter
+	getOrders = `select orderId, customerId, carId, currency, agreedPrice, discount, tax, total, paymentStatus, status,
+					createdAt, completedAt from orders`
+	getOrderByID = `select orderId, customerId, carId, currency, agreedPrice, discount, tax, total, paymentStatus, status,
+					createdAt, completedAt from orders where orderId = ?`
+	insertOrder = `insert into orders (orderId, customerId, carId, currency, agreedPrice, discount, tax, total, paymentStatus, status, createdAt)
+					values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	// updateOrder and cancelOrder only change open orders, completed and cancelled orders are final
+	updateOrder = `update orders set currency = ?, agreedPrice = ?, discount = ?, tax = ?, total = ?, paymentStatus = ?
+					where orderId = ? and status = 'open'`
+	cancelOrder = "update orders set status = 'cancelled' where orderId = ? and status = 'open'"
+
+	// completing an order sells its car through a status transition of the car store
+	completeOrder = "update orders set status = 'completed', completedAt = ? where orderId = ? and status = 'open'"
+)
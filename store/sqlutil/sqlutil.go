@@ -0,0 +1,14 @@
+package sqlutil
+
+import "strings"
+
+// Placeholders repeats the placeholder group row n times, separated by commas,
+// to complete multi-row inserts and in conditions
+func Placeholders(row string, n int) string {
+	rows := make([]string, n)
+	for i := range rows {
+		rows[i] = row
+	}
+
+	return strings.Join(rows, ", ")
+}
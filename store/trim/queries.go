@@ -0,0 +1,24 @@
+package trim
+
+// cars on the lot and reserved cars are counted as in stock
+const (
+	getTrimsByModel = "select t.trimId, t.modelId, m.brand, t.name, " +
+		"e.engineId, e.displacement, e.noOfCylinder, e.`range`, " +
+		"e.batteryCapacity, e.electricRange, e.tankCapacity, e.noOfMotors, " +
+		"e.power, e.torque, e.transmission, e.gears, e.drivetrain, count(c.carId) from trims t " +
+		"join models m on m.modelId = t.modelId " +
+		"join engines e on e.engineId = t.engineId " +
+		"left join cars c on c.trimId = t.trimId and c.status in ('on-lot', 'reserved') " +
+		"where t.modelId = ? group by t.trimId order by t.name"
+	getTrimByID = "select t.trimId, t.modelId, m.brand, t.name, " +
+		"e.engineId, e.displacement, e.noOfCylinder, e.`range`, " +
+		"e.batteryCapacity, e.electricRange, e.tankCapacity, e.noOfMotors, " +
+		"e.power, e.torque, e.transmission, e.gears, e.drivetrain, count(c.carId) from trims t " +
+		"join models m on m.modelId = t.modelId " +
+		"join engines e on e.engineId = t.engineId " +
+		"left join cars c on c.trimId = t.trimId and c.status in ('on-lot', 'reserved') " +
+		"where t.trimId = ? group by t.trimId"
+	insertTrim = "insert into trims (trimId, modelId, name, engineId) values (?, ?, ?, ?)"
+	updateTrim = "update trims set name = ? where trimId = ?"
+	deleteTrim = "delete from trims where trimId = ?"
+)
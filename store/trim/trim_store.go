@@ -0,0 +1,137 @@
+package trim
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/google/uuid"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/store/dberr"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+//nolint:revive //store should not be exported
+func New(db *sql.DB) store {
+	return store{db: db}
+}
+
+func (s store) GetByModel(modelID string) ([]model.Trim, error) {
+	trims := make([]model.Trim, 0)
+
+	rows, err := s.db.Query(getTrimsByModel, modelID)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.TrimNotExists())
+	}
+
+	defer func() {
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			log.Println(err)
+		}
+	}()
+
+	for rows.Next() {
+		trim, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		trims = append(trims, *trim)
+	}
+
+	return trims, nil
+}
+
+func (s store) GetByID(id string) (*model.Trim, error) {
+	trim, err := scan(s.db.QueryRow(getTrimByID, id))
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.TrimNotExists())
+	}
+
+	return trim, nil
+}
+
+func (s store) Create(trim *model.Trim) (*model.Trim, error) {
+	trim.ID = uuid.NewString()
+
+	stmt, err := s.db.Prepare(insertTrim)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.TrimNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(trim.ID, trim.ModelID, trim.Name, trim.Engine.ID)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.TrimNotExists())
+	}
+
+	return trim, nil
+}
+
+func (s store) Update(trim *model.Trim) (*model.Trim, error) {
+	stmt, err := s.db.Prepare(updateTrim)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.TrimNotExists())
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(trim.Name, trim.ID)
+	if err != nil {
+		return nil, dberr.Classify(err, customErrors.TrimNotExists())
+	}
+
+	return trim, nil
+}
+
+func (s store) Delete(id string) error {
+	stmt, err := s.db.Prepare(deleteTrim)
+	if err != nil {
+		return dberr.Classify(err, customErrors.TrimNotExists())
+	}
+
+	defer stmt.Close()
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return dberr.Classify(err, customErrors.TrimNotExists())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Classify(err, customErrors.TrimNotExists())
+	}
+
+	if n == 0 {
+		return customErrors.TrimNotExists()
+	}
+
+	return nil
+}
+
+// row is implemented by both *sql.Row and *sql.Rows
+type row interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(r row) (*model.Trim, error) {
+	var trim model.Trim
+
+	err := r.Scan(&trim.ID, &trim.ModelID, &trim.Brand, &trim.Name,
+		&trim.Engine.ID, &trim.Engine.Displacement, &trim.Engine.NoOfCylinders, &trim.Engine.Range,
+		&trim.Engine.BatteryCapacity, &trim.Engine.ElectricRange, &trim.Engine.TankCapacity, &trim.Engine.NoOfMotors,
+		&trim.Engine.Power, &trim.Engine.Torque, &trim.Engine.Transmission, &trim.Engine.Gears, &trim.Engine.Drivetrain, &trim.CarCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trim, nil
+}
@@ -0,0 +1,177 @@
+package trim
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func columns() []string {
+	return []string{"trimId", "modelId", "brand", "name", "engineId", "displacement", "noOfCylinder", "range",
+		"batteryCapacity", "electricRange", "tankCapacity", "noOfMotors", "power", "torque", "transmission", "gears", "drivetrain", "count"}
+}
+
+func trim1() model.Trim {
+	return model.Trim{
+		ID:      "t1",
+		ModelID: "m1",
+		Brand:   "Tesla",
+		Name:    "Long Range",
+		Engine: model.Engine{ID: "e1", Range: 500, BatteryCapacity: 75, NoOfMotors: 2,
+			Power: 498, Torque: 493, Transmission: "Automatic", Gears: 1, Drivetrain: "AWD"},
+		CarCount: 3,
+	}
+}
+
+func TestStore_GetByModel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	trim := trim1()
+	rows := sqlmock.NewRows(columns()).AddRow("t1", "m1", "Tesla", "Long Range", "e1", 0, 0, 500, 75.0, 0, 0.0, 2, 498, 493, "Automatic", 1, "AWD", 3)
+
+	query := "select t.trimId, t.modelId, m.brand, t.name"
+
+	mock.ExpectQuery(query).WithArgs("m1").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("m2").WillReturnRows(sqlmock.NewRows(columns()))
+	mock.ExpectQuery(query).WithArgs("m3").WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc    string
+		modelID string
+		trims   []model.Trim
+		err     error
+	}{
+		{"Success", "m1", []model.Trim{trim}, nil},
+		{"Model without trims", "m2", []model.Trim{}, nil},
+		{"DB error", "m3", nil, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		trims, err := store.GetByModel(tc.modelID)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.trims, trims, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+	trim := trim1()
+	rows := sqlmock.NewRows(columns()).AddRow("t1", "m1", "Tesla", "Long Range", "e1", 0, 0, 500, 75.0, 0, 0.0, 2, 498, 493, "Automatic", 1, "AWD", 3)
+
+	query := "select t.trimId, t.modelId, m.brand, t.name"
+
+	mock.ExpectQuery(query).WithArgs("t1").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("t2").WillReturnError(sql.ErrNoRows)
+
+	tests := []struct {
+		desc     string
+		id       string
+		expected *model.Trim
+		err      error
+	}{
+		{"Success", "t1", &trim, nil},
+		{"Trim not exists", "t2", nil, customErrors.TrimNotExists()},
+	}
+
+	for i, tc := range tests {
+		result, err := store.GetByID(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.expected, result, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestStore_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	query := "insert into trims \\(trimId, modelId, name, engineId\\) values \\(\\?, \\?, \\?, \\?\\)"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(sqlmock.AnyArg(), "m1", "Long Range", "e1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WillReturnError(errors.New("DB error"))
+
+	tests := []struct {
+		desc  string
+		input *model.Trim
+		err   error
+	}{
+		{"Success", &model.Trim{ModelID: "m1", Name: "Long Range", Engine: model.Engine{ID: "e1"}}, nil},
+		{"DB error", &model.Trim{}, errors.New("DB error")},
+	}
+
+	for i, tc := range tests {
+		result, err := store.Create(tc.input)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		if err == nil {
+			assert.NotEmptyf(t, result.ID, "Testcase[%v] (%v)", i, tc.desc)
+		}
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	defer db.Close()
+
+	store := New(db)
+
+	query := "delete from trims where trimId = \\?"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("t1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prep = mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("t2").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tests := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{"Success", "t1", nil},
+		{"Trim not exists", "t2", customErrors.TrimNotExists()},
+	}
+
+	for i, tc := range tests {
+		err := store.Delete(tc.id)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
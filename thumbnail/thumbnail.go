@@ -0,0 +1,108 @@
+// Package thumbnail scales down photos to thumbnails.
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// decoders of the photo formats accepted as attachments
+	_ "image/gif"
+	_ "image/png"
+)
+
+// quality is the JPEG quality thumbnails are encoded with
+const quality = 80
+
+// ErrTooLarge is returned for images with more pixels than allowed
+var ErrTooLarge = errors.New("thumbnail: image has too many pixels")
+
+// Generate decodes a JPEG, PNG or GIF image and returns it scaled down to fit into size x size pixels as JPEG,
+// the aspect ratio is kept and smaller images are not scaled up. Images with more than maxPixels pixels are rejected
+// with ErrTooLarge before they are decoded, since small files can declare dimensions which take gigabytes to decode
+func Generate(data []byte, size, maxPixels int) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(cfg.Width)*int64(cfg.Height) > int64(maxPixels) {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	b := src.Bounds()
+	w, h := fit(b.Dx(), b.Dy(), size)
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			// every thumbnail pixel averages the box of source pixels it covers
+			box := image.Rect(
+				b.Min.X+x*b.Dx()/w, b.Min.Y+y*b.Dy()/h,
+				b.Min.X+(x+1)*b.Dx()/w, b.Min.Y+(y+1)*b.Dy()/h,
+			)
+
+			dst.Set(x, y, average(src, box))
+		}
+	}
+
+	var buf bytes.Buffer
+
+	err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: quality})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fit returns the dimensions of a w x h image scaled down to fit into size x size, at least one pixel each
+func fit(w, h, size int) (fitW, fitH int) {
+	if w <= size && h <= size {
+		return w, h
+	}
+
+	if w >= h {
+		return size, maxInt(1, h*size/w)
+	}
+
+	return maxInt(1, w*size/h), size
+}
+
+func average(img image.Image, box image.Rectangle) color.Color {
+	var r, g, b, a, n uint64
+
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		for x := box.Min.X; x < box.Max.X; x++ {
+			cr, cg, cb, ca := img.At(x, y).RGBA()
+
+			r += uint64(cr)
+			g += uint64(cg)
+			b += uint64(cb)
+			a += uint64(ca)
+			n++
+		}
+	}
+
+	if n == 0 {
+		return color.RGBA64{}
+	}
+
+	return color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,108 @@
+package thumbnail
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// encodePNG returns a w x h PNG image filled with c
+func encodePNG(w, h int, c color.Color) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	_ = png.Encode(&buf, img)
+
+	return buf.Bytes()
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		desc   string
+		data   []byte
+		width  int
+		height int
+	}{
+		{"Landscape", encodePNG(1024, 512, color.White), 256, 128},
+		{"Portrait", encodePNG(300, 1200, color.White), 64, 256},
+		{"Small image is not scaled up", encodePNG(100, 50, color.White), 100, 50},
+		{"Thin image", encodePNG(2000, 1, color.White), 256, 1},
+	}
+
+	for i, tc := range tests {
+		data, err := Generate(tc.data, 256, 1<<22)
+
+		assert.Nilf(t, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		img, err := jpeg.Decode(bytes.NewReader(data))
+
+		assert.Nilf(t, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, image.Pt(tc.width, tc.height), img.Bounds().Size(), "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestGenerate_Average(t *testing.T) {
+	data, err := Generate(encodePNG(512, 512, color.RGBA{R: 200, G: 100, B: 50, A: 255}), 256, 1<<22)
+	assert.Nil(t, err)
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	assert.Nil(t, err)
+
+	r, g, b, _ := img.At(128, 128).RGBA()
+
+	// JPEG is lossy, the color is only compared approximately
+	assert.InDelta(t, 200, r>>8, 4)
+	assert.InDelta(t, 100, g>>8, 4)
+	assert.InDelta(t, 50, b>>8, 4)
+}
+
+func TestGenerate_NotAnImage(t *testing.T) {
+	_, err := Generate([]byte("%PDF-1.7"), 256, 1<<22)
+
+	assert.Equal(t, image.ErrFormat, err)
+}
+
+// pngHeader returns the signature and header chunk of a PNG image which declares w x h pixels, without any pixel data
+func pngHeader(w, h uint32) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], w)
+	binary.BigEndian.PutUint32(ihdr[8:], h)
+	// 8 bit RGBA
+	ihdr[12], ihdr[13] = 8, 6
+
+	var buf bytes.Buffer
+
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	_ = binary.Write(&buf, binary.BigEndian, uint32(13))
+	buf.Write(ihdr)
+	_ = binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(ihdr))
+
+	return buf.Bytes()
+}
+
+func TestGenerate_TooLarge(t *testing.T) {
+	// the image is rejected from its header, before its pixels would be allocated
+	_, err := Generate(pngHeader(100000, 100000), 256, 1<<22)
+
+	assert.Equal(t, ErrTooLarge, err)
+
+	_, err = Generate(encodePNG(2048, 2049, color.White), 256, 2048*2048)
+
+	assert.Equal(t, ErrTooLarge, err)
+}
@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"carAPI/model"
+)
+
+// AttachmentKind returns the kind of attachments with the sniffed contentType,
+// false is returned if attachments of contentType are not accepted
+func AttachmentKind(contentType string) (model.AttachmentKind, bool) {
+	kind, ok := attachmentKinds()[contentType]
+	return kind, ok
+}
+
+// ContentTypes returns all content types accepted as attachments
+func ContentTypes() []string {
+	return []string{"image/jpeg", "image/png", "image/gif", "application/pdf"}
+}
+
+// MaxAttachmentSize returns the maximum size in bytes of attachments of kind
+func MaxAttachmentSize(kind model.AttachmentKind) int64 {
+	if kind == model.AttachmentPhoto {
+		return model.MaxPhotoSize
+	}
+
+	return model.MaxDocumentSize
+}
+
+// attachmentKinds maps the accepted content types to the kind of their attachments
+func attachmentKinds() map[string]model.AttachmentKind {
+	return map[string]model.AttachmentKind{
+		"image/jpeg":      model.AttachmentPhoto,
+		"image/png":       model.AttachmentPhoto,
+		"image/gif":       model.AttachmentPhoto,
+		"application/pdf": model.AttachmentDocument,
+	}
+}
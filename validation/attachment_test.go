@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"carAPI/model"
+)
+
+func TestAttachmentKind(t *testing.T) {
+	tests := []struct {
+		contentType string
+		kind        model.AttachmentKind
+		ok          bool
+	}{
+		{"image/jpeg", model.AttachmentPhoto, true},
+		{"image/png", model.AttachmentPhoto, true},
+		{"application/pdf", model.AttachmentDocument, true},
+		{"text/plain; charset=utf-8", "", false},
+		{"image/webp", "", false},
+	}
+
+	for i, tc := range tests {
+		kind, ok := AttachmentKind(tc.contentType)
+
+		assert.Equalf(t, tc.kind, kind, "Testcase[%v] (%v)", i, tc.contentType)
+
+		assert.Equalf(t, tc.ok, ok, "Testcase[%v] (%v)", i, tc.contentType)
+	}
+
+	// every accepted content type has a kind
+	for _, contentType := range ContentTypes() {
+		_, ok := AttachmentKind(contentType)
+
+		assert.Truef(t, ok, "%v", contentType)
+	}
+}
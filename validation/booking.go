@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+// Booking validates a new booking, its slot must end after it starts and must not be over yet.
+// The car and customer of the booking are checked when it is created.
+func Booking(booking *model.Booking) error {
+	var errs customErrors.InvalidFields
+
+	if booking.CarID == "" {
+		errs = append(errs, required(model.ParamCarID))
+	}
+
+	errs = append(errs, validateEnum(model.ParamKind, string(booking.Kind), BookingKinds())...)
+
+	switch {
+	case booking.Start.IsZero():
+		errs = append(errs, required(model.ParamStart))
+	case booking.End.IsZero():
+		errs = append(errs, required(model.ParamEnd))
+	case !booking.End.After(booking.Start):
+		errs = append(errs, customErrors.FieldError{
+			Path:    path(model.ParamEnd),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must be after %v", model.ParamEnd, model.ParamStart),
+		})
+	case !booking.End.After(time.Now()):
+		errs = append(errs, customErrors.FieldError{
+			Path:    path(model.ParamEnd),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must be in the future", model.ParamEnd),
+		})
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// BookingKinds returns all kinds of bookings
+func BookingKinds() []string {
+	return []string{string(model.BookingTestDrive), string(model.BookingHold)}
+}
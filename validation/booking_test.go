@@ -0,0 +1,43 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func TestBooking(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		desc    string
+		booking model.Booking
+		err     error
+	}{
+		{"Valid test drive", model.Booking{CarID: "car1", Kind: model.BookingTestDrive, Start: tomorrow, End: tomorrow.Add(time.Hour)}, nil},
+		{"Missing fields", model.Booking{}, customErrors.InvalidFields{
+			{Path: "/carId", Code: customErrors.FieldRequired, Message: "carId is required"},
+			{Path: "/kind", Code: customErrors.FieldRequired, Message: "kind is required"},
+			{Path: "/start", Code: customErrors.FieldRequired, Message: "start is required"},
+		}},
+		{"End before start", model.Booking{CarID: "car1", Kind: model.BookingHold, Start: tomorrow, End: tomorrow.Add(-time.Hour)},
+			customErrors.InvalidFields{
+				{Path: "/end", Code: customErrors.FieldOutOfRange, Message: "end must be after start"},
+			}},
+		{"Slot is over", model.Booking{CarID: "car1", Kind: "repair", Start: tomorrow.AddDate(0, 0, -3), End: tomorrow.AddDate(0, 0, -2)},
+			customErrors.InvalidFields{
+				{Path: "/kind", Code: customErrors.FieldInvalid, Message: "repair is not a valid kind", Allowed: []string{"test-drive", "hold"}},
+				{Path: "/end", Code: customErrors.FieldOutOfRange, Message: "end must be in the future"},
+			}},
+	}
+
+	for i, tc := range tests {
+		err := Booking(&tc.booking)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+// Dealership validates the name and the optional city of dealership
+func Dealership(dealership *model.Dealership) error {
+	var errs customErrors.InvalidFields
+
+	if strings.TrimSpace(dealership.Name) == "" {
+		errs = append(errs, required(model.ParamName))
+	}
+
+	errs = append(errs, validateDealershipField(model.ParamName, dealership.Name)...)
+	errs = append(errs, validateDealershipField(model.ParamCity, dealership.City)...)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Transfer validates the dealership a car is transferred to, its existence is checked by the service
+func Transfer(transfer *model.Transfer) error {
+	if transfer.To == "" {
+		return customErrors.InvalidFields{required(model.ParamTo)}
+	}
+
+	return nil
+}
+
+func validateDealershipField(param, value string) []customErrors.FieldError {
+	if len(value) > model.MaxDealershipFieldLength {
+		return []customErrors.FieldError{{
+			Path:    path(param),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must not be longer than %v characters", param, model.MaxDealershipFieldLength),
+		}}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func TestDealership(t *testing.T) {
+	tests := []struct {
+		desc       string
+		dealership model.Dealership
+		err        error
+	}{
+		{"Valid dealership", model.Dealership{Name: "North", City: "Hamburg"}, nil},
+		{"Without city", model.Dealership{Name: "North"}, nil},
+		{"Missing name", model.Dealership{Name: " "}, customErrors.InvalidFields{
+			{Path: "/name", Code: customErrors.FieldRequired, Message: "name is required"},
+		}},
+		{"Too long", model.Dealership{Name: strings.Repeat("n", 51), City: strings.Repeat("c", 51)}, customErrors.InvalidFields{
+			{Path: "/name", Code: customErrors.FieldOutOfRange, Message: "name must not be longer than 50 characters"},
+			{Path: "/city", Code: customErrors.FieldOutOfRange, Message: "city must not be longer than 50 characters"},
+		}},
+	}
+
+	for i, tc := range tests {
+		err := Dealership(&tc.dealership)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	tests := []struct {
+		desc     string
+		transfer model.Transfer
+		err      error
+	}{
+		{"Valid transfer", model.Transfer{To: "d2"}, nil},
+		{"Missing dealership", model.Transfer{}, customErrors.InvalidFields{
+			{Path: "/to", Code: customErrors.FieldRequired, Message: "to is required"},
+		}},
+	}
+
+	for i, tc := range tests {
+		err := Transfer(&tc.transfer)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
@@ -0,0 +1,17 @@
+package validation
+
+import "carAPI/model"
+
+type Catalog interface {
+	// Brands returns the names of all brands a car can have
+	Brands() ([]string, error)
+
+	// FuelTypes returns the names of all fuel types a car can have
+	FuelTypes() ([]string, error)
+
+	// EngineRules returns the engine rules of all fuel types, keyed by fuel type
+	EngineRules() (map[string]model.EngineRule, error)
+
+	// GetTrim fetches the trim with given ID, with the brand of its model and its default engine
+	GetTrim(id string) (*model.Trim, error)
+}
@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+// Customer validates every field of customer, all invalid fields are reported at once
+func Customer(customer *model.Customer) error {
+	var errs customErrors.InvalidFields
+
+	errs = append(errs, validateCustomerName(customer.Name)...)
+	errs = append(errs, validateEmail(customer.Email)...)
+	errs = append(errs, validatePhone(customer.Phone)...)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Order validates the amounts and payment status of an order, its customer and car are checked when the order is created.
+// The discount must not exceed the agreed price, an empty payment status is pending.
+func Order(order *model.Order) error {
+	var errs customErrors.InvalidFields
+
+	errs = append(errs, validateEnum(model.ParamCurrency, order.Currency, Currencies())...)
+
+	if order.AgreedPrice <= 0 {
+		errs = append(errs, customErrors.FieldError{
+			Path:    path(model.ParamAgreedPrice),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must be positive", model.ParamAgreedPrice),
+		})
+	} else if order.Discount < 0 || order.Discount > order.AgreedPrice {
+		errs = append(errs, customErrors.FieldError{
+			Path:    path(model.ParamDiscount),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must be between 0 and the %v", model.ParamDiscount, model.ParamAgreedPrice),
+		})
+	}
+
+	if order.Tax < 0 {
+		errs = append(errs, customErrors.FieldError{
+			Path:    path(model.ParamTax),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must not be negative", model.ParamTax),
+		})
+	}
+
+	if order.PaymentStatus != "" {
+		errs = append(errs, validateEnum(model.ParamPaymentStatus, string(order.PaymentStatus), PaymentStatuses())...)
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// PaymentStatuses returns all payment statuses an order can have
+func PaymentStatuses() []string {
+	return []string{string(model.PaymentPending), string(model.PaymentDeposit), string(model.PaymentPaid), string(model.PaymentRefunded)}
+}
+
+// OrderStatuses returns all statuses an order can have
+func OrderStatuses() []string {
+	return []string{string(model.OrderOpen), string(model.OrderCompleted), string(model.OrderCancelled)}
+}
+
+func validateCustomerName(name string) []customErrors.FieldError {
+	if strings.TrimSpace(name) == "" {
+		return []customErrors.FieldError{required(model.ParamName)}
+	}
+
+	if len(name) > model.MaxCustomerNameLength {
+		return []customErrors.FieldError{{
+			Path:    path(model.ParamName),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must not be longer than %v characters", model.ParamName, model.MaxCustomerNameLength),
+		}}
+	}
+
+	return nil
+}
+
+// validateEmail only accepts a bare address, e.g. jane@example.com, not a name with an address
+func validateEmail(email string) []customErrors.FieldError {
+	if email == "" {
+		return []customErrors.FieldError{required(model.ParamEmail)}
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return []customErrors.FieldError{{
+			Path:    path(model.ParamEmail),
+			Code:    customErrors.FieldInvalid,
+			Message: fmt.Sprintf("%v is not a valid email address", email),
+		}}
+	}
+
+	return nil
+}
+
+// validatePhone validates the optional phone number, it may contain digits, spaces, dashes and a leading +
+func validatePhone(phone string) []customErrors.FieldError {
+	if phone == "" {
+		return nil
+	}
+
+	valid := len(phone) <= model.MaxPhoneLength
+
+	for i, c := range phone {
+		if !(c >= '0' && c <= '9' || c == ' ' || c == '-' || c == '+' && i == 0) {
+			valid = false
+		}
+	}
+
+	if !valid {
+		return []customErrors.FieldError{{
+			Path:    path(model.ParamPhone),
+			Code:    customErrors.FieldInvalid,
+			Message: fmt.Sprintf("%v is not a valid phone number", model.ParamPhone),
+		}}
+	}
+
+	return nil
+}
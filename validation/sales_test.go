@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+)
+
+func TestCustomer(t *testing.T) {
+	tests := []struct {
+		desc     string
+		customer model.Customer
+		err      error
+	}{
+		{"Valid customer", model.Customer{Name: "Jane Doe", Email: "jane@example.com", Phone: "+49 30 1234-5678"}, nil},
+		{"Without phone", model.Customer{Name: "Jane Doe", Email: "jane@example.com"}, nil},
+		{"Missing fields", model.Customer{}, customErrors.InvalidFields{
+			{Path: "/name", Code: customErrors.FieldRequired, Message: "name is required"},
+			{Path: "/email", Code: customErrors.FieldRequired, Message: "email is required"},
+		}},
+		{"Invalid email and phone", model.Customer{Name: "Jane Doe", Email: "Jane <jane@example.com>", Phone: "call me"},
+			customErrors.InvalidFields{
+				{Path: "/email", Code: customErrors.FieldInvalid, Message: "Jane <jane@example.com> is not a valid email address"},
+				{Path: "/phone", Code: customErrors.FieldInvalid, Message: "phone is not a valid phone number"},
+			}},
+	}
+
+	for i, tc := range tests {
+		err := Customer(&tc.customer)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		desc  string
+		order model.Order
+		err   error
+	}{
+		{"Valid order", model.Order{Currency: "EUR", AgreedPrice: 8999900, Discount: 100000, Tax: 1710000, PaymentStatus: model.PaymentPaid}, nil},
+		{"Without payment status", model.Order{Currency: "EUR", AgreedPrice: 8999900}, nil},
+		{"Missing fields", model.Order{}, customErrors.InvalidFields{
+			{Path: "/currency", Code: customErrors.FieldRequired, Message: "currency is required"},
+			{Path: "/agreedPrice", Code: customErrors.FieldOutOfRange, Message: "agreedPrice must be positive"},
+		}},
+		{"Invalid amounts", model.Order{Currency: "EUR", AgreedPrice: 100, Discount: 200, Tax: -1, PaymentStatus: "overdue"},
+			customErrors.InvalidFields{
+				{Path: "/discount", Code: customErrors.FieldOutOfRange, Message: "discount must be between 0 and the agreedPrice"},
+				{Path: "/tax", Code: customErrors.FieldOutOfRange, Message: "tax must not be negative"},
+				{Path: "/paymentStatus", Code: customErrors.FieldInvalid, Message: "overdue is not a valid paymentStatus",
+					Allowed: []string{"pending", "deposit-paid", "paid", "refunded"}},
+			}},
+	}
+
+	for i, tc := range tests {
+		err := Order(&tc.order)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
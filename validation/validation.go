@@ -0,0 +1,527 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/model"
+	"carAPI/vin"
+)
+
+// Car validates every field of car, including its engine, brands, fuel types and trims are validated against the catalog.
+// Engine params missing from car are taken from its trim.
+// All invalid fields are reported at once in a customErrors.InvalidFields, nil is returned for a valid car.
+// Any other error is returned when the catalog cannot be read.
+func Car(car *model.Car, catalog Catalog) error {
+	brands, err := catalog.Brands()
+	if err != nil {
+		return err
+	}
+
+	fuelTypes, err := catalog.FuelTypes()
+	if err != nil {
+		return err
+	}
+
+	engineRules, err := catalog.EngineRules()
+	if err != nil {
+		return err
+	}
+
+	defaults, trimErrs, err := validateTrim(car, catalog)
+	if err != nil {
+		return err
+	}
+
+	fuelTypeErrs := validateEnum(model.ParamFuelType, car.FuelType, fuelTypes)
+	engine := car.Engine.WithDefaults(defaults)
+
+	var errs customErrors.InvalidFields
+
+	errs = append(errs, validateName(car.Name)...)
+	errs = append(errs, validateYearOfManufacture(car.YearOfManufacture)...)
+	errs = append(errs, validateEnum(model.ParamBrand, car.Brand, brands)...)
+	errs = append(errs, fuelTypeErrs...)
+	errs = append(errs, trimErrs...)
+	errs = append(errs, validateVIN(car)...)
+
+	// the engine rules of a fuel type only apply once the fuel type itself is valid
+	if len(fuelTypeErrs) == 0 {
+		errs = append(errs, validateEngine(car.FuelType, engine, engineRules)...)
+	} else {
+		errs = append(errs, validateEngineValues(engine)...)
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// CarModel validates a model, its brand is validated against the catalog
+func CarModel(carModel *model.CarModel, catalog Catalog) error {
+	brands, err := catalog.Brands()
+	if err != nil {
+		return err
+	}
+
+	var errs customErrors.InvalidFields
+
+	errs = append(errs, validateCatalogName(carModel.Name)...)
+	errs = append(errs, validateEnum(model.ParamBrand, carModel.Brand, brands)...)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Trim validates the name and default engine of a trim, its model is checked when the trim is created
+func Trim(trim *model.Trim) error {
+	var errs customErrors.InvalidFields
+
+	errs = append(errs, validateCatalogName(trim.Name)...)
+	errs = append(errs, validateEngineValues(trim.Engine)...)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Status validates the status a car is transitioned to
+func Status(status model.Status) error {
+	errs := validateEnum(model.ParamStatus, string(status), Statuses())
+	if len(errs) != 0 {
+		return customErrors.InvalidFields(errs)
+	}
+
+	return nil
+}
+
+// Statuses returns all statuses a car can have
+func Statuses() []string {
+	return []string{
+		string(model.StatusInTransit), string(model.StatusOnLot), string(model.StatusReserved),
+		string(model.StatusSold), string(model.StatusReturned),
+	}
+}
+
+// PriceChange validates a change of the price of a car, the minimum price is optional but must not exceed the list price
+func PriceChange(change *model.PriceChange) error {
+	var errs []customErrors.FieldError
+
+	errs = append(errs, validateEnum(model.ParamCurrency, change.Currency, Currencies())...)
+
+	switch {
+	case change.List == 0:
+		errs = append(errs, required(model.ParamListPrice))
+	case change.List < 0:
+		errs = append(errs, customErrors.FieldError{
+			Path:    path(model.ParamListPrice),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must be positive", model.ParamListPrice),
+		})
+	case change.Minimum < 0 || change.Minimum > change.List:
+		errs = append(errs, customErrors.FieldError{
+			Path:    path(model.ParamMinimumPrice),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must be between 0 and the %v price", model.ParamMinimumPrice, model.ParamListPrice),
+		})
+	}
+
+	errs = append(errs, validateReason(change.Reason)...)
+
+	if len(errs) != 0 {
+		return customErrors.InvalidFields(errs)
+	}
+
+	return nil
+}
+
+// Currencies returns the ISO 4217 codes of the currencies cars can be priced in
+func Currencies() []string {
+	return []string{
+		"AED", "AUD", "BRL", "CAD", "CHF", "CNY", "CZK", "DKK", "EUR", "GBP", "HKD",
+		"INR", "JPY", "KRW", "MXN", "NOK", "NZD", "PLN", "SEK", "SGD", "USD", "ZAR",
+	}
+}
+
+func validateReason(reason string) []customErrors.FieldError {
+	if strings.TrimSpace(reason) == "" {
+		return []customErrors.FieldError{required(model.ParamReason)}
+	}
+
+	if len(reason) > model.MaxReasonLength {
+		return []customErrors.FieldError{{
+			Path:    path(model.ParamReason),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must not be longer than %v characters", model.ParamReason, model.MaxReasonLength),
+		}}
+	}
+
+	return nil
+}
+
+// CatalogName validates the name of a brand or fuel type
+func CatalogName(name string) error {
+	errs := validateCatalogName(name)
+	if len(errs) != 0 {
+		return customErrors.InvalidFields(errs)
+	}
+
+	return nil
+}
+
+// FuelType validates the name and engine rule of fuelType, the engine rule is required unless an existing fuel type
+// is updated, which keeps its rule if it is left out
+func FuelType(fuelType *model.FuelType, update bool) error {
+	errs := validateCatalogName(fuelType.Name)
+
+	switch {
+	case fuelType.Engine != nil:
+		errs = append(errs, validateEngineRule(*fuelType.Engine)...)
+	case !update:
+		errs = append(errs, customErrors.FieldError{
+			Path:    "/" + model.ParamEngine,
+			Code:    customErrors.FieldRequired,
+			Message: fmt.Sprintf("%v is required, it lists the required and forbidden engine params of the fuel type", model.ParamEngine),
+		})
+	}
+
+	if len(errs) != 0 {
+		return customErrors.InvalidFields(errs)
+	}
+
+	return nil
+}
+
+// validateEngineRule validates that rule only lists engine params, and every param at most once
+func validateEngineRule(rule model.EngineRule) []customErrors.FieldError {
+	var errs []customErrors.FieldError
+
+	listed := make(map[string]bool)
+
+	for _, list := range []struct {
+		member string
+		params []string
+	}{{"required", rule.Required}, {"forbidden", rule.Forbidden}} {
+		for i, param := range list.params {
+			p := fmt.Sprintf("/%v/%v/%v", model.ParamEngine, list.member, i)
+
+			if !isEngineParam(param) {
+				errs = append(errs, customErrors.FieldError{Path: p, Code: customErrors.FieldInvalid,
+					Message: fmt.Sprintf("%v is not an engine param", param), Allowed: engineParams()})
+
+				continue
+			}
+
+			if listed[param] {
+				errs = append(errs, customErrors.FieldError{Path: p, Code: customErrors.FieldInvalid,
+					Message: fmt.Sprintf("%v is listed more than once", param)})
+			}
+
+			listed[param] = true
+		}
+	}
+
+	return errs
+}
+
+func isEngineParam(param string) bool {
+	for _, p := range engineParams() {
+		if param == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateCatalogName(name string) []customErrors.FieldError {
+	if name == "" {
+		return []customErrors.FieldError{required(model.ParamName)}
+	}
+
+	if len(name) > model.MaxCatalogNameLength {
+		return []customErrors.FieldError{{
+			Path:    path(model.ParamName),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must not be longer than %v characters", model.ParamName, model.MaxCatalogNameLength),
+		}}
+	}
+
+	return nil
+}
+
+// path returns the JSON pointer of a car param, engine params are nested under /engine
+func path(param string) string {
+	specs := []string{model.ParamPower, model.ParamTorque, model.ParamTransmission, model.ParamGears, model.ParamDrivetrain}
+
+	for _, p := range append(engineParams(), specs...) {
+		if param == p {
+			return "/engine/" + param
+		}
+	}
+
+	return "/" + param
+}
+
+func required(param string) customErrors.FieldError {
+	return customErrors.FieldError{
+		Path:    path(param),
+		Code:    customErrors.FieldRequired,
+		Message: fmt.Sprintf("%v is required", param),
+	}
+}
+
+func validateName(name string) []customErrors.FieldError {
+	if name == "" {
+		return []customErrors.FieldError{required(model.ParamName)}
+	}
+
+	return nil
+}
+
+func validateYearOfManufacture(year int) []customErrors.FieldError {
+	if year == 0 {
+		return []customErrors.FieldError{required(model.ParamYearOfManufacture)}
+	}
+
+	if year < model.MinYear || year > time.Now().Year() {
+		return []customErrors.FieldError{{
+			Path:    path(model.ParamYearOfManufacture),
+			Code:    customErrors.FieldOutOfRange,
+			Message: fmt.Sprintf("%v must be between %v and the current year", model.ParamYearOfManufacture, model.MinYear),
+		}}
+	}
+
+	return nil
+}
+
+func validateEnum(param, value string, allowed []string) []customErrors.FieldError {
+	if value == "" {
+		return []customErrors.FieldError{required(param)}
+	}
+
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+
+	return []customErrors.FieldError{{
+		Path:    path(param),
+		Code:    customErrors.FieldInvalid,
+		Message: fmt.Sprintf("%v is not a valid %v", value, param),
+		Allowed: allowed,
+	}}
+}
+
+// engineParams lists the engine params in the order they are reported
+func engineParams() []string {
+	return []string{
+		model.ParamDisplacement, model.ParamNoOfCylinders, model.ParamRange,
+		model.ParamBatteryCapacity, model.ParamElectricRange, model.ParamTankCapacity, model.ParamNoOfMotors,
+	}
+}
+
+// engineValues maps the engine params to their values in engine
+func engineValues(engine model.Engine) map[string]float64 {
+	return map[string]float64{
+		model.ParamDisplacement:    float64(engine.Displacement),
+		model.ParamNoOfCylinders:   float64(engine.NoOfCylinders),
+		model.ParamRange:           float64(engine.Range),
+		model.ParamBatteryCapacity: engine.BatteryCapacity,
+		model.ParamElectricRange:   float64(engine.ElectricRange),
+		model.ParamTankCapacity:    engine.TankCapacity,
+		model.ParamNoOfMotors:      float64(engine.NoOfMotors),
+	}
+}
+
+func validateEngineValues(engine model.Engine) []customErrors.FieldError {
+	errs := make([]customErrors.FieldError, 0)
+	values := engineValues(engine)
+
+	// engine values can never be negative, YAML bodies can also set the float values to .nan or .inf
+	for _, param := range engineParams() {
+		if math.IsNaN(values[param]) || math.IsInf(values[param], 0) {
+			errs = append(errs, customErrors.FieldError{
+				Path:    path(param),
+				Code:    customErrors.FieldInvalid,
+				Message: fmt.Sprintf("%v must be a finite number", param),
+			})
+		} else if values[param] < 0 {
+			errs = append(errs, customErrors.FieldError{
+				Path:    path(param),
+				Code:    customErrors.FieldOutOfRange,
+				Message: fmt.Sprintf("%v must not be negative", param),
+			})
+		}
+	}
+
+	errs = append(errs, validateEngineSpecs(engine)...)
+
+	return errs
+}
+
+// Transmissions returns the transmission types an engine can have
+func Transmissions() []string {
+	return []string{model.TransmissionManual, model.TransmissionAutomatic, model.TransmissionDCT, model.TransmissionCVT}
+}
+
+// Drivetrains returns the drivetrains an engine can have
+func Drivetrains() []string {
+	return []string{model.DrivetrainFWD, model.DrivetrainRWD, model.DrivetrainAWD}
+}
+
+// validateEngineSpecs validates the optional specs of engine, a zero value means the spec is not known
+func validateEngineSpecs(engine model.Engine) []customErrors.FieldError {
+	var errs []customErrors.FieldError
+
+	errs = append(errs, validateOptionalRange(model.ParamPower, engine.Power, model.MaxPower)...)
+	errs = append(errs, validateOptionalRange(model.ParamTorque, engine.Torque, model.MaxTorque)...)
+	errs = append(errs, validateOptionalRange(model.ParamGears, engine.Gears, model.MaxGears)...)
+
+	if engine.Transmission != "" {
+		errs = append(errs, validateEnum(model.ParamTransmission, engine.Transmission, Transmissions())...)
+	}
+
+	if engine.Drivetrain != "" {
+		errs = append(errs, validateEnum(model.ParamDrivetrain, engine.Drivetrain, Drivetrains())...)
+	}
+
+	// a CVT has no fixed gears
+	if engine.Transmission == model.TransmissionCVT && engine.Gears != 0 {
+		errs = append(errs, customErrors.FieldError{
+			Path:    path(model.ParamGears),
+			Code:    customErrors.FieldForbidden,
+			Message: fmt.Sprintf("%v is not allowed for %v transmissions", model.ParamGears, model.TransmissionCVT),
+		})
+	}
+
+	return errs
+}
+
+func validateOptionalRange(param string, value, maxValue int) []customErrors.FieldError {
+	if value == 0 || (value > 0 && value <= maxValue) {
+		return nil
+	}
+
+	return []customErrors.FieldError{{
+		Path:    path(param),
+		Code:    customErrors.FieldOutOfRange,
+		Message: fmt.Sprintf("%v must be between 1 and %v", param, maxValue),
+	}}
+}
+
+// validateEngine validates the engine a car of fuelType ends up with, after defaults of its trim are applied,
+// against the engine rule of fuelType in rules
+func validateEngine(fuelType string, engine model.Engine, rules map[string]model.EngineRule) []customErrors.FieldError {
+	errs := validateEngineValues(engine)
+	values := engineValues(engine)
+
+	rule, ok := rules[fuelType]
+	if !ok {
+		return errs
+	}
+
+	for _, param := range rule.Required {
+		if values[param] == 0 {
+			errs = append(errs, required(param))
+		}
+	}
+
+	for _, param := range rule.Forbidden {
+		if values[param] != 0 {
+			errs = append(errs, customErrors.FieldError{
+				Path:    path(param),
+				Code:    customErrors.FieldForbidden,
+				Message: fmt.Sprintf("%v is not allowed for %v cars", param, fuelType),
+			})
+		}
+	}
+
+	return errs
+}
+
+// validateVIN validates the optional VIN of car, its manufacturer and model year are cross-checked against the car
+func validateVIN(car *model.Car) []customErrors.FieldError {
+	if car.VIN == "" {
+		return nil
+	}
+
+	info, err := vin.Decode(car.VIN)
+	if err != nil {
+		return []customErrors.FieldError{invalidVIN(err.Error())}
+	}
+
+	var errs []customErrors.FieldError
+
+	if info.Manufacturer != "" && info.Manufacturer != car.Brand {
+		errs = append(errs, invalidVIN(fmt.Sprintf("vin was assigned to brand %v", info.Manufacturer)))
+	}
+
+	// the model year of a car is either its year of manufacture or the year after
+	if len(info.ModelYears) != 0 && car.YearOfManufacture != 0 {
+		matches := false
+
+		for _, year := range info.ModelYears {
+			if car.YearOfManufacture == year || car.YearOfManufacture == year-1 {
+				matches = true
+			}
+		}
+
+		if !matches {
+			errs = append(errs, invalidVIN(fmt.Sprintf("model year of vin does not match %v", model.ParamYearOfManufacture)))
+		}
+	}
+
+	return errs
+}
+
+func invalidVIN(message string) customErrors.FieldError {
+	return customErrors.FieldError{
+		Path:    path(model.ParamVIN),
+		Code:    customErrors.FieldInvalid,
+		Message: message,
+	}
+}
+
+// validateTrim checks the trim of car exists and belongs to the brand of the car,
+// the default engine of the trim is returned, a zero engine if the car has no valid trim
+func validateTrim(car *model.Car, catalog Catalog) (model.Engine, []customErrors.FieldError, error) {
+	if car.TrimID == "" {
+		return model.Engine{}, nil, nil
+	}
+
+	trim, err := catalog.GetTrim(car.TrimID)
+	if errors.Is(err, customErrors.ErrNotFound) {
+		return model.Engine{}, []customErrors.FieldError{{
+			Path:    path(model.ParamTrimID),
+			Code:    customErrors.FieldInvalid,
+			Message: fmt.Sprintf("trim %v not exists", car.TrimID),
+		}}, nil
+	}
+
+	if err != nil {
+		return model.Engine{}, nil, err
+	}
+
+	if trim.Brand != car.Brand {
+		return model.Engine{}, []customErrors.FieldError{{
+			Path:    path(model.ParamTrimID),
+			Code:    customErrors.FieldInvalid,
+			Message: fmt.Sprintf("trim %v belongs to brand %v", car.TrimID, trim.Brand),
+		}}, nil
+	}
+
+	return trim.Engine, nil, nil
+}
@@ -0,0 +1,357 @@
+package validation
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	customErrors "carAPI/custom-errors"
+	"carAPI/mocks"
+	"carAPI/model"
+)
+
+// engineRules returns the engine rules of the fuel types of the tests, LPG has none
+func engineRules() map[string]model.EngineRule {
+	return map[string]model.EngineRule{
+		model.ValueElectric: {
+			Required:  []string{model.ParamRange},
+			Forbidden: []string{model.ParamDisplacement, model.ParamNoOfCylinders, model.ParamElectricRange, model.ParamTankCapacity},
+		},
+		model.ValuePetrol: {
+			Required:  []string{model.ParamDisplacement, model.ParamNoOfCylinders},
+			Forbidden: []string{model.ParamBatteryCapacity, model.ParamElectricRange, model.ParamNoOfMotors},
+		},
+		model.ValueDiesel: {
+			Required:  []string{model.ParamDisplacement, model.ParamNoOfCylinders},
+			Forbidden: []string{model.ParamBatteryCapacity, model.ParamElectricRange, model.ParamNoOfMotors},
+		},
+		model.ValuePlugInHybrid: {
+			Required: []string{model.ParamDisplacement, model.ParamNoOfCylinders,
+				model.ParamBatteryCapacity, model.ParamElectricRange, model.ParamNoOfMotors},
+		},
+		model.ValueHydrogen: {
+			Required:  []string{model.ParamRange, model.ParamTankCapacity, model.ParamNoOfMotors},
+			Forbidden: []string{model.ParamDisplacement, model.ParamNoOfCylinders, model.ParamElectricRange},
+		},
+	}
+}
+
+func TestCar(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCatalog(mockCtrl)
+
+	c.EXPECT().Brands().Return([]string{"Tesla", "Ferrari", "BMW", "Porsche"}, nil).AnyTimes()
+	c.EXPECT().FuelTypes().Return([]string{"Electric", "Petrol", "Diesel", "Plug-in Hybrid", "Hydrogen", "LPG"}, nil).AnyTimes()
+	c.EXPECT().EngineRules().Return(engineRules(), nil).AnyTimes()
+
+	tests := []struct {
+		desc string
+		car  model.Car
+		err  error
+	}{
+		{
+			"Valid electric car",
+			model.Car{Name: "Roadster", YearOfManufacture: 2000, Brand: "Tesla", FuelType: "Electric", Engine: model.Engine{Range: 400}},
+			nil,
+		},
+		{
+			"Valid petrol car",
+			model.Car{Name: "F8", YearOfManufacture: 2020, Brand: "Ferrari", FuelType: "Petrol",
+				Engine: model.Engine{Displacement: 3900, NoOfCylinders: 8}},
+			nil,
+		},
+		{
+			"All fields reported at once",
+			model.Car{YearOfManufacture: 1800, Brand: "Pesla", FuelType: "Diesel", Engine: model.Engine{NoOfCylinders: -1}},
+			customErrors.InvalidFields{
+				{Path: "/name", Code: customErrors.FieldRequired, Message: "name is required"},
+				{Path: "/yearOfManufacture", Code: customErrors.FieldOutOfRange,
+					Message: "yearOfManufacture must be between 1866 and the current year"},
+				{Path: "/brand", Code: customErrors.FieldInvalid, Message: "Pesla is not a valid brand",
+					Allowed: []string{"Tesla", "Ferrari", "BMW", "Porsche"}},
+				{Path: "/engine/noOfCylinders", Code: customErrors.FieldOutOfRange, Message: "noOfCylinders must not be negative"},
+				{Path: "/engine/displacement", Code: customErrors.FieldRequired, Message: "displacement is required"},
+			},
+		},
+		{
+			"Fuel type without engine requirements",
+			model.Car{Name: "Panda", YearOfManufacture: 2010, Brand: "BMW", FuelType: "LPG"},
+			nil,
+		},
+		{
+			"Valid plug-in hybrid car",
+			model.Car{Name: "X5 xDrive50e", YearOfManufacture: 2023, Brand: "BMW", FuelType: "Plug-in Hybrid",
+				Engine: model.Engine{Displacement: 2998, NoOfCylinders: 6, BatteryCapacity: 25.7, ElectricRange: 110, NoOfMotors: 1}},
+			nil,
+		},
+		{
+			"Missing battery of plug-in hybrid car",
+			model.Car{Name: "X5 xDrive50e", YearOfManufacture: 2023, Brand: "BMW", FuelType: "Plug-in Hybrid",
+				Engine: model.Engine{Displacement: 2998, NoOfCylinders: 6, NoOfMotors: 1}},
+			customErrors.InvalidFields{
+				{Path: "/engine/batteryCapacity", Code: customErrors.FieldRequired, Message: "batteryCapacity is required"},
+				{Path: "/engine/electricRange", Code: customErrors.FieldRequired, Message: "electricRange is required"},
+			},
+		},
+		{
+			"Forbidden engine params of hydrogen car",
+			model.Car{Name: "Mirai", YearOfManufacture: 2021, Brand: "Tesla", FuelType: "Hydrogen",
+				Engine: model.Engine{Displacement: 1500, Range: 650, TankCapacity: 5.6, NoOfMotors: 1}},
+			customErrors.InvalidFields{
+				{Path: "/engine/displacement", Code: customErrors.FieldForbidden, Message: "displacement is not allowed for Hydrogen cars"},
+			},
+		},
+		{
+			"Valid engine specs",
+			model.Car{Name: "M3", YearOfManufacture: 2021, Brand: "BMW", FuelType: "Petrol",
+				Engine: model.Engine{Displacement: 2993, NoOfCylinders: 6, Power: 510, Torque: 650,
+					Transmission: "Automatic", Gears: 8, Drivetrain: "AWD"}},
+			nil,
+		},
+		{
+			"Invalid engine specs",
+			model.Car{Name: "M3", YearOfManufacture: 2021, Brand: "BMW", FuelType: "Petrol",
+				Engine: model.Engine{Displacement: 2993, NoOfCylinders: 6, Power: 5000, Transmission: "CVT", Gears: 6, Drivetrain: "4WD"}},
+			customErrors.InvalidFields{
+				{Path: "/engine/power", Code: customErrors.FieldOutOfRange, Message: "power must be between 1 and 2000"},
+				{Path: "/engine/drivetrain", Code: customErrors.FieldInvalid, Message: "4WD is not a valid drivetrain",
+					Allowed: []string{"FWD", "RWD", "AWD"}},
+				{Path: "/engine/gears", Code: customErrors.FieldForbidden, Message: "gears is not allowed for CVT transmissions"},
+			},
+		},
+		{
+			"Valid VIN",
+			model.Car{Name: "Model 3", YearOfManufacture: 2019, Brand: "Tesla", FuelType: "Electric", VIN: "5YJ3E1EA6LF000001",
+				Engine: model.Engine{Range: 400}},
+			nil,
+		},
+		{
+			"VIN of another brand and model year",
+			model.Car{Name: "i4", YearOfManufacture: 2022, Brand: "BMW", FuelType: "Electric", VIN: "5YJ3E1EA6LF000001",
+				Engine: model.Engine{Range: 400}},
+			customErrors.InvalidFields{
+				{Path: "/vin", Code: customErrors.FieldInvalid, Message: "vin was assigned to brand Tesla"},
+				{Path: "/vin", Code: customErrors.FieldInvalid, Message: "model year of vin does not match yearOfManufacture"},
+			},
+		},
+		{
+			"Invalid VIN",
+			model.Car{Name: "Model 3", YearOfManufacture: 2020, Brand: "Tesla", FuelType: "Electric", VIN: "5YJ3E1EA7LF000001",
+				Engine: model.Engine{Range: 400}},
+			customErrors.InvalidFields{
+				{Path: "/vin", Code: customErrors.FieldInvalid, Message: "check digit of vin does not match"},
+			},
+		},
+		{
+			"Engine rules skipped for invalid fuel type",
+			model.Car{Name: "Roadster", YearOfManufacture: 2000, Brand: "Tesla", FuelType: "Steam", Engine: model.Engine{TankCapacity: -1}},
+			customErrors.InvalidFields{
+				{Path: "/fuelType", Code: customErrors.FieldInvalid, Message: "Steam is not a valid fuelType",
+					Allowed: []string{"Electric", "Petrol", "Diesel", "Plug-in Hybrid", "Hydrogen", "LPG"}},
+				{Path: "/engine/tankCapacity", Code: customErrors.FieldOutOfRange, Message: "tankCapacity must not be negative"},
+			},
+		},
+		{
+			"Engine values which are not finite",
+			model.Car{Name: "X5 xDrive50e", YearOfManufacture: 2023, Brand: "BMW", FuelType: "Plug-in Hybrid",
+				Engine: model.Engine{Displacement: 2998, NoOfCylinders: 6, BatteryCapacity: math.NaN(), ElectricRange: 110,
+					TankCapacity: math.Inf(-1), NoOfMotors: 1}},
+			customErrors.InvalidFields{
+				{Path: "/engine/batteryCapacity", Code: customErrors.FieldInvalid, Message: "batteryCapacity must be a finite number"},
+				{Path: "/engine/tankCapacity", Code: customErrors.FieldInvalid, Message: "tankCapacity must be a finite number"},
+			},
+		},
+		{
+			"Missing range of electric car",
+			model.Car{Name: "Roadster", YearOfManufacture: 2000, Brand: "Tesla", FuelType: "Electric"},
+			customErrors.InvalidFields{
+				{Path: "/engine/range", Code: customErrors.FieldRequired, Message: "range is required"},
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		err := Car(&tc.car, c)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestCar_Trim(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCatalog(mockCtrl)
+
+	c.EXPECT().Brands().Return([]string{"Tesla", "BMW"}, nil).AnyTimes()
+	c.EXPECT().FuelTypes().Return([]string{"Electric", "Petrol"}, nil).AnyTimes()
+	c.EXPECT().EngineRules().Return(engineRules(), nil).AnyTimes()
+	c.EXPECT().GetTrim("t1").Return(&model.Trim{ID: "t1", Brand: "Tesla", Engine: model.Engine{Range: 500}}, nil).AnyTimes()
+	c.EXPECT().GetTrim("t2").Return(nil, customErrors.TrimNotExists())
+	c.EXPECT().GetTrim("t3").Return(nil, errors.New("DB error"))
+
+	tests := []struct {
+		desc string
+		car  model.Car
+		err  error
+	}{
+		{
+			"Engine taken from trim",
+			model.Car{Name: "Model 3", YearOfManufacture: 2020, Brand: "Tesla", FuelType: "Electric", TrimID: "t1"},
+			nil,
+		},
+		{
+			"Trim of another brand",
+			model.Car{Name: "i4", YearOfManufacture: 2020, Brand: "BMW", FuelType: "Electric", TrimID: "t1"},
+			customErrors.InvalidFields{
+				{Path: "/trimId", Code: customErrors.FieldInvalid, Message: "trim t1 belongs to brand Tesla"},
+				{Path: "/engine/range", Code: customErrors.FieldRequired, Message: "range is required"},
+			},
+		},
+		{
+			"Trim not exists",
+			model.Car{Name: "Model 3", YearOfManufacture: 2020, Brand: "Tesla", FuelType: "Electric", TrimID: "t2",
+				Engine: model.Engine{Range: 400}},
+			customErrors.InvalidFields{
+				{Path: "/trimId", Code: customErrors.FieldInvalid, Message: "trim t2 not exists"},
+			},
+		},
+		{
+			"Catalog error",
+			model.Car{Name: "Model 3", YearOfManufacture: 2020, Brand: "Tesla", FuelType: "Electric", TrimID: "t3"},
+			errors.New("DB error"),
+		},
+	}
+
+	for i, tc := range tests {
+		err := Car(&tc.car, c)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestCar_CatalogError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	c := mocks.NewMockCatalog(mockCtrl)
+
+	c.EXPECT().Brands().Return(nil, errors.New("DB error"))
+
+	err := Car(&model.Car{}, c)
+
+	assert.Equal(t, errors.New("DB error"), err)
+}
+
+func TestCatalogName(t *testing.T) {
+	tests := []struct {
+		desc string
+		name string
+		err  error
+	}{
+		{"Valid name", "Audi", nil},
+		{"Empty name", "", customErrors.InvalidFields{
+			{Path: "/name", Code: customErrors.FieldRequired, Message: "name is required"},
+		}},
+		{"Too long name", strings.Repeat("a", 51), customErrors.InvalidFields{
+			{Path: "/name", Code: customErrors.FieldOutOfRange, Message: "name must not be longer than 50 characters"},
+		}},
+	}
+
+	for i, tc := range tests {
+		err := CatalogName(tc.name)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestFuelType(t *testing.T) {
+	tests := []struct {
+		desc     string
+		fuelType model.FuelType
+		update   bool
+		err      error
+	}{
+		{"Valid fuel type", model.FuelType{Name: "LPG", Engine: &model.EngineRule{
+			Required: []string{"displacement"}, Forbidden: []string{"range"}}}, false, nil},
+		{"Empty engine rule", model.FuelType{Name: "LPG", Engine: &model.EngineRule{}}, false, nil},
+		{"Missing engine rule", model.FuelType{Name: "LPG"}, false, customErrors.InvalidFields{
+			{Path: "/engine", Code: customErrors.FieldRequired,
+				Message: "engine is required, it lists the required and forbidden engine params of the fuel type"},
+		}},
+		{"Engine rule kept on update", model.FuelType{Name: "LPG"}, true, nil},
+		{"Invalid engine rule", model.FuelType{Engine: &model.EngineRule{
+			Required: []string{"displacement", "wheels"}, Forbidden: []string{"displacement"}}}, true, customErrors.InvalidFields{
+			{Path: "/name", Code: customErrors.FieldRequired, Message: "name is required"},
+			{Path: "/engine/required/1", Code: customErrors.FieldInvalid, Message: "wheels is not an engine param",
+				Allowed: engineParams()},
+			{Path: "/engine/forbidden/0", Code: customErrors.FieldInvalid, Message: "displacement is listed more than once"},
+		}},
+	}
+
+	for i, tc := range tests {
+		err := FuelType(&tc.fuelType, tc.update)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	tests := []struct {
+		desc string
+		trim model.Trim
+		err  error
+	}{
+		{"Valid trim", model.Trim{ModelID: "m1", Name: "Long Range", Engine: model.Engine{Range: 500}}, nil},
+		{"Invalid trim", model.Trim{Engine: model.Engine{Displacement: -1}}, customErrors.InvalidFields{
+			{Path: "/name", Code: customErrors.FieldRequired, Message: "name is required"},
+			{Path: "/engine/displacement", Code: customErrors.FieldOutOfRange, Message: "displacement must not be negative"},
+		}},
+	}
+
+	for i, tc := range tests {
+		err := Trim(&tc.trim)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestPriceChange(t *testing.T) {
+	tests := []struct {
+		desc   string
+		change model.PriceChange
+		err    error
+	}{
+		{"Valid price", model.PriceChange{Price: model.Price{Currency: "EUR", List: 8999900, Minimum: 8500000}, Reason: "spring sale"}, nil},
+		{"Without minimum price", model.PriceChange{Price: model.Price{Currency: "USD", List: 8999900}, Reason: "new stock"}, nil},
+		{"Missing fields", model.PriceChange{}, customErrors.InvalidFields{
+			{Path: "/currency", Code: customErrors.FieldRequired, Message: "currency is required"},
+			{Path: "/list", Code: customErrors.FieldRequired, Message: "list is required"},
+			{Path: "/reason", Code: customErrors.FieldRequired, Message: "reason is required"},
+		}},
+		{"Negative list price", model.PriceChange{Price: model.Price{Currency: "EUR", List: -1}, Reason: "typo"}, customErrors.InvalidFields{
+			{Path: "/list", Code: customErrors.FieldOutOfRange, Message: "list must be positive"},
+		}},
+		{"Minimum above list price", model.PriceChange{Price: model.Price{Currency: "EUR", List: 100, Minimum: 200}, Reason: "typo"},
+			customErrors.InvalidFields{
+				{Path: "/minimum", Code: customErrors.FieldOutOfRange, Message: "minimum must be between 0 and the list price"},
+			}},
+		{"Unknown currency", model.PriceChange{Price: model.Price{Currency: "eur", List: 100}, Reason: strings.Repeat("a", 256)},
+			customErrors.InvalidFields{
+				{Path: "/currency", Code: customErrors.FieldInvalid, Message: "eur is not a valid currency", Allowed: Currencies()},
+				{Path: "/reason", Code: customErrors.FieldOutOfRange, Message: "reason must not be longer than 255 characters"},
+			}},
+	}
+
+	for i, tc := range tests {
+		err := PriceChange(&tc.change)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
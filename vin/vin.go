@@ -0,0 +1,153 @@
+// Package vin validates and decodes vehicle identification numbers as defined by ISO 3779.
+package vin
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Length is the number of characters of a VIN
+const Length = 17
+
+// checkDigitIndex is the index of the check digit in a VIN
+const checkDigitIndex = 8
+
+// yearCodeIndex is the index of the model year code in a VIN
+const yearCodeIndex = 9
+
+// yearCodes lists the model year codes starting at 1980, the codes repeat every 30 years
+const yearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+const firstModelYear = 1980
+
+var (
+	ErrFormat     = errors.New("vin must be 17 digits and capital letters except I, O and Q")
+	ErrCheckDigit = errors.New("check digit of vin does not match")
+)
+
+// Info is the information decoded from a VIN
+type Info struct {
+	// WMI is the world manufacturer identifier, the first three characters of the VIN
+	WMI string
+
+	// Manufacturer is the brand the WMI is assigned to, it is empty for unknown WMIs
+	Manufacturer string
+
+	// ModelYears are the model years up to next year the year code of the VIN can stand for,
+	// it is empty if the VIN has no valid year code
+	ModelYears []int
+}
+
+// Validate checks the format of v, the check digit is checked for VINs of North American vehicles,
+// as it is only mandatory there
+func Validate(v string) error {
+	if len(v) != Length {
+		return ErrFormat
+	}
+
+	for i := 0; i < len(v); i++ {
+		if _, ok := value(v[i]); !ok {
+			return ErrFormat
+		}
+	}
+
+	if NorthAmerican(v) && CheckDigit(v) != v[checkDigitIndex] {
+		return ErrCheckDigit
+	}
+
+	return nil
+}
+
+// Decode validates v and decodes its manufacturer and model year
+func Decode(v string) (Info, error) {
+	err := Validate(v)
+	if err != nil {
+		return Info{}, err
+	}
+
+	wmi := v[:3]
+
+	return Info{
+		WMI:          wmi,
+		Manufacturer: manufacturers()[wmi],
+		ModelYears:   modelYears(v[yearCodeIndex]),
+	}, nil
+}
+
+// NorthAmerican reports whether v was assigned to a vehicle made in North America
+func NorthAmerican(v string) bool {
+	return v != "" && v[0] >= '1' && v[0] <= '5'
+}
+
+// CheckDigit computes the North American check digit of v, v must have a valid format
+func CheckDigit(v string) byte {
+	weights := [Length]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+	sum := 0
+
+	for i := 0; i < Length; i++ {
+		n, _ := value(v[i])
+		sum += n * weights[i]
+	}
+
+	rem := sum % 11
+	if rem == 10 {
+		return 'X'
+	}
+
+	return byte('0' + rem)
+}
+
+// value transliterates a VIN character to its numeric value, false is returned for characters not allowed in a VIN
+func value(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'A' && c <= 'H':
+		return int(c-'A') + 1, true
+	case c >= 'J' && c <= 'N':
+		return int(c-'J') + 1, true
+	case c == 'P':
+		return 7, true
+	case c == 'R':
+		return 9, true
+	case c >= 'S' && c <= 'Z':
+		return int(c-'S') + 2, true
+	}
+
+	return 0, false
+}
+
+func modelYears(code byte) []int {
+	i := strings.IndexByte(yearCodes, code)
+	if i < 0 {
+		return nil
+	}
+
+	var years []int
+
+	for year := firstModelYear + i; year <= time.Now().Year()+1; year += len(yearCodes) {
+		years = append(years, year)
+	}
+
+	return years
+}
+
+// manufacturers maps the WMIs of the brands we sell to their brand
+func manufacturers() map[string]string {
+	return map[string]string{
+		"5YJ": "Tesla",
+		"7SA": "Tesla",
+		"LRW": "Tesla",
+		"XP7": "Tesla",
+		"ZFF": "Ferrari",
+		"WBA": "BMW",
+		"WBS": "BMW",
+		"WBY": "BMW",
+		"4US": "BMW",
+		"5UX": "BMW",
+		"5YM": "BMW",
+		"WP0": "Porsche",
+		"WP1": "Porsche",
+	}
+}
@@ -0,0 +1,50 @@
+package vin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc string
+		vin  string
+		err  error
+	}{
+		{"Valid North American VIN", "1M8GDM9AXKP042788", nil},
+		{"Valid Tesla VIN", "5YJ3E1EA6LF000001", nil},
+		{"European VIN without check digit", "ZFF79ALA4J0000004", nil},
+		{"Too short", "5YJ3E1EA6LF00000", ErrFormat},
+		{"Letter O", "5YJ3E1EA6LF0O0001", ErrFormat},
+		{"Lower case", "5yj3e1ea6lf000001", ErrFormat},
+		{"Wrong check digit", "5YJ3E1EA7LF000001", ErrCheckDigit},
+	}
+
+	for i, tc := range tests {
+		err := Validate(tc.vin)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		desc string
+		vin  string
+		info Info
+		err  error
+	}{
+		{"Known manufacturer", "5YJ3E1EA6LF000001", Info{WMI: "5YJ", Manufacturer: "Tesla", ModelYears: []int{1990, 2020}}, nil},
+		{"Unknown manufacturer", "1M8GDM9AXKP042788", Info{WMI: "1M8", ModelYears: []int{1989, 2019}}, nil},
+		{"Invalid VIN", "5YJ3E1EA7LF000001", Info{}, ErrCheckDigit},
+	}
+
+	for i, tc := range tests {
+		info, err := Decode(tc.vin)
+
+		assert.Equalf(t, tc.err, err, "Testcase[%v] (%v)", i, tc.desc)
+
+		assert.Equalf(t, tc.info, info, "Testcase[%v] (%v)", i, tc.desc)
+	}
+}
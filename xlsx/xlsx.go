@@ -0,0 +1,161 @@
+// Package xlsx writes single-sheet Office Open XML spreadsheets one row at a time.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the media type of XLSX files
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+
+	rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" ` +
+		`Target="xl/workbook.xml"/></Relationships>`
+
+	// workbook is completed by the escaped name of the sheet and workbookEnd
+	workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="`
+	workbookEnd = `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" ` +
+		`Target="worksheets/sheet1.xml"/></Relationships>`
+
+	sheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetEnd = `</sheetData></worksheet>`
+)
+
+// Writer writes the rows of a sheet as they come, the parts of the workbook are written before the first row
+// so that nothing but the current row is held in memory
+type Writer struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	row   int
+}
+
+// NewWriter writes the workbook of a sheet with given name to w and starts the sheet
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", workbook + escape(sheetName) + workbookEnd},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+	}
+
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.WriteString(f, p.content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the sheet is the last part, so that it can be written while the rows come
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := bufio.NewWriter(f)
+
+	_, err = sheet.WriteString(sheetStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{zw: zw, sheet: sheet}, nil
+}
+
+// WriteRow appends a row to the sheet. Strings are written as text, ints and floats as numbers
+// and nil as an empty cell, values of other types are written as text in their default format
+func (w *Writer) WriteRow(values []interface{}) error {
+	w.row++
+
+	_, err := fmt.Fprintf(w.sheet, `<row r="%d">`, w.row)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range values {
+		_, err = w.sheet.WriteString(cell(v))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = w.sheet.WriteString("</row>")
+
+	return err
+}
+
+// Close ends the sheet and the file, it does not close the underlying writer
+func (w *Writer) Close() error {
+	_, err := w.sheet.WriteString(sheetEnd)
+	if err != nil {
+		return err
+	}
+
+	err = w.sheet.Flush()
+	if err != nil {
+		return err
+	}
+
+	return w.zw.Close()
+}
+
+// cell returns the XML of a cell holding v
+func cell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "<c/>"
+	case int:
+		return "<c><v>" + strconv.Itoa(v) + "</v></c>"
+	case int64:
+		return "<c><v>" + strconv.FormatInt(v, 10) + "</v></c>"
+	case float64:
+		return "<c><v>" + strconv.FormatFloat(v, 'f', -1, 64) + "</v></c>"
+	case string:
+		return `<c t="inlineStr"><is><t xml:space="preserve">` + escape(v) + "</t></is></c>"
+	default:
+		return cell(fmt.Sprint(v))
+	}
+}
+
+// escape escapes s for XML text and attribute values, characters XML cannot hold are replaced
+func escape(s string) string {
+	var b strings.Builder
+
+	// writing to a strings.Builder does not fail
+	_ = xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
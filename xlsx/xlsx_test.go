@@ -0,0 +1,48 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, `Cars & "Bikes"`)
+	assert.Nil(t, err)
+
+	assert.Nil(t, w.WriteRow([]interface{}{"name", "range"}))
+	assert.Nil(t, w.WriteRow([]interface{}{"Roadster <2008>", 393, int64(5000000), 53.5, nil, true}))
+	assert.Nil(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+
+	parts := make(map[string]string)
+
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		assert.Nil(t, err)
+
+		content, err := io.ReadAll(rc)
+		assert.Nil(t, err)
+
+		parts[f.Name] = string(content)
+	}
+
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts, "_rels/.rels")
+	assert.Contains(t, parts, "xl/_rels/workbook.xml.rels")
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Cars &amp; &#34;Bikes&#34;" sheetId="1" r:id="rId1"/>`)
+
+	text := func(s string) string { return `<c t="inlineStr"><is><t xml:space="preserve">` + s + `</t></is></c>` }
+
+	assert.Equal(t, sheetStart+
+		`<row r="1">`+text("name")+text("range")+`</row>`+
+		`<row r="2">`+text("Roadster &lt;2008&gt;")+`<c><v>393</v></c><c><v>5000000</v></c><c><v>53.5</v></c><c/>`+text("true")+`</row>`+
+		sheetEnd, parts["xl/worksheets/sheet1.xml"])
+}